@@ -3,6 +3,7 @@ package main
 import (
     "context"
     "log"
+    "net"
     "net/http"
     "os"
     "os/signal"
@@ -11,10 +12,26 @@ import (
 
     "github.com/joho/godotenv"
     "github.com/gorilla/mux"
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/gex"
+    "github.com/ryanhamamura/options-chain-go/internal/market"
     "github.com/ryanhamamura/options-chain-go/internal/api"
     "github.com/ryanhamamura/options-chain-go/internal/models"
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "github.com/ryanhamamura/options-chain-go/internal/portfolio"
+    "github.com/ryanhamamura/options-chain-go/internal/quality"
+    "github.com/ryanhamamura/options-chain-go/internal/realized"
+    "github.com/ryanhamamura/options-chain-go/internal/rpc"
+    "github.com/ryanhamamura/options-chain-go/internal/scenario"
+    "github.com/ryanhamamura/options-chain-go/internal/screener"
+    "github.com/ryanhamamura/options-chain-go/internal/smile"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
+    "github.com/ryanhamamura/options-chain-go/internal/surface"
+    "github.com/ryanhamamura/options-chain-go/internal/unusual"
     "github.com/ryanhamamura/options-chain-go/internal/stream"
     "github.com/ryanhamamura/options-chain-go/internal/tasty"
+    "google.golang.org/grpc"
 )
 
 func main() {
@@ -42,6 +59,56 @@ func main() {
         log.Printf("  Streamer URL: %s", config.StreamerURL)
     }
 
+    // Load pricing models and inputs
+    analyticsConfig, err := analytics.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load analytics configuration: %v", err)
+    }
+    marketConfig, err := market.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load market configuration: %v", err)
+    }
+    marketParams, err := market.NewParams(*marketConfig)
+    if err != nil {
+        log.Fatalf("Failed to load market parameters: %v", err)
+    }
+    surfaceConfig, err := surface.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load surface configuration: %v", err)
+    }
+    smileConfig, err := smile.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load smile configuration: %v", err)
+    }
+    gexConfig, err := gex.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load GEX configuration: %v", err)
+    }
+    realizedConfig, err := realized.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load realized vol configuration: %v", err)
+    }
+    screenConfig, err := screener.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load screener configuration: %v", err)
+    }
+    unusualConfig, err := unusual.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load unusual activity configuration: %v", err)
+    }
+    qualityConfig, err := quality.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load quality configuration: %v", err)
+    }
+    scenarioConfig, err := scenario.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load scenario configuration: %v", err)
+    }
+    portfolioConfig, err := portfolio.LoadConfig()
+    if err != nil {
+        log.Fatalf("Failed to load portfolio configuration: %v", err)
+    }
+
     ctx, cancel := context.WithCancel(context.Background())
     defer cancel()
 
@@ -98,27 +165,182 @@ func main() {
 
     // Create WebSocket manager for our frontend
     wsManager := stream.NewManager()
+    wsManager.SetDefaultRate(config.WSDefaultRate)
+
+    // Latest chain per underlying, shared by the HTTP and gRPC servers
+    chains := store.NewChainStore()
+
+    // Flags crossed quotes and quotes that break no-arbitrage bounds
+    validator := quality.NewValidator(chains, *qualityConfig, marketParams)
+
+    // Fills in Greeks the feed does not provide, pricing underlyings with
+    // American models in the background
+    engine := analytics.NewEngine(*analyticsConfig, marketParams)
+    go engine.Run(ctx)
+
+    // Refits the stored chains' smiles for fair-value vols, which chain
+    // updates are stamped with
+    smiles := smile.NewFitter(chains, marketParams, *smileConfig)
+    go smiles.Run(ctx)
+
+    // Realized vol from daily candles, against the chains' implied vol
+    realizedVol, err := realized.NewService(chains, *realizedConfig)
+    if err != nil {
+        log.Fatalf("Failed to load realized vol history: %v", err)
+    }
+    client.SetCandleHandler(realizedVol.AddCandle)
+
+    // Daily candles are requested from a start fixed at startup, so every
+    // request for an underlying's candles is the same subscription
+    candleStart := realizedVol.HistoryStart().UnixMilli()
+
+    // Unusual activity from time and sale prints and chain volume
+    activity, err := unusual.NewDetector(*unusualConfig, func(a unusual.Alert) {
+        wsManager.Publish(stream.ChannelUnusual, a.Underlying, unusual.NewMessage(a))
+    })
+    if err != nil {
+        log.Fatalf("Failed to load volume history: %v", err)
+    }
+    client.SetTimeAndSaleHandler(activity.AddTrade)
+
+    // Live quotes of the spreads clients subscribe to, requesting any legs
+    // the feed does not carry yet until the last subscriber leaves
+    legSubscriptions := func(contracts []string) []tasty.DXSubscription {
+        var subs []tasty.DXSubscription
+        for _, contract := range contracts {
+            subs = append(subs, tasty.OptionSubscriptions(contract)...)
+        }
+        return subs
+    }
+    spreads := strategy.NewSpreads(chains, engine, func(q strategy.SpreadQuote) {
+        wsManager.Publish(stream.ChannelSpread, q.ID, strategy.NewSpreadMessage(q))
+    }, func(contracts []string) {
+        if err := client.AddSubscriptions(legSubscriptions(contracts)); err != nil {
+            log.Printf("Failed to subscribe to spread legs: %v", err)
+        }
+    }, func(contracts []string) {
+        if err := client.RemoveSubscriptions(legSubscriptions(contracts)); err != nil {
+            log.Printf("Failed to unsubscribe from spread legs: %v", err)
+        }
+    })
+    wsManager.SetSpreadSource(spreads)
+
+    // Positions valued from the live chains, with Greeks beta-weighted to
+    // the benchmark; underlyings and contracts of new positions are
+    // requested from the feed along with their daily candles
+    holdingSubscriptions := func(underlying string, contracts []string) []tasty.DXSubscription {
+        subs := append(tasty.OptionSubscriptions(underlying),
+            tasty.DXSubscription{Type: "Candle", Symbol: underlying + "{=1d}", FromTime: candleStart})
+        return append(subs, legSubscriptions(contracts)...)
+    }
+    holdings, err := portfolio.NewPortfolio(chains, realizedVol, engine, *portfolioConfig, func(s portfolio.Summary) {
+        wsManager.Publish(stream.ChannelPortfolio, s.Benchmark, portfolio.NewMessage(s))
+    }, func(underlying string, contracts []string) {
+        if err := client.AddSubscriptions(holdingSubscriptions(underlying, contracts)); err != nil {
+            log.Printf("Failed to subscribe to portfolio %s: %v", underlying, err)
+        }
+    }, func(underlying string, contracts []string) {
+        if err := client.RemoveSubscriptions(holdingSubscriptions(underlying, contracts)); err != nil {
+            log.Printf("Failed to unsubscribe from portfolio %s: %v", underlying, err)
+        }
+    })
+    if err != nil {
+        log.Fatalf("Failed to load portfolio: %v", err)
+    }
+
+    // Coalesce feed updates before they are priced and reach the store
+    // and the manager
+    conflator := stream.NewConflator(func(chain models.OptionChain) {
+        validator.Apply(&chain)
+        engine.Enrich(&chain)
+        smiles.Apply(&chain)
+        engine.Probabilities(&chain)
+        now := time.Now()
+        forwards := marketParams.Forwards(chain, now)
+        chain.Metrics = analytics.ChainMetrics(chain, forwards, now)
+        chain.Moves = analytics.ExpectedMoves(chain, forwards, now)
+        realizedVol.Observe(chain)
+        activity.Observe(chain)
+        chains.Put(chain)
+        wsManager.BroadcastOptionChain(chain)
+        spreads.Update(chain)
+        holdings.Update(chain)
+    })
+    go conflator.Run(ctx)
+
+    // Republish volatility surfaces built from the stored chains of
+    // underlyings clients subscribe to
+    surfaces := surface.NewService(chains, surfaceConfig.Interval, func(s surface.Surface) {
+        wsManager.Publish(stream.ChannelSurface, s.Symbol, surface.NewMessage(s))
+    }, func(symbol string) bool {
+        return wsManager.FeedSubscribed(stream.ChannelSurface, symbol)
+    })
+    go surfaces.Run(ctx)
+
+    // Dealer positioning from the stored chains' open interest
+    positioning := gex.NewService(chains, *gexConfig, marketParams)
+
+    // Multi-leg position analysis, valued with the pricing engine
+    strategies := strategy.NewAnalyzer(chains, engine)
+
+    // Positions repriced under spot, vol and time shocks
+    scenarios, err := scenario.NewEngine(chains, engine, *scenarioConfig)
+    if err != nil {
+        log.Fatalf("Failed to load scenario presets: %v", err)
+    }
+
+    // Contract screens over the stored chains; saved screens stream their
+    // matches as they change
+    screen := screener.NewScreener(chains, realizedVol)
+    screens := screener.NewScreens(screen, screenConfig.Interval, func(u screener.Update) {
+        wsManager.Publish(stream.ChannelScreen, u.Name, screener.NewMessage(u))
+    })
+    go screens.Run(ctx)
+    go holdings.Run(ctx)
 
     // Create router and handler
     r := mux.NewRouter()
-    handler := api.NewHandler(wsManager)
+    handler := api.NewHandler(api.Services{
+        WSManager: wsManager,
+        Chains:    chains,
+        Market:    marketParams,
+        Surfaces:  surfaces,
+        Smiles:    smiles,
+        GEX:       positioning,
+        Realized:  realizedVol,
+        Strategy:  strategies,
+        Screener:  screen,
+        Screens:   screens,
+        Unusual:   activity,
+        Quality:   validator,
+        Scenario:  scenarios,
+        Portfolio: holdings,
+    })
     api.SetupRoutes(r, handler)
 
+    // Create gRPC server sharing the same chain store
+    grpcServer := grpc.NewServer()
+    optionsv1.RegisterChainServiceServer(grpcServer, rpc.NewServer(chains, wsManager))
+
     // Subscribe to market data for specific options
     subscriptions := []tasty.DXSubscription{
         {Type: "Quote", Symbol: "SPY"},
         {Type: "Greeks", Symbol: "SPY"},
         {Type: "Trade", Symbol: "SPY"},
         {Type: "Summary", Symbol: "SPY"},
+        {Type: "TimeAndSale", Symbol: "SPY"},
+        {Type: "Candle", Symbol: "SPY{=1d}", FromTime: candleStart},
     }
     if err := client.Subscribe(ctx, 1, subscriptions); err != nil {
         log.Fatalf("Failed to subscribe: %v", err)
     }
+    holdings.Watch()
 
     // Start reading market data with automatic reconnection
     client.StartReading(ctx, func(chain models.OptionChain) {
-        // Broadcast the transformed data to all connected frontend clients
-        wsManager.BroadcastOptionChain(chain)
+        // Hand the transformed data to the conflator, which publishes it
+        // to the connected frontend clients
+        conflator.Push(chain)
     })
 
     // Create server with timeouts from config
@@ -143,6 +365,19 @@ func main() {
         }
     }()
 
+    // Start gRPC server in goroutine
+    grpcPort := getEnvOrDefault("GRPC_PORT", "9090")
+    grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+    if err != nil {
+        log.Fatalf("Failed to listen on gRPC port: %v", err)
+    }
+    go func() {
+        log.Printf("gRPC server starting on port %s", grpcPort)
+        if err := grpcServer.Serve(grpcListener); err != nil {
+            log.Fatalf("gRPC server error: %v", err)
+        }
+    }()
+
     // Wait for shutdown signal
     <-shutdown
     log.Println("Shutting down gracefully...")
@@ -155,10 +390,20 @@ func main() {
     if err := server.Shutdown(shutdownCtx); err != nil {
         log.Printf("Server shutdown error: %v", err)
     }
+
+    // Stop gRPC server, cutting off open streams
+    grpcServer.Stop()
     
     // Cancel main context to stop client operations
     cancel()
 
+    if err := realizedVol.Save(); err != nil {
+        log.Printf("Error saving IV history: %v", err)
+    }
+    if err := activity.Save(); err != nil {
+        log.Printf("Error saving volume history: %v", err)
+    }
+
     log.Println("Server stopped")
 }
 
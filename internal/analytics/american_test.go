@@ -0,0 +1,88 @@
+package analytics
+
+import (
+    "math"
+    "testing"
+)
+
+// TestBjerksundStenslandPrice checks American calls against the
+// Bjerksund-Stensland 2002 table in Haug, The Complete Guide to Option
+// Pricing Formulas (2nd ed.): K = 100, r = 10% and cost of carry b = 0
+func TestBjerksundStenslandPrice(t *testing.T) {
+    tests := []struct {
+        spot, vol, t float64
+        want         float64
+    }{
+        {90, 0.15, 0.1, 0.0205}, {100, 0.15, 0.1, 1.8757}, {110, 0.15, 0.1, 10.0000},
+        {90, 0.25, 0.1, 0.3151}, {100, 0.25, 0.1, 3.1256}, {110, 0.25, 0.1, 10.3725},
+        {90, 0.35, 0.1, 0.9479}, {100, 0.35, 0.1, 4.3746}, {110, 0.35, 0.1, 11.1578},
+        {90, 0.15, 0.5, 0.8099}, {100, 0.15, 0.5, 4.0628}, {110, 0.15, 0.5, 10.7898},
+        {90, 0.25, 0.5, 2.7180}, {100, 0.25, 0.5, 6.7661}, {110, 0.25, 0.5, 12.9814},
+        {90, 0.35, 0.5, 4.9665}, {100, 0.35, 0.5, 9.4608}, {110, 0.35, 0.5, 15.5137},
+    }
+    for _, tt := range tests {
+        p := Params{Spot: tt.spot, Strike: 100, T: tt.t, Rate: 0.10, Dividend: 0.10, Vol: tt.vol, Call: true}
+        if got := BjerksundStenslandPrice(p); math.Abs(got-tt.want) > 5e-5 {
+            t.Errorf("S=%v vol=%v T=%v: price = %.4f, want %.4f", tt.spot, tt.vol, tt.t, got, tt.want)
+        }
+    }
+}
+
+// TestAmericanPut checks American puts against the finite difference
+// values in Longstaff and Schwartz (2001), table 1: K = 40, r = 6%
+func TestAmericanPut(t *testing.T) {
+    tests := []struct {
+        spot, vol, t float64
+        want         float64
+    }{
+        {36, 0.2, 1, 4.478},
+        {36, 0.2, 2, 4.840},
+        {36, 0.4, 1, 7.101},
+        {36, 0.4, 2, 8.508},
+        {40, 0.2, 1, 2.314},
+        {44, 0.2, 1, 1.110},
+    }
+    for _, tt := range tests {
+        p := Params{Spot: tt.spot, Strike: 40, T: tt.t, Rate: 0.06, Vol: tt.vol}
+        european := BSMPrice(p)
+        if got := BinomialPrice(p, 1000); math.Abs(got-tt.want) > 0.01 {
+            t.Errorf("S=%v vol=%v T=%v: binomial = %.4f, want %.3f", tt.spot, tt.vol, tt.t, got, tt.want)
+        }
+        // Bjerksund-Stensland is a lower bound, close under the tree
+        if got := BjerksundStenslandPrice(p); got > tt.want || got < tt.want-0.05 || got < european {
+            t.Errorf("S=%v vol=%v T=%v: Bjerksund-Stensland = %.4f, want just under %.3f", tt.spot, tt.vol, tt.t, got, tt.want)
+        }
+    }
+}
+
+func TestAmericanCallWithoutDividendsIsEuropean(t *testing.T) {
+    p := Params{Spot: 100, Strike: 95, T: 0.5, Rate: 0.05, Vol: 0.25, Call: true}
+    european := BSMPrice(p)
+    if got := BjerksundStenslandPrice(p); math.Abs(got-european) > 1e-9 {
+        t.Errorf("Bjerksund-Stensland = %.6f, want the European %.6f", got, european)
+    }
+    if got := BinomialPrice(p, 1000); math.Abs(got-european) > 0.01 {
+        t.Errorf("binomial = %.4f, want the European %.4f", got, european)
+    }
+}
+
+func TestImpliedVolForAmericanModels(t *testing.T) {
+    // A deep in-the-money American put, whose price carries an early
+    // exercise premium BSM cannot reach
+    p := Params{Spot: 80, Strike: 100, T: 0.5, Rate: 0.08, Vol: 0.30}
+    for _, model := range []Model{ModelBinomial, ModelBjerksundStensland} {
+        t.Run(string(model), func(t *testing.T) {
+            pricer, err := NewPricer(model, 200)
+            if err != nil {
+                t.Fatal(err)
+            }
+            vol, err := ImpliedVolFor(pricer, pricer.Price(p), p)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if math.Abs(vol-p.Vol) > 1e-4 {
+                t.Errorf("ImpliedVolFor = %.6f, want %.6f", vol, p.Vol)
+            }
+        })
+    }
+}
@@ -0,0 +1,65 @@
+package analytics
+
+import "math"
+
+// BinomialPrice prices an American option on a Cox-Ross-Rubinstein tree
+// with the given number of steps. The last step is valued with Black-
+// Scholes-Merton instead of the payoff, which removes most of the odd-even
+// oscillation of plain trees so that finite-difference Greeks are smooth.
+// Discrete dividends follow the escrowed dividend model: the tree models
+// the spot net of the dividends still to be paid, and their present value
+// is added back at each node to get the stock price used for early
+// exercise.
+func BinomialPrice(p Params, steps int) float64 {
+    if p.T <= 0 || p.Vol <= 0 || steps < 1 {
+        return math.Max(intrinsic(p), discountedIntrinsic(p))
+    }
+
+    dt := p.T / float64(steps)
+    u := math.Exp(p.Vol * math.Sqrt(dt))
+    d := 1 / u
+    disc := math.Exp(-p.Rate * dt)
+    up := (math.Exp((p.Rate-p.Dividend)*dt) - d) / (u - d)
+    down := 1 - up
+    if up <= 0 || up >= 1 {
+        // The step is too coarse for the carry; fall back to the European value
+        return math.Max(intrinsic(p), BSMPrice(p))
+    }
+
+    base := p.Spot - presentValue(p.Dividends, 0, p.T, p.Rate)
+    payoff := func(stock float64) float64 {
+        if p.Call {
+            return math.Max(stock-p.Strike, 0)
+        }
+        return math.Max(p.Strike-stock, 0)
+    }
+
+    // Values one step before expiration, indexed by the number of up moves
+    last := steps - 1
+    values := make([]float64, steps)
+    european := Params{Strike: p.Strike, T: dt, Rate: p.Rate, Dividend: p.Dividend, Vol: p.Vol, Call: p.Call}
+    pv := presentValue(p.Dividends, float64(last)*dt, p.T, p.Rate)
+    for j := 0; j <= last; j++ {
+        european.Spot = base * math.Pow(u, float64(2*j-last))
+        values[j] = math.Max(BSMPrice(european), payoff(european.Spot+pv))
+    }
+
+    for i := last - 1; i >= 0; i-- {
+        pv := presentValue(p.Dividends, float64(i)*dt, p.T, p.Rate)
+        stock := base * math.Pow(d, float64(i))
+        for j := 0; j <= i; j++ {
+            held := disc * (up*values[j+1] + down*values[j])
+            values[j] = math.Max(held, payoff(stock+pv))
+            stock *= u * u
+        }
+    }
+    return values[0]
+}
+
+// intrinsic is the value of exercising the option now
+func intrinsic(p Params) float64 {
+    if p.Call {
+        return math.Max(p.Spot-p.Strike, 0)
+    }
+    return math.Max(p.Strike-p.Spot, 0)
+}
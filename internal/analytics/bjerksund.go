@@ -0,0 +1,199 @@
+package analytics
+
+import "math"
+
+// BjerksundStenslandPrice prices an American option with the Bjerksund-
+// Stensland (2002) closed-form approximation. Puts are priced through the
+// put-call transformation; discrete dividends use the escrowed dividend
+// model.
+func BjerksundStenslandPrice(p Params) float64 {
+    p = p.escrowed()
+    if p.T <= 0 || p.Vol <= 0 || p.Spot <= 0 || p.Strike <= 0 {
+        return math.Max(intrinsic(p), discountedIntrinsic(p))
+    }
+
+    carry := p.Rate - p.Dividend
+    if p.Call {
+        return bs2002Call(p.Spot, p.Strike, p.T, p.Rate, carry, p.Vol)
+    }
+    return bs2002Call(p.Strike, p.Spot, p.T, p.Rate-carry, -carry, p.Vol)
+}
+
+// bs2002Call prices an American call with cost of carry b using the
+// two-step flat exercise boundary of Bjerksund and Stensland
+func bs2002Call(s, k, t, r, b, vol float64) float64 {
+    if b >= r {
+        // Never optimal to exercise early
+        return BSMPrice(Params{Spot: s, Strike: k, T: t, Rate: r, Dividend: r - b, Vol: vol, Call: true})
+    }
+
+    v2 := vol * vol
+    t1 := (math.Sqrt(5) - 1) / 2 * t
+    beta := (0.5 - b/v2) + math.Sqrt((b/v2-0.5)*(b/v2-0.5)+2*r/v2)
+    bInf := beta / (beta - 1) * k
+    b0 := math.Max(k, r/(r-b)*k)
+    h1 := -(b*t1 + 2*vol*math.Sqrt(t1)) * k * k / ((bInf - b0) * b0)
+    h2 := -(b*t + 2*vol*math.Sqrt(t)) * k * k / ((bInf - b0) * b0)
+    i1 := b0 + (bInf-b0)*(1-math.Exp(h1))
+    i2 := b0 + (bInf-b0)*(1-math.Exp(h2))
+    alpha1 := (i1 - k) * math.Pow(i1, -beta)
+    alpha2 := (i2 - k) * math.Pow(i2, -beta)
+
+    if s >= i2 {
+        return s - k
+    }
+
+    phi := func(gamma, h, i float64) float64 {
+        return bs2002Phi(s, t1, gamma, h, i, r, b, vol)
+    }
+    psi := func(gamma, h float64) float64 {
+        return bs2002Psi(s, t, gamma, h, i2, i1, t1, r, b, vol)
+    }
+    return alpha2*math.Pow(s, beta) -
+        alpha2*phi(beta, i2, i2) +
+        phi(1, i2, i2) -
+        phi(1, i1, i2) -
+        k*phi(0, i2, i2) +
+        k*phi(0, i1, i2) +
+        alpha1*phi(beta, i1, i2) -
+        alpha1*psi(beta, i1) +
+        psi(1, i1) -
+        psi(1, k) -
+        k*psi(0, i1) +
+        k*psi(0, k)
+}
+
+func bs2002Phi(s, t, gamma, h, i, r, b, vol float64) float64 {
+    v2 := vol * vol
+    sqrtT := vol * math.Sqrt(t)
+    lambda := (-r + gamma*b + 0.5*gamma*(gamma-1)*v2) * t
+    d := -(math.Log(s/h) + (b+(gamma-0.5)*v2)*t) / sqrtT
+    kappa := 2*b/v2 + 2*gamma - 1
+    return math.Exp(lambda) * math.Pow(s, gamma) *
+        (normCDF(d) - math.Pow(i/s, kappa)*normCDF(d-2*math.Log(i/s)/sqrtT))
+}
+
+func bs2002Psi(s, t2, gamma, h, i2, i1, t1, r, b, vol float64) float64 {
+    v2 := vol * vol
+    sqrtT1 := vol * math.Sqrt(t1)
+    sqrtT2 := vol * math.Sqrt(t2)
+    drift1 := (b + (gamma-0.5)*v2) * t1
+    drift2 := (b + (gamma-0.5)*v2) * t2
+
+    e1 := (math.Log(s/i1) + drift1) / sqrtT1
+    e2 := (math.Log(i2*i2/(s*i1)) + drift1) / sqrtT1
+    e3 := (math.Log(s/i1) - drift1) / sqrtT1
+    e4 := (math.Log(i2*i2/(s*i1)) - drift1) / sqrtT1
+    f1 := (math.Log(s/h) + drift2) / sqrtT2
+    f2 := (math.Log(i2*i2/(s*h)) + drift2) / sqrtT2
+    f3 := (math.Log(i1*i1/(s*h)) + drift2) / sqrtT2
+    f4 := (math.Log(s*i1*i1/(h*i2*i2)) + drift2) / sqrtT2
+
+    rho := math.Sqrt(t1 / t2)
+    lambda := -r + gamma*b + 0.5*gamma*(gamma-1)*v2
+    kappa := 2*b/v2 + 2*gamma - 1
+    return math.Exp(lambda*t2) * math.Pow(s, gamma) *
+        (bivariateNormCDF(-e1, -f1, rho) -
+            math.Pow(i2/s, kappa)*bivariateNormCDF(-e2, -f2, rho) -
+            math.Pow(i1/s, kappa)*bivariateNormCDF(-e3, -f3, -rho) +
+            math.Pow(i1/i2, kappa)*bivariateNormCDF(-e4, -f4, -rho))
+}
+
+// Gauss-Legendre abscissae and weights on [-1, 1] (positive half) for the
+// bivariate normal integrals
+var (
+    gl6x  = []float64{0.9324695142031522, 0.6612093864662647, 0.2386191860831970}
+    gl6w  = []float64{0.1713244923791705, 0.3607615730481384, 0.4679139345726904}
+    gl12x = []float64{0.9815606342467191, 0.9041172563704750, 0.7699026741943050, 0.5873179542866171, 0.3678314989981802, 0.1252334085114692}
+    gl12w = []float64{0.04717533638651177, 0.1069393259953183, 0.1600783285433464, 0.2031674267230659, 0.2334925365383547, 0.2491470458134029}
+    gl20x = []float64{0.9931285991850949, 0.9639719272779138, 0.9122344282513259, 0.8391169718222188, 0.7463319064601508, 0.6360536807265150, 0.5108670019508271, 0.3737060887154196, 0.2277858511416451, 0.07652652113349733}
+    gl20w = []float64{0.01761400713915212, 0.04060142980038694, 0.06267204833410906, 0.08327674157670475, 0.1019301198172404, 0.1181945319615184, 0.1316886384491766, 0.1420961093183821, 0.1491729864726037, 0.1527533871307259}
+)
+
+// bivariateNormCDF is P(X < a, Y < b) for standard normals with
+// correlation rho, computed with Genz's algorithm
+func bivariateNormCDF(a, b, rho float64) float64 {
+    h, k := -a, -b
+    if rho == 0 {
+        return normCDF(-h) * normCDF(-k)
+    }
+
+    xs, ws := gl20x, gl20w
+    switch {
+    case math.Abs(rho) < 0.3:
+        xs, ws = gl6x, gl6w
+    case math.Abs(rho) < 0.75:
+        xs, ws = gl12x, gl12w
+    }
+
+    hk := h * k
+    var bvn float64
+    if math.Abs(rho) < 0.925 {
+        hs := (h*h + k*k) / 2
+        asr := math.Asin(rho) / 2
+        for i := range xs {
+            for _, sign := range []float64{-1, 1} {
+                sn := math.Sin(asr * (1 + sign*xs[i]))
+                bvn += ws[i] * math.Exp((sn*hk-hs)/(1-sn*sn))
+            }
+        }
+        bvn = bvn*asr/(2*math.Pi) + normCDF(-h)*normCDF(-k)
+        return clamp01(bvn)
+    }
+
+    if rho < 0 {
+        k = -k
+        hk = -hk
+    }
+    if math.Abs(rho) < 1 {
+        as := 1 - rho*rho
+        a := math.Sqrt(as)
+        bs := (h - k) * (h - k)
+        c := (4 - hk) / 8
+        d := (12 - hk) / 80
+        asr := -(bs/as + hk) / 2
+        if asr > -100 {
+            bvn = a * math.Exp(asr) * (1 - c*(bs-as)*(1-d*bs)/3 + c*d*as*as)
+        }
+        if hk > -100 {
+            bb := math.Sqrt(bs)
+            sp := math.Sqrt(2*math.Pi) * normCDF(-bb/a)
+            bvn -= math.Exp(-hk/2) * sp * bb * (1 - c*bs*(1-d*bs)/3)
+        }
+        a /= 2
+        var sum float64
+        for i := range xs {
+            for _, sign := range []float64{-1, 1} {
+                x := a * (1 + sign*xs[i])
+                x2 := x * x
+                asr := -(bs/x2 + hk) / 2
+                if asr <= -100 {
+                    continue
+                }
+                sp := 1 + c*x2*(1+5*d*x2)
+                rs := math.Sqrt(1 - x2)
+                ep := math.Exp(-(hk/2)*x2/((1+rs)*(1+rs))) / rs
+                sum += ws[i] * math.Exp(asr) * (sp - ep)
+            }
+        }
+        bvn = (a*sum - bvn) / (2 * math.Pi)
+    }
+
+    if rho > 0 {
+        return clamp01(bvn + normCDF(-math.Max(h, k)))
+    }
+    if h >= k {
+        return clamp01(-bvn)
+    }
+    var l float64
+    if h < 0 {
+        l = normCDF(k) - normCDF(h)
+    } else {
+        l = normCDF(-h) - normCDF(-k)
+    }
+    return clamp01(l - bvn)
+}
+
+func clamp01(x float64) float64 {
+    return math.Max(0, math.Min(1, x))
+}
@@ -0,0 +1,130 @@
+package analytics
+
+import "math"
+
+// Params holds the inputs of an option pricing model
+type Params struct {
+    Spot      float64        // underlying price
+    Strike    float64
+    T         float64        // time to expiration in years
+    Rate      float64        // continuously compounded risk-free rate
+    Dividend  float64        // continuous dividend yield
+    Dividends []CashDividend // discrete dividends, on top of the yield
+    Vol       float64        // annualised volatility
+    Call      bool
+}
+
+// Greeks holds option sensitivities in trading-desk units: theta and charm
+// per calendar day, vega, rho and vanna per vol or rate point (1%), and
+// vomma per vol point squared
+type Greeks struct {
+    Delta float64
+    Gamma float64
+    Theta float64
+    Vega  float64
+    Rho   float64
+
+    Vanna float64 // change in delta per vol point
+    Charm float64 // change in delta per day
+    Vomma float64 // change in vega per vol point
+    Speed float64 // change in gamma per $1 move in the underlying
+}
+
+// d1d2 returns the Black-Scholes-Merton d1 and d2 terms
+func d1d2(p Params) (float64, float64) {
+    sqrtT := math.Sqrt(p.T)
+    d1 := (math.Log(p.Spot/p.Strike) + (p.Rate-p.Dividend+p.Vol*p.Vol/2)*p.T) / (p.Vol * sqrtT)
+    return d1, d1 - p.Vol*sqrtT
+}
+
+// BSMPrice prices a European option with Black-Scholes-Merton, treating
+// discrete dividends with the escrowed dividend model. At or after
+// expiration, or with zero volatility, it returns the discounted intrinsic
+// value of the forward.
+func BSMPrice(p Params) float64 {
+    p = p.escrowed()
+    if p.T <= 0 || p.Vol <= 0 {
+        return discountedIntrinsic(p)
+    }
+    d1, d2 := d1d2(p)
+    spot := p.Spot * math.Exp(-p.Dividend*p.T)
+    strike := p.Strike * math.Exp(-p.Rate*p.T)
+    if p.Call {
+        return spot*normCDF(d1) - strike*normCDF(d2)
+    }
+    return strike*normCDF(-d2) - spot*normCDF(-d1)
+}
+
+// BSMGreeks computes first and second-order Greeks of a European option
+// with Black-Scholes-Merton. It returns zero Greeks at or after expiration.
+func BSMGreeks(p Params) Greeks {
+    p = p.escrowed()
+    if p.T <= 0 || p.Vol <= 0 || p.Spot <= 0 || p.Strike <= 0 {
+        return Greeks{}
+    }
+
+    d1, d2 := d1d2(p)
+    sqrtT := math.Sqrt(p.T)
+    qDisc := math.Exp(-p.Dividend * p.T)
+    rDisc := math.Exp(-p.Rate * p.T)
+    pdf := normPDF(d1)
+
+    gamma := qDisc * pdf / (p.Spot * p.Vol * sqrtT)
+    vega := p.Spot * qDisc * pdf * sqrtT
+    decay := -p.Spot * qDisc * pdf * p.Vol / (2 * sqrtT)
+    charmTerm := qDisc * pdf * (2*(p.Rate-p.Dividend)*p.T - d2*p.Vol*sqrtT) / (2 * p.T * p.Vol * sqrtT)
+
+    var g Greeks
+    if p.Call {
+        g.Delta = qDisc * normCDF(d1)
+        g.Theta = decay - p.Rate*p.Strike*rDisc*normCDF(d2) + p.Dividend*p.Spot*qDisc*normCDF(d1)
+        g.Rho = p.Strike * p.T * rDisc * normCDF(d2)
+        g.Charm = p.Dividend*qDisc*normCDF(d1) - charmTerm
+    } else {
+        g.Delta = -qDisc * normCDF(-d1)
+        g.Theta = decay + p.Rate*p.Strike*rDisc*normCDF(-d2) - p.Dividend*p.Spot*qDisc*normCDF(-d1)
+        g.Rho = -p.Strike * p.T * rDisc * normCDF(-d2)
+        g.Charm = -p.Dividend*qDisc*normCDF(-d1) - charmTerm
+    }
+    g.Gamma = gamma
+    g.Vega = vega
+    g.Vanna = -qDisc * pdf * d2 / p.Vol
+    g.Vomma = vega * d1 * d2 / p.Vol
+    g.Speed = -gamma / p.Spot * (d1/(p.Vol*sqrtT) + 1)
+
+    return g.desk()
+}
+
+// desk converts Greeks from per-unit to trading-desk units
+func (g Greeks) desk() Greeks {
+    g.Theta /= 365
+    g.Vega /= 100
+    g.Rho /= 100
+    g.Vanna /= 100
+    g.Charm /= 365
+    g.Vomma /= 100 * 100
+    return g
+}
+
+// discountedIntrinsic is the value of an option whose time value is gone:
+// the intrinsic value against the forward, discounted to today
+func discountedIntrinsic(p Params) float64 {
+    p = p.escrowed()
+    t := math.Max(p.T, 0)
+    spot := p.Spot * math.Exp(-p.Dividend*t)
+    strike := p.Strike * math.Exp(-p.Rate*t)
+    if p.Call {
+        return math.Max(spot-strike, 0)
+    }
+    return math.Max(strike-spot, 0)
+}
+
+// normCDF is the standard normal cumulative distribution function
+func normCDF(x float64) float64 {
+    return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// normPDF is the standard normal probability density function
+func normPDF(x float64) float64 {
+    return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
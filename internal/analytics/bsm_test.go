@@ -0,0 +1,120 @@
+package analytics
+
+import (
+    "math"
+    "testing"
+)
+
+// Examples from Haug, The Complete Guide to Option Pricing Formulas
+// (2nd ed.), chapter 1, where the cost of carry b is Rate - Dividend, and
+// Hull, Options, Futures, and Other Derivatives, chapter 19, whose values
+// are rounded to three figures.
+
+func TestBSMPrice(t *testing.T) {
+    tests := []struct {
+        name string
+        p    Params
+        want float64
+    }{
+        {"call", Params{Spot: 60, Strike: 65, T: 0.25, Rate: 0.08, Vol: 0.30, Call: true}, 2.1334},
+        {"put with dividend yield", Params{Spot: 100, Strike: 95, T: 0.5, Rate: 0.10, Dividend: 0.05, Vol: 0.20}, 2.4648},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := BSMPrice(tt.p); math.Abs(got-tt.want) > 5e-5 {
+                t.Errorf("BSMPrice = %.4f, want %.4f", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestBSMGreeks(t *testing.T) {
+    tests := []struct {
+        name  string
+        p     Params
+        greek func(Greeks) float64
+        want  float64 // desk units
+        tol   float64
+    }{
+        {
+            name:  "call delta",
+            p:     Params{Spot: 105, Strike: 100, T: 0.5, Rate: 0.10, Dividend: 0.10, Vol: 0.36, Call: true},
+            greek: func(g Greeks) float64 { return g.Delta },
+            want:  0.5946,
+            tol:   5e-5,
+        },
+        {
+            name:  "put delta",
+            p:     Params{Spot: 105, Strike: 100, T: 0.5, Rate: 0.10, Dividend: 0.10, Vol: 0.36},
+            greek: func(g Greeks) float64 { return g.Delta },
+            want:  -0.3566,
+            tol:   5e-5,
+        },
+        {
+            name:  "gamma",
+            p:     Params{Spot: 55, Strike: 60, T: 0.75, Rate: 0.10, Vol: 0.30, Call: true},
+            greek: func(g Greeks) float64 { return g.Gamma },
+            want:  0.0278,
+            tol:   5e-5,
+        },
+        {
+            name:  "vega per vol point",
+            p:     Params{Spot: 49, Strike: 50, T: 20.0 / 52, Rate: 0.05, Vol: 0.20, Call: true},
+            greek: func(g Greeks) float64 { return g.Vega },
+            want:  12.1 / 100,
+            tol:   5e-4,
+        },
+        {
+            name:  "put theta per day",
+            p:     Params{Spot: 430, Strike: 405, T: 0.0833, Rate: 0.07, Dividend: 0.05, Vol: 0.20},
+            greek: func(g Greeks) float64 { return g.Theta },
+            want:  -31.1924 / 365,
+            tol:   5e-7,
+        },
+        {
+            name:  "call rho per rate point",
+            p:     Params{Spot: 72, Strike: 75, T: 1, Rate: 0.09, Vol: 0.19, Call: true},
+            greek: func(g Greeks) float64 { return g.Rho },
+            want:  38.7325 / 100,
+            tol:   5e-7,
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := tt.greek(BSMGreeks(tt.p))
+            if math.Abs(got-tt.want) > tt.tol {
+                t.Errorf("got %.6f, want %.6f", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestBSMGreeksMatchPriceDifferences(t *testing.T) {
+    p := Params{Spot: 100, Strike: 110, T: 0.4, Rate: 0.03, Dividend: 0.01, Vol: 0.25, Call: true}
+    g := BSMGreeks(p)
+    bump := func(f func(*Params, float64), h float64) float64 {
+        up, down := p, p
+        f(&up, h)
+        f(&down, -h)
+        return (BSMPrice(up) - BSMPrice(down)) / (2 * h)
+    }
+
+    delta := bump(func(p *Params, h float64) { p.Spot += h }, 0.01)
+    vega := bump(func(p *Params, h float64) { p.Vol += h }, 1e-4) / 100
+    rho := bump(func(p *Params, h float64) { p.Rate += h }, 1e-4) / 100
+    theta := -bump(func(p *Params, h float64) { p.T += h }, 1e-5) / 365
+
+    for _, c := range []struct {
+        name      string
+        got, want float64
+    }{
+        {"delta", g.Delta, delta},
+        {"vega", g.Vega, vega},
+        {"rho", g.Rho, rho},
+        {"theta", g.Theta, theta},
+    } {
+        if math.Abs(c.got-c.want) > 1e-5 {
+            t.Errorf("%s = %.6f, want %.6f from prices", c.name, c.got, c.want)
+        }
+    }
+}
@@ -0,0 +1,99 @@
+package analytics
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+)
+
+// Config holds the model settings of the pricing engine
+type Config struct {
+    Model         Model            // pricing model for underlyings not listed in Models
+    Models        map[string]Model // pricing model per underlying symbol
+    BinomialSteps int              // depth of binomial trees
+
+    Drift Drift // expected return assumed by probabilities
+    Skew  bool  // take probabilities from the fitted smile where there is one
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        BinomialSteps: getIntOrDefault("BINOMIAL_STEPS", 200),
+        Skew:          getBoolOrDefault("PROBABILITY_SKEW", false),
+    }
+
+    var err error
+    config.Model, err = ParseModel(getEnvOrDefault("PRICING_MODEL", string(ModelEuropean)))
+    if err != nil {
+        return nil, err
+    }
+    config.Models, err = parseModels(os.Getenv("PRICING_MODELS"))
+    if err != nil {
+        return nil, err
+    }
+    config.Drift, err = ParseDrift(getEnvOrDefault("PROBABILITY_DRIFT", string(DriftRiskNeutral)))
+    if err != nil {
+        return nil, err
+    }
+
+    if config.BinomialSteps < 1 || config.BinomialSteps > 10000 {
+        return nil, fmt.Errorf("invalid binomial steps: %d", config.BinomialSteps)
+    }
+
+    return config, nil
+}
+
+// parseModels parses per-underlying models in the form
+// "AAPL:binomial,SPX:european"
+func parseModels(str string) (map[string]Model, error) {
+    models := make(map[string]Model)
+    for _, entry := range strings.Split(str, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        symbol, name, ok := strings.Cut(entry, ":")
+        if !ok || strings.TrimSpace(symbol) == "" {
+            return nil, fmt.Errorf("invalid pricing model entry: %q", entry)
+        }
+        model, err := ParseModel(name)
+        if err != nil {
+            return nil, err
+        }
+        models[strings.ToUpper(strings.TrimSpace(symbol))] = model
+    }
+    return models, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
+
+func getIntOrDefault(key string, defaultValue int) int {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    val, err := strconv.Atoi(str)
+    if err != nil {
+        return defaultValue
+    }
+    return val
+}
+
+func getBoolOrDefault(key string, defaultValue bool) bool {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    val, err := strconv.ParseBool(str)
+    if err != nil {
+        return defaultValue
+    }
+    return val
+}
@@ -0,0 +1,33 @@
+package analytics
+
+import "math"
+
+// CashDividend is a discrete dividend paid before expiration
+type CashDividend struct {
+    T      float64 // time until the ex-dividend date in years
+    Amount float64
+}
+
+// presentValue returns the value at time t of the dividends paid after t
+// and up to expiration, discounted at the risk-free rate
+func presentValue(dividends []CashDividend, t, expiry, rate float64) float64 {
+    var pv float64
+    for _, d := range dividends {
+        if d.T > t && d.T <= expiry {
+            pv += d.Amount * math.Exp(-rate*(d.T-t))
+        }
+    }
+    return pv
+}
+
+// escrowed applies the escrowed dividend model: the spot is reduced by the
+// present value of the discrete dividends paid before expiration, and the
+// remaining model runs on the adjusted spot without them
+func (p Params) escrowed() Params {
+    if len(p.Dividends) == 0 {
+        return p
+    }
+    p.Spot -= presentValue(p.Dividends, 0, p.T, p.Rate)
+    p.Dividends = nil
+    return p
+}
@@ -0,0 +1,480 @@
+package analytics
+
+import (
+    "context"
+    "log"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// MarketParams supplies the rates and dividends the engine prices with
+type MarketParams interface {
+    // RiskFreeRate returns the continuously compounded rate to a horizon
+    // in years
+    RiskFreeRate(t float64) float64
+    // CashDividends returns the continuous dividend yield of an underlying
+    // and its discrete dividends, timed in years from now
+    CashDividends(symbol string, now time.Time) (float64, []CashDividend)
+}
+
+// Engine fills in the model-derived fields of option chains
+type Engine struct {
+    config Config
+    market MarketParams
+    now    func() time.Time
+
+    pricer  Pricer            // default model
+    pricers map[string]Pricer // per-underlying models
+
+    // Enriched contracts by underlying, reused while their inputs are
+    // unchanged
+    cacheMux sync.Mutex
+    cache    map[string]map[string]cachedOption
+
+    // American models are too slow to evaluate on every update, so chains
+    // of underlyings priced with them queue here for the pricing worker.
+    // Its results by underlying and contract, and the inputs they came
+    // from, are kept in american.
+    jobsMux  sync.Mutex
+    jobs     map[string]americanJob
+    wake     chan struct{}
+    american map[string]map[string]cachedOption
+}
+
+// americanJob is a chain waiting to be priced with an American model
+type americanJob struct {
+    chain     models.OptionChain
+    pricer    Pricer
+    yield     float64
+    dividends []CashDividend
+    now       time.Time
+}
+
+// cachedOption is an enriched contract and the inputs it was computed from
+type cachedOption struct {
+    input  models.OptionData
+    spot   float64
+    now    time.Time
+    output models.OptionData
+}
+
+// NewEngine creates a pricing engine with the given model settings and
+// market inputs. Models that cannot be built fall back to
+// Black-Scholes-Merton.
+func NewEngine(config Config, market MarketParams) *Engine {
+    e := &Engine{
+        config:  config,
+        market:  market,
+        now:     time.Now,
+        pricer:  newPricerOrEuropean(config.Model, config.BinomialSteps),
+        pricers:  make(map[string]Pricer),
+        cache:    make(map[string]map[string]cachedOption),
+        jobs:     make(map[string]americanJob),
+        wake:     make(chan struct{}, 1),
+        american: make(map[string]map[string]cachedOption),
+    }
+    for symbol, model := range config.Models {
+        e.pricers[strings.ToUpper(symbol)] = newPricerOrEuropean(model, config.BinomialSteps)
+    }
+    return e
+}
+
+func newPricerOrEuropean(model Model, steps int) Pricer {
+    if model == "" {
+        return europeanPricer{}
+    }
+    pricer, err := NewPricer(model, steps)
+    if err != nil {
+        log.Printf("Pricing with Black-Scholes-Merton instead: %v", err)
+        return europeanPricer{}
+    }
+    return pricer
+}
+
+// pricerFor returns the pricing model of an underlying
+func (e *Engine) pricerFor(symbol string) Pricer {
+    if pricer, ok := e.pricers[strings.ToUpper(symbol)]; ok {
+        return pricer
+    }
+    return e.pricer
+}
+
+// Enrich solves the bid, ask and mid implied volatilities of every contract
+// in the chain and fills in the Greeks the feed did not provide, pricing
+// from the contract's implied volatility (the mid IV when the feed has
+// none) and the underlying price. Second-order Greeks are always model
+// values.
+//
+// Chains are priced with Black-Scholes-Merton here. Underlyings with an
+// American model are also queued for the pricing worker started by Run,
+// and carry its latest values instead once it has priced them, so their
+// model fields lag the feed by one pass of the worker.
+//
+// Time is truncated to the minute so that contracts whose inputs have not
+// changed produce identical values and stay out of stream deltas.
+func (e *Engine) Enrich(chain *models.OptionChain) {
+    if chain.Underlying <= 0 {
+        return
+    }
+    now := e.now().Truncate(time.Minute)
+    pricer := e.pricerFor(chain.Symbol)
+    yield, dividends := e.market.CashDividends(chain.Symbol, now)
+
+    _, european := pricer.(europeanPricer)
+    if !european {
+        e.queue(americanJob{
+            chain:     cloneChain(*chain),
+            pricer:    pricer,
+            yield:     yield,
+            dividends: dividends,
+            now:       now,
+        })
+    }
+
+    e.cacheMux.Lock()
+    defer e.cacheMux.Unlock()
+    e.cache[chain.Symbol] = e.enrichChain(chain, europeanPricer{}, e.cache[chain.Symbol], yield, dividends, now)
+    if european {
+        return
+    }
+    priced := e.american[chain.Symbol]
+    apply := func(option *models.OptionData, input models.OptionData) {
+        if american, ok := priced[option.Symbol]; ok {
+            applyModelFields(option, input, american.output)
+        }
+    }
+    for i := range chain.Calls {
+        apply(&chain.Calls[i], e.cache[chain.Symbol][chain.Calls[i].Symbol].input)
+    }
+    for i := range chain.Puts {
+        apply(&chain.Puts[i], e.cache[chain.Symbol][chain.Puts[i].Symbol].input)
+    }
+}
+
+// enrichChain enriches every contract of a chain with a pricer, reusing
+// the previous results of contracts whose inputs are unchanged, and
+// returns the results for the next call
+func (e *Engine) enrichChain(chain *models.OptionChain, pricer Pricer, prev map[string]cachedOption, yield float64, dividends []CashDividend, now time.Time) map[string]cachedOption {
+    next := make(map[string]cachedOption, len(chain.Calls)+len(chain.Puts))
+    enrich := func(option *models.OptionData) {
+        entry := cachedOption{input: *option, spot: chain.Underlying, now: now}
+        if cached, ok := prev[option.Symbol]; ok && cached.input == entry.input &&
+            cached.spot == entry.spot && cached.now.Equal(now) {
+            *option = cached.output
+        } else {
+            e.enrichOption(option, pricer, chain.Underlying, yield, dividends, now)
+        }
+        entry.output = *option
+        if option.Symbol != "" {
+            next[option.Symbol] = entry
+        }
+    }
+    for i := range chain.Calls {
+        enrich(&chain.Calls[i])
+    }
+    for i := range chain.Puts {
+        enrich(&chain.Puts[i])
+    }
+    return next
+}
+
+// applyModelFields copies the model values of an American pricing onto a
+// contract, keeping the values the feed supplied in its input
+func applyModelFields(option *models.OptionData, input, american models.OptionData) {
+    option.BidIV = american.BidIV
+    option.AskIV = american.AskIV
+    option.MidIV = american.MidIV
+    if input.ImpliedVol <= 0 {
+        option.ImpliedVol = american.ImpliedVol
+    }
+    if !hasGreeks(input) {
+        option.Delta = american.Delta
+        option.Gamma = american.Gamma
+        option.Theta = american.Theta
+        option.Vega = american.Vega
+    }
+    if input.Rho == 0 {
+        option.Rho = american.Rho
+    }
+    option.Vanna = american.Vanna
+    option.Charm = american.Charm
+    option.Vomma = american.Vomma
+    option.Speed = american.Speed
+}
+
+// queue hands a chain to the pricing worker, replacing any chain of the
+// same underlying it has not started on
+func (e *Engine) queue(job americanJob) {
+    e.jobsMux.Lock()
+    e.jobs[job.chain.Symbol] = job
+    e.jobsMux.Unlock()
+    select {
+    case e.wake <- struct{}{}:
+    default:
+    }
+}
+
+// Run prices the chains queued by Enrich with their American models until
+// the context is cancelled. Without it, every chain carries
+// Black-Scholes-Merton values.
+func (e *Engine) Run(ctx context.Context) {
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-e.wake:
+        }
+
+        e.jobsMux.Lock()
+        jobs := e.jobs
+        e.jobs = make(map[string]americanJob)
+        e.jobsMux.Unlock()
+
+        for symbol, job := range jobs {
+            e.cacheMux.Lock()
+            prev := e.american[symbol]
+            e.cacheMux.Unlock()
+
+            // Contracts whose inputs are unchanged since the last pass are
+            // reused; the rest are priced without holding the cache
+            priced := e.enrichChain(&job.chain, job.pricer, prev, job.yield, job.dividends, job.now)
+
+            e.cacheMux.Lock()
+            e.american[symbol] = priced
+            e.cacheMux.Unlock()
+        }
+    }
+}
+
+// cloneChain copies a chain's contracts so it can be priced while the
+// original is enriched
+func cloneChain(chain models.OptionChain) models.OptionChain {
+    chain.Calls = append([]models.OptionData(nil), chain.Calls...)
+    chain.Puts = append([]models.OptionData(nil), chain.Puts...)
+    return chain
+}
+
+// enrichOption solves the implied volatilities and fills in the missing
+// Greeks of a single contract
+func (e *Engine) enrichOption(option *models.OptionData, pricer Pricer, spot, yield float64, dividends []CashDividend, now time.Time) {
+    t, err := YearsToExpiration(option.Expiration, now)
+    if err != nil || t <= 0 {
+        return
+    }
+    p := Params{
+        Spot:      spot,
+        Strike:    option.Strike,
+        T:         t,
+        Rate:      e.market.RiskFreeRate(t),
+        Dividend:  yield,
+        Dividends: dividends,
+        Call:      option.Type == "call",
+    }
+
+    option.BidIV = quoteVol(pricer, option.Bid, p)
+    option.AskIV = quoteVol(pricer, option.Ask, p)
+    option.MidIV = 0
+    if option.Bid > 0 && option.Ask > 0 && !option.HasFlag(models.FlagCrossed) {
+        option.MidIV = quoteVol(pricer, (option.Bid+option.Ask)/2, p)
+    }
+
+    if option.ImpliedVol <= 0 {
+        option.ImpliedVol = option.MidIV
+        if option.ImpliedVol == 0 {
+            option.ImpliedVol = quoteVol(pricer, option.LastPrice, p)
+        }
+        if option.ImpliedVol == 0 {
+            return
+        }
+    }
+    p.Vol = option.ImpliedVol
+    g := pricer.Greeks(p)
+
+    if !hasGreeks(*option) {
+        option.Delta = g.Delta
+        option.Gamma = g.Gamma
+        option.Theta = g.Theta
+        option.Vega = g.Vega
+    }
+    if option.Rho == 0 {
+        option.Rho = g.Rho
+    }
+    option.Vanna = g.Vanna
+    option.Charm = g.Charm
+    option.Vomma = g.Vomma
+    option.Speed = g.Speed
+}
+
+// hasGreeks reports whether the feed supplied first-order Greeks
+func hasGreeks(option models.OptionData) bool {
+    return option.Delta != 0 || option.Gamma != 0 || option.Theta != 0 || option.Vega != 0
+}
+
+// quoteVol solves the implied volatility of a quoted price, returning 0
+// for zero or arbitrage-violating quotes that have none
+func quoteVol(pricer Pricer, price float64, p Params) float64 {
+    vol, err := ImpliedVolFor(pricer, price, p)
+    if err != nil {
+        return 0
+    }
+    return vol
+}
+
+// Value prices a contract of an underlying with the underlying's model at
+// a given spot and time, holding its implied vol (the mid IV when the feed
+// has none). Contracts expired by then are worth their intrinsic value.
+func (e *Engine) Value(symbol string, option models.OptionData, spot float64, at time.Time) float64 {
+    t, err := YearsToExpiration(option.Expiration, at)
+    if err != nil {
+        return 0
+    }
+    yield, dividends := e.market.CashDividends(symbol, at)
+    p := Params{
+        Spot:      spot,
+        Strike:    option.Strike,
+        T:         t,
+        Rate:      e.market.RiskFreeRate(t),
+        Dividend:  yield,
+        Dividends: dividends,
+        Vol:       option.ImpliedVol,
+        Call:      option.Type == "call",
+    }
+    if p.Vol <= 0 {
+        p.Vol = option.MidIV
+    }
+    if t <= 0 {
+        return intrinsic(p)
+    }
+    return e.pricerFor(symbol).Price(p)
+}
+
+// Distribution returns the distribution of an underlying's price at an
+// expiration under the engine's drift assumption, at the given vol
+func (e *Engine) Distribution(symbol string, spot, vol float64, expiration string, now time.Time) Lognormal {
+    t, err := YearsToExpiration(expiration, now)
+    if err != nil {
+        t = 0
+    }
+    yield, dividends := e.market.CashDividends(symbol, now)
+    return NewLognormal(Params{
+        Spot:      spot,
+        T:         t,
+        Rate:      e.market.RiskFreeRate(t),
+        Dividend:  yield,
+        Dividends: dividends,
+        Vol:       vol,
+    }, e.config.Drift)
+}
+
+// Probabilities sets the probability of expiring in the money, of touching
+// the strike and of profit at the mid price on every contract of a chain.
+// With skew enabled, contracts with a fitted vol use the smile's level and
+// slope instead of their implied vol.
+func (e *Engine) Probabilities(chain *models.OptionChain) {
+    if chain.Underlying <= 0 {
+        return
+    }
+    now := e.now()
+    yield, dividends := e.market.CashDividends(chain.Symbol, now)
+    var slopes map[string]map[float64]float64
+    if e.config.Skew {
+        slopes = smileSlopes(chain)
+    }
+
+    set := func(option *models.OptionData) {
+        option.PITM, option.PTouch, option.POP = 0, 0, 0
+        vol, slope := option.ImpliedVol, 0.0
+        if e.config.Skew && option.FittedIV > 0 {
+            vol, slope = option.FittedIV, slopes[option.Expiration][option.Strike]
+        }
+        t, err := YearsToExpiration(option.Expiration, now)
+        if err != nil || t <= 0 || vol <= 0 || option.Strike <= 0 {
+            return
+        }
+
+        call := option.Type == "call"
+        d := NewLognormal(Params{
+            Spot:      chain.Underlying,
+            Strike:    option.Strike,
+            T:         t,
+            Rate:      e.market.RiskFreeRate(t),
+            Dividend:  yield,
+            Dividends: dividends,
+            Vol:       vol,
+            Call:      call,
+        }, e.config.Drift)
+
+        // Probability of finishing above a level, with the vol moved along
+        // the smile from the strike to the level
+        above := func(level float64) float64 {
+            shifted := d
+            if v := vol + slope*(level-option.Strike); v > 0 {
+                shifted.Vol = v
+            }
+            return shifted.AboveSkew(level, slope)
+        }
+
+        option.PTouch = d.Touch(option.Strike)
+        premium := option.LastPrice
+        if option.Bid > 0 && option.Ask > 0 {
+            premium = (option.Bid + option.Ask) / 2
+        }
+        if call {
+            option.PITM = above(option.Strike)
+            if premium > 0 {
+                option.POP = above(option.Strike + premium)
+            }
+        } else {
+            option.PITM = 1 - above(option.Strike)
+            if premium > 0 && premium < option.Strike {
+                option.POP = 1 - above(option.Strike-premium)
+            }
+        }
+    }
+    for i := range chain.Calls {
+        set(&chain.Calls[i])
+    }
+    for i := range chain.Puts {
+        set(&chain.Puts[i])
+    }
+}
+
+// smileSlopes returns the slope of the fitted vol against strike at every
+// strike of each expiration, by differences between neighbouring strikes
+func smileSlopes(chain *models.OptionChain) map[string]map[float64]float64 {
+    vols := make(map[string]map[float64]float64)
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for _, option := range options {
+            if option.FittedIV <= 0 {
+                continue
+            }
+            if vols[option.Expiration] == nil {
+                vols[option.Expiration] = make(map[float64]float64)
+            }
+            vols[option.Expiration][option.Strike] = option.FittedIV
+        }
+    }
+
+    slopes := make(map[string]map[float64]float64, len(vols))
+    for expiration, byStrike := range vols {
+        strikes := make([]float64, 0, len(byStrike))
+        for strike := range byStrike {
+            strikes = append(strikes, strike)
+        }
+        sort.Float64s(strikes)
+
+        slopes[expiration] = make(map[float64]float64, len(strikes))
+        for i, strike := range strikes {
+            lo, hi := max(i-1, 0), min(i+1, len(strikes)-1)
+            if lo == hi {
+                continue
+            }
+            slopes[expiration][strike] = (byStrike[strikes[hi]] - byStrike[strikes[lo]]) / (strikes[hi] - strikes[lo])
+        }
+    }
+    return slopes
+}
@@ -0,0 +1,70 @@
+package analytics
+
+import (
+    "context"
+    "math"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// flatMarket prices with a flat rate and no dividends
+type flatMarket struct {
+    rate float64
+}
+
+func (m flatMarket) RiskFreeRate(float64) float64 { return m.rate }
+func (flatMarket) CashDividends(string, time.Time) (float64, []CashDividend) {
+    return 0, nil
+}
+
+func TestEnrichPricesAmericanModelsInBackground(t *testing.T) {
+    now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+    e := NewEngine(Config{Model: ModelBinomial, BinomialSteps: 200}, flatMarket{rate: 0.08})
+    e.now = func() time.Time { return now }
+
+    expiration := "2026-08-31"
+    years, err := YearsToExpiration(expiration, now)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // A deep in-the-money put, worth well above its European value
+    p := Params{Spot: 80, Strike: 100, T: years, Rate: 0.08, Vol: 0.3}
+    price := BinomialPrice(p, 200)
+    chain := func() models.OptionChain {
+        return models.OptionChain{Symbol: "XYZ", Underlying: 80, Puts: []models.OptionData{
+            {Symbol: ".XYZ260831P100", Strike: 100, Expiration: expiration, Type: "put", Bid: price - 0.01, Ask: price + 0.01},
+        }}
+    }
+
+    first := chain()
+    e.Enrich(&first)
+    european := first.Puts[0].MidIV
+    if european != 0 && math.Abs(european-0.3) < 0.01 {
+        t.Fatalf("European mid IV = %v before the worker ran, want it away from the American 0.3", european)
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    go e.Run(ctx)
+    deadline := time.Now().Add(5 * time.Second)
+    for {
+        e.cacheMux.Lock()
+        priced := len(e.american["XYZ"])
+        e.cacheMux.Unlock()
+        if priced > 0 {
+            break
+        }
+        if time.Now().After(deadline) {
+            t.Fatal("pricing worker did not price the chain")
+        }
+        time.Sleep(time.Millisecond)
+    }
+
+    second := chain()
+    e.Enrich(&second)
+    if got := second.Puts[0].MidIV; math.Abs(got-0.3) > 1e-3 {
+        t.Errorf("American mid IV = %v, want 0.3", got)
+    }
+}
@@ -0,0 +1,42 @@
+package analytics
+
+import (
+    "time"
+)
+
+// daysPerYear is the calendar-day basis used for time to expiration
+const daysPerYear = 365.0
+
+// exchangeLocation is the time zone expirations are quoted in
+var exchangeLocation = func() *time.Location {
+    loc, err := time.LoadLocation("America/New_York")
+    if err != nil {
+        // Fall back to Eastern Standard Time when no zone database exists
+        return time.FixedZone("EST", -5*60*60)
+    }
+    return loc
+}()
+
+// ExpirationTime returns the moment an expiration date (2006-01-02) stops
+// trading: 4pm New York time on that day
+func ExpirationTime(expiration string) (time.Time, error) {
+    date, err := time.ParseInLocation("2006-01-02", expiration, exchangeLocation)
+    if err != nil {
+        return time.Time{}, err
+    }
+    return date.Add(16 * time.Hour), nil
+}
+
+// YearsToExpiration returns the time from now until an expiration date in
+// years, or 0 if it has passed
+func YearsToExpiration(expiration string, now time.Time) (float64, error) {
+    expiry, err := ExpirationTime(expiration)
+    if err != nil {
+        return 0, err
+    }
+    years := expiry.Sub(now).Hours() / 24 / daysPerYear
+    if years < 0 {
+        return 0, nil
+    }
+    return years, nil
+}
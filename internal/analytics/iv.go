@@ -0,0 +1,210 @@
+package analytics
+
+import (
+    "errors"
+    "math"
+)
+
+const (
+    minVol = 1e-4
+    maxVol = 5.0
+
+    // priceTolerance is the pricing error at which the solver stops
+    priceTolerance = 1e-9
+    maxNewtonSteps = 20
+    maxBrentSteps  = 100
+)
+
+// Errors returned by ImpliedVol for quotes that have no implied volatility
+var (
+    ErrNoPrice         = errors.New("no price to solve for")
+    ErrBelowIntrinsic  = errors.New("price at or below intrinsic value")
+    ErrAboveUpperBound = errors.New("price above the no-arbitrage upper bound")
+    ErrNoConvergence   = errors.New("implied volatility solver did not converge")
+    ErrInvalidContract = errors.New("invalid contract parameters")
+)
+
+// ImpliedVol finds the volatility at which the Black-Scholes-Merton price
+// of p matches price. In-the-money options are solved through put-call
+// parity as the out-of-the-money option at the same strike, whose price is
+// all time value. Newton's method is tried first from a Corrado-Miller
+// estimate; if it stalls on a flat vega or leaves the search range, Brent's
+// method finishes on [minVol, maxVol].
+func ImpliedVol(price float64, p Params) (float64, error) {
+    if err := checkQuote(price, p); err != nil {
+        return 0, err
+    }
+    p = p.escrowed()
+
+    // Bounds: the discounted intrinsic value at zero volatility, and the
+    // discounted spot (call) or strike (put) at infinite volatility
+    spot := p.Spot * math.Exp(-p.Dividend*p.T)
+    strike := p.Strike * math.Exp(-p.Rate*p.T)
+    lower := discountedIntrinsic(p)
+    upper := strike
+    if p.Call {
+        upper = spot
+    }
+    if price <= lower+priceTolerance {
+        return 0, ErrBelowIntrinsic
+    }
+    if price >= upper {
+        return 0, ErrAboveUpperBound
+    }
+
+    // Solve in-the-money options as their out-of-the-money counterpart
+    if lower > 0 {
+        if p.Call {
+            price = price - spot + strike
+        } else {
+            price = price + spot - strike
+        }
+        p.Call = !p.Call
+    }
+
+    if vol, ok := newtonVol(price, p); ok {
+        return vol, nil
+    }
+    return brentVol(price, p, BSMPrice)
+}
+
+// ImpliedVolFor finds the volatility at which the pricer's price of p
+// matches price. European pricers use ImpliedVol; American models have no
+// usable vega, so they are solved with Brent's method between the model
+// prices at minVol and maxVol.
+func ImpliedVolFor(pricer Pricer, price float64, p Params) (float64, error) {
+    if _, ok := pricer.(europeanPricer); ok {
+        return ImpliedVol(price, p)
+    }
+    if err := checkQuote(price, p); err != nil {
+        return 0, err
+    }
+
+    p.Vol = minVol
+    if price <= pricer.Price(p)+priceTolerance {
+        return 0, ErrBelowIntrinsic
+    }
+    p.Vol = maxVol
+    if price >= pricer.Price(p) {
+        return 0, ErrAboveUpperBound
+    }
+    return brentVol(price, p, pricer.Price)
+}
+
+// checkQuote rejects contracts and prices that cannot be solved
+func checkQuote(price float64, p Params) error {
+    if p.T <= 0 || p.Spot <= 0 || p.Strike <= 0 {
+        return ErrInvalidContract
+    }
+    if price <= 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+        return ErrNoPrice
+    }
+    return nil
+}
+
+// newtonVol runs Newton's method on the vega, reporting false if it fails
+// to converge inside the search range
+func newtonVol(price float64, p Params) (float64, bool) {
+    vol := initialVol(price, p)
+    sqrtT := math.Sqrt(p.T)
+    for i := 0; i < maxNewtonSteps; i++ {
+        p.Vol = vol
+        diff := BSMPrice(p) - price
+        if math.Abs(diff) < priceTolerance {
+            return vol, true
+        }
+        d1, _ := d1d2(p)
+        vega := p.Spot * math.Exp(-p.Dividend*p.T) * normPDF(d1) * sqrtT
+        if vega < 1e-12 {
+            return 0, false
+        }
+        vol -= diff / vega
+        if vol <= minVol || vol >= maxVol || math.IsNaN(vol) {
+            return 0, false
+        }
+    }
+    return 0, false
+}
+
+// initialVol estimates the volatility with the Corrado-Miller formula,
+// falling back to 30% when the estimate is undefined
+func initialVol(price float64, p Params) float64 {
+    spot := p.Spot * math.Exp(-p.Dividend*p.T)
+    strike := p.Strike * math.Exp(-p.Rate*p.T)
+    // The formula is stated for calls; convert puts through parity
+    call := price
+    if !p.Call {
+        call = price + spot - strike
+    }
+    half := call - (spot-strike)/2
+    disc := half*half - (spot-strike)*(spot-strike)/math.Pi
+    if disc < 0 {
+        disc = 0
+    }
+    vol := math.Sqrt(2*math.Pi/p.T) / (spot + strike) * (half + math.Sqrt(disc))
+    if math.IsNaN(vol) || vol <= minVol || vol >= maxVol {
+        return 0.3
+    }
+    return vol
+}
+
+// brentVol finds the volatility with Brent's method. The price is known
+// to lie strictly between the model prices at minVol and maxVol.
+func brentVol(price float64, p Params, model func(Params) float64) (float64, error) {
+    f := func(vol float64) float64 {
+        p.Vol = vol
+        return model(p) - price
+    }
+
+    a, b := minVol, maxVol
+    fa, fb := f(a), f(b)
+    if fa*fb > 0 {
+        return 0, ErrNoConvergence
+    }
+    if math.Abs(fa) < math.Abs(fb) {
+        a, b, fa, fb = b, a, fb, fa
+    }
+    c, fc := a, fa
+    d := b - a
+    bisected := true
+
+    for i := 0; i < maxBrentSteps; i++ {
+        if math.Abs(fb) < priceTolerance || math.Abs(b-a) < 1e-12 {
+            return b, nil
+        }
+
+        var s float64
+        if fa != fc && fb != fc {
+            // Inverse quadratic interpolation
+            s = a*fb*fc/((fa-fb)*(fa-fc)) +
+                b*fa*fc/((fb-fa)*(fb-fc)) +
+                c*fa*fb/((fc-fa)*(fc-fb))
+        } else {
+            // Secant step
+            s = b - fb*(b-a)/(fb-fa)
+        }
+
+        // Fall back to bisection when the interpolated step is poor
+        mid := (3*a + b) / 4
+        if (s-mid)*(s-b) >= 0 ||
+            (bisected && math.Abs(s-b) >= math.Abs(b-c)/2) ||
+            (!bisected && math.Abs(s-b) >= math.Abs(c-d)/2) {
+            s = (a + b) / 2
+            bisected = true
+        } else {
+            bisected = false
+        }
+
+        fs := f(s)
+        d, c, fc = c, b, fb
+        if fa*fs < 0 {
+            b, fb = s, fs
+        } else {
+            a, fa = s, fs
+        }
+        if math.Abs(fa) < math.Abs(fb) {
+            a, b, fa, fb = b, a, fb, fa
+        }
+    }
+    return 0, ErrNoConvergence
+}
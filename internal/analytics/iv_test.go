@@ -0,0 +1,61 @@
+package analytics
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+func TestImpliedVolRoundTrip(t *testing.T) {
+    tests := []struct {
+        name string
+        p    Params
+    }{
+        {"at-the-money call", Params{Spot: 100, Strike: 100, T: 0.25, Rate: 0.04, Vol: 0.20, Call: true}},
+        {"at-the-money put", Params{Spot: 100, Strike: 100, T: 0.25, Rate: 0.04, Vol: 0.20}},
+        {"in-the-money call", Params{Spot: 100, Strike: 70, T: 0.5, Rate: 0.04, Dividend: 0.02, Vol: 0.30, Call: true}},
+        {"in-the-money put", Params{Spot: 100, Strike: 130, T: 0.5, Rate: 0.04, Vol: 0.35}},
+        {"deep out-of-the-money call", Params{Spot: 100, Strike: 160, T: 0.25, Rate: 0.04, Vol: 0.25, Call: true}},
+        {"deep out-of-the-money put", Params{Spot: 100, Strike: 55, T: 0.25, Rate: 0.04, Vol: 0.45}},
+        {"short-dated wing", Params{Spot: 100, Strike: 108, T: 2.0 / 365, Rate: 0.04, Vol: 0.30, Call: true}},
+        {"long-dated high vol", Params{Spot: 100, Strike: 120, T: 3, Rate: 0.04, Vol: 1.5, Call: true}},
+        {"low vol", Params{Spot: 100, Strike: 102, T: 1, Rate: 0.04, Vol: 0.03, Call: true}},
+        {"discrete dividend", Params{Spot: 100, Strike: 95, T: 0.5, Rate: 0.04, Vol: 0.25, Dividends: []CashDividend{{Amount: 2, T: 0.2}}}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            price := BSMPrice(tt.p)
+            vol, err := ImpliedVol(price, tt.p)
+            if err != nil {
+                t.Fatalf("ImpliedVol(%.6f): %v", price, err)
+            }
+            if math.Abs(vol-tt.p.Vol) > 1e-6 {
+                t.Errorf("ImpliedVol = %.8f, want %.8f", vol, tt.p.Vol)
+            }
+        })
+    }
+}
+
+func TestImpliedVolRejectsBadQuotes(t *testing.T) {
+    call := Params{Spot: 100, Strike: 90, T: 0.5, Rate: 0.04, Call: true}
+    intrinsic := 100 - 90*math.Exp(-0.04*0.5)
+
+    tests := []struct {
+        name  string
+        price float64
+        p     Params
+        want  error
+    }{
+        {"no price", 0, call, ErrNoPrice},
+        {"below intrinsic", intrinsic - 0.5, call, ErrBelowIntrinsic},
+        {"above the spot", 101, call, ErrAboveUpperBound},
+        {"expired", 12, Params{Spot: 100, Strike: 90, Call: true}, ErrInvalidContract},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := ImpliedVol(tt.price, tt.p); !errors.Is(err, tt.want) {
+                t.Errorf("err = %v, want %v", err, tt.want)
+            }
+        })
+    }
+}
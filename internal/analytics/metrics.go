@@ -0,0 +1,217 @@
+package analytics
+
+import (
+    "math"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// wingDelta is the absolute delta of the wings used for skew metrics
+const wingDelta = 0.25
+
+// metricPoint is a contract's vol against its log-moneyness and delta
+type metricPoint struct {
+    k, delta, vol float64
+}
+
+// ChainMetrics computes the ATM volatility term structure and the 25-delta
+// skew of every expiration. Forwards maps expirations to the forward used
+// for moneyness; expirations without one are skipped.
+func ChainMetrics(chain models.OptionChain, forwards map[string]float64, now time.Time) *models.VolMetrics {
+    slices := volSlices(chain, forwards)
+
+    metrics := &models.VolMetrics{}
+    for expiration, s := range slices {
+        t, err := YearsToExpiration(expiration, now)
+        if err != nil || t <= 0 {
+            continue
+        }
+        m := models.ExpirationMetrics{
+            Expiration:       expiration,
+            DaysToExpiration: t * daysPerYear,
+            Forward:          forwards[expiration],
+            ATMIV:            s.atm(),
+        }
+
+        var callK, putK float64
+        m.Call25IV, callK = wingVol(s.calls, wingDelta)
+        m.Put25IV, putK = wingVol(s.puts, -wingDelta)
+        if m.Call25IV > 0 && m.Put25IV > 0 {
+            m.RiskReversal25 = m.Call25IV - m.Put25IV
+            if m.ATMIV > 0 {
+                m.Butterfly25 = (m.Call25IV+m.Put25IV)/2 - m.ATMIV
+            }
+        }
+        if m.ATMIV > 0 && m.Put25IV > 0 && putK < 0 {
+            m.PutSkewSlope = (m.Put25IV - m.ATMIV) / putK
+        }
+        if m.ATMIV > 0 && m.Call25IV > 0 && callK > 0 {
+            m.CallSkewSlope = (m.Call25IV - m.ATMIV) / callK
+        }
+        metrics.Expirations = append(metrics.Expirations, m)
+    }
+    sort.Slice(metrics.Expirations, func(i, j int) bool {
+        return metrics.Expirations[i].Expiration < metrics.Expirations[j].Expiration
+    })
+
+    term := atmTerm(metrics.Expirations)
+    for i := range metrics.Expirations {
+        m := &metrics.Expirations[i]
+        for _, next := range term {
+            if next.Expiration > m.Expiration && m.ATMIV > 0 {
+                m.TermRatio = m.ATMIV / next.ATMIV
+                break
+            }
+        }
+    }
+    if len(term) >= 2 {
+        metrics.FrontBackRatio = term[0].ATMIV / term[1].ATMIV
+    }
+    metrics.ATMIV30 = constantMaturityVol(term, 30)
+    metrics.ATMIV90 = constantMaturityVol(term, 90)
+    if metrics.ATMIV30 > 0 && metrics.ATMIV90 > 0 {
+        metrics.Ratio30To90 = metrics.ATMIV30 / metrics.ATMIV90
+    }
+    return metrics
+}
+
+// volSlice holds the contracts of one expiration that have a vol
+type volSlice struct {
+    calls, puts []metricPoint
+}
+
+// atm returns the slice's vol at the forward
+func (s *volSlice) atm() float64 {
+    return atmVol(s.calls, s.puts)
+}
+
+// volSlices groups the contracts with a vol and a forward by expiration.
+// Each contract's vol is its mid IV, or its implied vol without one.
+func volSlices(chain models.OptionChain, forwards map[string]float64) map[string]*volSlice {
+    slices := make(map[string]*volSlice)
+    add := func(options []models.OptionData, call bool) {
+        for _, option := range options {
+            forward := forwards[option.Expiration]
+            vol := option.MidIV
+            if vol <= 0 {
+                vol = option.ImpliedVol
+            }
+            if forward <= 0 || option.Strike <= 0 || vol <= 0 {
+                continue
+            }
+            s, ok := slices[option.Expiration]
+            if !ok {
+                s = &volSlice{}
+                slices[option.Expiration] = s
+            }
+            p := metricPoint{k: math.Log(option.Strike / forward), delta: option.Delta, vol: vol}
+            if call {
+                s.calls = append(s.calls, p)
+            } else {
+                s.puts = append(s.puts, p)
+            }
+        }
+    }
+    add(chain.Calls, true)
+    add(chain.Puts, false)
+    return slices
+}
+
+// atmVol interpolates the out-of-the-money vols linearly in log-moneyness
+// at the forward
+func atmVol(calls, puts []metricPoint) float64 {
+    var points []metricPoint
+    for _, p := range calls {
+        if p.k >= 0 {
+            points = append(points, p)
+        }
+    }
+    for _, p := range puts {
+        if p.k < 0 {
+            points = append(points, p)
+        }
+    }
+    if len(points) == 0 {
+        return 0
+    }
+    sort.Slice(points, func(i, j int) bool { return points[i].k < points[j].k })
+    if points[0].k >= 0 {
+        return points[0].vol
+    }
+    for i := 1; i < len(points); i++ {
+        if points[i].k >= 0 {
+            lo, hi := points[i-1], points[i]
+            return lo.vol + (hi.vol-lo.vol)*(0-lo.k)/(hi.k-lo.k)
+        }
+    }
+    return points[len(points)-1].vol
+}
+
+// wingVol interpolates vol and log-moneyness linearly in delta at the
+// target delta. It returns zeros unless the target lies between two
+// contracts.
+func wingVol(points []metricPoint, target float64) (float64, float64) {
+    var valid []metricPoint
+    for _, p := range points {
+        if p.delta != 0 && math.Abs(p.delta) < 1 {
+            valid = append(valid, p)
+        }
+    }
+    sort.Slice(valid, func(i, j int) bool { return valid[i].delta < valid[j].delta })
+    for i := 1; i < len(valid); i++ {
+        lo, hi := valid[i-1], valid[i]
+        if lo.delta <= target && target <= hi.delta && hi.delta > lo.delta {
+            weight := (target - lo.delta) / (hi.delta - lo.delta)
+            return lo.vol + (hi.vol-lo.vol)*weight, lo.k + (hi.k-lo.k)*weight
+        }
+    }
+    return 0, 0
+}
+
+// constantMaturityVol interpolates ATM vol at a number of days, linearly
+// in total variance between expirations and flat before the first. It
+// returns 0 past the last expiration.
+func constantMaturityVol(term []models.ExpirationMetrics, days float64) float64 {
+    if len(term) == 0 {
+        return 0
+    }
+    if days <= term[0].DaysToExpiration {
+        return term[0].ATMIV
+    }
+    for i := 1; i < len(term); i++ {
+        lo, hi := term[i-1], term[i]
+        if days > hi.DaysToExpiration {
+            continue
+        }
+        loVar := lo.ATMIV * lo.ATMIV * lo.DaysToExpiration
+        hiVar := hi.ATMIV * hi.ATMIV * hi.DaysToExpiration
+        variance := loVar + (hiVar-loVar)*(days-lo.DaysToExpiration)/(hi.DaysToExpiration-lo.DaysToExpiration)
+        if variance <= 0 {
+            return 0
+        }
+        return math.Sqrt(variance / days)
+    }
+    return 0
+}
+
+// ATMVolAt interpolates the ATM term structure of chain metrics to a
+// number of calendar days, or returns 0 without metrics
+func ATMVolAt(metrics *models.VolMetrics, days float64) float64 {
+    if metrics == nil {
+        return 0
+    }
+    return constantMaturityVol(atmTerm(metrics.Expirations), days)
+}
+
+// atmTerm is the term structure over the expirations with an ATM vol
+func atmTerm(expirations []models.ExpirationMetrics) []models.ExpirationMetrics {
+    var term []models.ExpirationMetrics
+    for _, m := range expirations {
+        if m.ATMIV > 0 {
+            term = append(term, m)
+        }
+    }
+    return term
+}
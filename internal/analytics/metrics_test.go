@@ -0,0 +1,48 @@
+package analytics
+
+import (
+    "math"
+    "testing"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+func TestATMVolAt(t *testing.T) {
+    // The 30-day expiration has no ATM vol and is skipped: between 10 and
+    // 60 days total variance runs from 0.09 * 10 to 0.04 * 60
+    metrics := &models.VolMetrics{Expirations: []models.ExpirationMetrics{
+        {Expiration: "2026-10-26", DaysToExpiration: 10, ATMIV: 0.30},
+        {Expiration: "2026-11-15", DaysToExpiration: 30},
+        {Expiration: "2026-12-15", DaysToExpiration: 60, ATMIV: 0.20},
+    }}
+
+    tests := []struct {
+        name    string
+        metrics *models.VolMetrics
+        days    float64
+        want    float64
+    }{
+        {"flat before the first expiration", metrics, 5, 0.30},
+        {"at an expiration", metrics, 10, 0.30},
+        {"across an expiration without ATM vol", metrics, 30, math.Sqrt((0.9 + 1.5*20/50) / 30)},
+        {"at the last expiration", metrics, 60, 0.20},
+        {"past the last expiration", metrics, 90, 0},
+        {
+            name: "first expiration without ATM vol",
+            metrics: &models.VolMetrics{Expirations: []models.ExpirationMetrics{
+                {Expiration: "2026-10-19", DaysToExpiration: 3},
+                {Expiration: "2026-10-26", DaysToExpiration: 10, ATMIV: 0.30},
+            }},
+            days: 3,
+            want: 0.30,
+        },
+        {"no metrics", nil, 30, 0},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := ATMVolAt(tt.metrics, tt.days); math.Abs(got-tt.want) > 1e-12 {
+                t.Errorf("ATMVolAt(%v) = %.6f, want %.6f", tt.days, got, tt.want)
+            }
+        })
+    }
+}
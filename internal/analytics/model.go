@@ -0,0 +1,137 @@
+package analytics
+
+import (
+    "fmt"
+    "math"
+    "strings"
+)
+
+// Model names an option pricing model
+type Model string
+
+const (
+    ModelEuropean           Model = "european"            // Black-Scholes-Merton
+    ModelBinomial           Model = "binomial"            // Cox-Ross-Rubinstein tree, American exercise
+    ModelBjerksundStensland Model = "bjerksund-stensland" // closed-form American approximation
+)
+
+// ParseModel parses a model name
+func ParseModel(name string) (Model, error) {
+    switch model := Model(strings.ToLower(strings.TrimSpace(name))); model {
+    case ModelEuropean, ModelBinomial, ModelBjerksundStensland:
+        return model, nil
+    case "bsm", "black-scholes":
+        return ModelEuropean, nil
+    case "crr":
+        return ModelBinomial, nil
+    case "bs2002":
+        return ModelBjerksundStensland, nil
+    }
+    return "", fmt.Errorf("unknown pricing model: %q", name)
+}
+
+// Pricer prices options and computes their Greeks under one model
+type Pricer interface {
+    Price(p Params) float64
+    Greeks(p Params) Greeks
+}
+
+// NewPricer returns the pricer for a model. Steps is the depth of the
+// binomial tree and is ignored by the other models.
+func NewPricer(model Model, steps int) (Pricer, error) {
+    switch model {
+    case ModelEuropean:
+        return europeanPricer{}, nil
+    case ModelBinomial:
+        if steps < 1 {
+            return nil, fmt.Errorf("invalid binomial steps: %d", steps)
+        }
+        return binomialPricer{steps: steps}, nil
+    case ModelBjerksundStensland:
+        return bjerksundPricer{}, nil
+    }
+    return nil, fmt.Errorf("unknown pricing model: %q", model)
+}
+
+type europeanPricer struct{}
+
+func (europeanPricer) Price(p Params) float64  { return BSMPrice(p) }
+func (europeanPricer) Greeks(p Params) Greeks { return BSMGreeks(p) }
+
+type binomialPricer struct {
+    steps int
+}
+
+func (b binomialPricer) Price(p Params) float64 { return BinomialPrice(p, b.steps) }
+func (b binomialPricer) Greeks(p Params) Greeks { return numericGreeks(b.Price, p) }
+
+type bjerksundPricer struct{}
+
+func (bjerksundPricer) Price(p Params) float64  { return BjerksundStenslandPrice(p) }
+func (bjerksundPricer) Greeks(p Params) Greeks { return numericGreeks(BjerksundStenslandPrice, p) }
+
+// numericGreeks computes Greeks by central finite differences of a pricing
+// function, for models without closed-form sensitivities. The spot bump is
+// 1% so that tree discretisation does not dominate the second differences.
+func numericGreeks(price func(Params) float64, p Params) Greeks {
+    if p.T <= 0 || p.Vol <= 0 || p.Spot <= 0 || p.Strike <= 0 {
+        return Greeks{}
+    }
+
+    const (
+        dVol  = 0.01     // one vol point
+        dRate = 0.0001   // one basis point
+        dT    = 1.0 / 365 // one day
+    )
+    dS := p.Spot * 0.01
+
+    at := func(spot, vol, t float64) float64 {
+        q := p
+        q.Spot, q.Vol, q.T = spot, vol, t
+        if t < p.T {
+            // Dividend times are measured from today, so shift them with it
+            q.Dividends = shiftDividends(p.Dividends, p.T-t)
+        }
+        return price(q)
+    }
+    s, v, t := p.Spot, p.Vol, p.T
+    later := math.Max(t-dT, 0)
+
+    base := at(s, v, t)
+    up, down := at(s+dS, v, t), at(s-dS, v, t)
+    up2, down2 := at(s+2*dS, v, t), at(s-2*dS, v, t)
+    volUp, volDown := at(s, v+dVol, t), at(s, v-dVol, t)
+    delta := func(vol, t float64) float64 {
+        return (at(s+dS, vol, t) - at(s-dS, vol, t)) / (2 * dS)
+    }
+
+    rateUp, rateDown := p, p
+    rateUp.Rate += dRate
+    rateDown.Rate -= dRate
+
+    // Each Greek is in desk units already: bumps are one vol point, one
+    // day and the rate move is scaled to one point
+    var g Greeks
+    g.Delta = (up - down) / (2 * dS)
+    g.Gamma = (up - 2*base + down) / (dS * dS)
+    g.Theta = (at(s, v, later) - base) * dT / (t - later)
+    g.Vega = (volUp - volDown) / 2
+    g.Rho = (price(rateUp) - price(rateDown)) / (2 * dRate) / 100
+    g.Vanna = (delta(v+dVol, t) - delta(v-dVol, t)) / 2
+    g.Charm = (delta(v, later) - g.Delta) * dT / (t - later)
+    g.Vomma = volUp - 2*base + volDown
+    g.Speed = (up2 - 2*up + 2*down - down2) / (2 * dS * dS * dS)
+    return g
+}
+
+// shiftDividends moves a dividend schedule forward by dt years, dropping
+// dividends that have gone ex
+func shiftDividends(dividends []CashDividend, dt float64) []CashDividend {
+    var shifted []CashDividend
+    for _, d := range dividends {
+        if d.T-dt > 0 {
+            shifted = append(shifted, CashDividend{T: d.T - dt, Amount: d.Amount})
+        }
+    }
+    return shifted
+}
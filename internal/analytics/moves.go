@@ -0,0 +1,106 @@
+package analytics
+
+import (
+    "math"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// ExpectedMoves computes the expected move of every expiration of a chain
+// from the ATM straddle, the two-sided call and put pair at the strike
+// nearest the forward, and from ATM implied volatility.
+func ExpectedMoves(chain models.OptionChain, forwards map[string]float64, now time.Time) []models.ExpectedMove {
+    spot := chain.Underlying
+    if spot <= 0 {
+        return nil
+    }
+
+    // Mid prices of two-sided quotes by expiration and strike
+    type pair struct{ call, put float64 }
+    pairs := make(map[string]map[float64]*pair)
+    add := func(options []models.OptionData, call bool) {
+        for _, option := range options {
+            if option.Bid <= 0 || option.Ask <= 0 {
+                continue
+            }
+            strikes, ok := pairs[option.Expiration]
+            if !ok {
+                strikes = make(map[float64]*pair)
+                pairs[option.Expiration] = strikes
+            }
+            p, ok := strikes[option.Strike]
+            if !ok {
+                p = &pair{}
+                strikes[option.Strike] = p
+            }
+            if call {
+                p.call = (option.Bid + option.Ask) / 2
+            } else {
+                p.put = (option.Bid + option.Ask) / 2
+            }
+        }
+    }
+    add(chain.Calls, true)
+    add(chain.Puts, false)
+
+    slices := volSlices(chain, forwards)
+    var moves []models.ExpectedMove
+    for expiration, forward := range forwards {
+        t, err := YearsToExpiration(expiration, now)
+        if err != nil || t <= 0 || forward <= 0 {
+            continue
+        }
+        move := models.ExpectedMove{
+            Expiration:       expiration,
+            DaysToExpiration: t * daysPerYear,
+        }
+        if s, ok := slices[expiration]; ok {
+            move.ATMIV = s.atm()
+        }
+
+        best := math.Inf(1)
+        for strike, p := range pairs[expiration] {
+            if p.call > 0 && p.put > 0 && math.Abs(strike-forward) < best {
+                best = math.Abs(strike - forward)
+                move.StraddleStrike = strike
+                move.StraddlePrice = p.call + p.put
+            }
+        }
+
+        if move.StraddlePrice > 0 {
+            move.StraddleLower = math.Max(spot-move.StraddlePrice, 0)
+            move.StraddleUpper = spot + move.StraddlePrice
+            move.StraddleProbability = probabilityBetween(move.StraddleLower, move.StraddleUpper, forward, move.ATMIV, t)
+        }
+        if move.ATMIV > 0 {
+            move.IVMove = spot * move.ATMIV * math.Sqrt(t)
+            move.IVLower = math.Max(spot-move.IVMove, 0)
+            move.IVUpper = spot + move.IVMove
+            move.IVProbability = probabilityBetween(move.IVLower, move.IVUpper, forward, move.ATMIV, t)
+        }
+        if move.StraddlePrice > 0 || move.ATMIV > 0 {
+            moves = append(moves, move)
+        }
+    }
+
+    sort.Slice(moves, func(i, j int) bool { return moves[i].Expiration < moves[j].Expiration })
+    return moves
+}
+
+// probabilityBetween is the lognormal probability that a price with the
+// given forward and vol finishes between lower and upper after t years
+func probabilityBetween(lower, upper, forward, vol, t float64) float64 {
+    if vol <= 0 || t <= 0 {
+        return 0
+    }
+    sd := vol * math.Sqrt(t)
+    below := func(price float64) float64 {
+        if price <= 0 {
+            return 0
+        }
+        return normCDF((math.Log(price/forward) + sd*sd/2) / sd)
+    }
+    return below(upper) - below(lower)
+}
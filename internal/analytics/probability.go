@@ -0,0 +1,162 @@
+package analytics
+
+import (
+    "fmt"
+    "math"
+)
+
+// Drift is the expected return assumed for the underlying when computing
+// probabilities
+type Drift string
+
+const (
+    DriftRiskNeutral Drift = "risk-neutral" // the rate less the dividend yield
+    DriftZero        Drift = "zero"         // the price is expected to stay put
+)
+
+// ParseDrift parses a drift assumption
+func ParseDrift(name string) (Drift, error) {
+    switch Drift(name) {
+    case DriftRiskNeutral, DriftZero:
+        return Drift(name), nil
+    }
+    return "", fmt.Errorf("unknown drift: %q", name)
+}
+
+// Lognormal is the distribution of the underlying's price T years ahead
+// under geometric Brownian motion with the given drift and volatility
+type Lognormal struct {
+    Spot  float64
+    T     float64 // years ahead
+    Vol   float64 // annualised volatility
+    Drift float64 // continuously compounded expected return
+}
+
+// NewLognormal returns the price distribution implied by pricing inputs.
+// Discrete dividends lower the starting price by their present value.
+func NewLognormal(p Params, drift Drift) Lognormal {
+    p = p.escrowed()
+    d := Lognormal{Spot: p.Spot, T: p.T, Vol: p.Vol}
+    if drift == DriftRiskNeutral {
+        d.Drift = p.Rate - p.Dividend
+    }
+    return d
+}
+
+// valid reports whether the distribution has spread
+func (d Lognormal) valid() bool {
+    return d.Spot > 0 && d.T > 0 && d.Vol > 0
+}
+
+// d2 is the standardised distance of a level below the median log price
+func (d Lognormal) d2(level float64) float64 {
+    return (math.Log(d.Spot/level) + (d.Drift-d.Vol*d.Vol/2)*d.T) / (d.Vol * math.Sqrt(d.T))
+}
+
+// Above is the probability of finishing above a level
+func (d Lognormal) Above(level float64) float64 {
+    if level <= 0 {
+        return 1
+    }
+    if !d.valid() {
+        if d.Spot > level {
+            return 1
+        }
+        return 0
+    }
+    return normCDF(d.d2(level))
+}
+
+// Below is the probability of finishing below a level
+func (d Lognormal) Below(level float64) float64 {
+    return 1 - d.Above(level)
+}
+
+// AboveSkew is the probability of finishing above a level when volatility
+// varies with the level at the given slope (per unit of price), as on a
+// smile. It is minus the strike derivative of a digital's forward value.
+func (d Lognormal) AboveSkew(level, slope float64) float64 {
+    if slope == 0 || level <= 0 || !d.valid() {
+        return d.Above(level)
+    }
+    d2 := d.d2(level)
+    return clamp01(normCDF(d2) - level*normPDF(d2)*math.Sqrt(d.T)*slope)
+}
+
+// Touch is the probability of the price trading at a level at any time
+// before T, from the reflection principle for drifted Brownian motion
+func (d Lognormal) Touch(level float64) float64 {
+    if level <= 0 {
+        return 0
+    }
+    if level == d.Spot {
+        return 1
+    }
+    if !d.valid() {
+        return 0
+    }
+    nu := d.Drift - d.Vol*d.Vol/2
+    b := math.Log(level / d.Spot)
+    if b < 0 {
+        // A lower barrier is an upper one for the mirrored process
+        b, nu = -b, -nu
+    }
+    sd := d.Vol * math.Sqrt(d.T)
+    return clamp01(normCDF((nu*d.T-b)/sd) + math.Exp(2*nu*b/(d.Vol*d.Vol))*normCDF((-nu*d.T-b)/sd))
+}
+
+// ProfitProbability is the probability that a payoff at T, net of its
+// cost, is positive. It walks a log-price grid six standard deviations
+// either side of the median, locating breakevens inside each step by
+// bisection.
+func (d Lognormal) ProfitProbability(payoff func(price float64) float64) float64 {
+    if !d.valid() {
+        if payoff(d.Spot) > 0 {
+            return 1
+        }
+        return 0
+    }
+    const steps = 600
+    sd := d.Vol * math.Sqrt(d.T)
+    median := math.Log(d.Spot) + (d.Drift-d.Vol*d.Vol/2)*d.T
+    lo, hi := median-6*sd, median+6*sd
+    width := (hi - lo) / steps
+    cdf := func(x float64) float64 { return normCDF((x - median) / sd) }
+    profit := func(x float64) bool { return payoff(math.Exp(x)) > 0 }
+
+    // Tails beyond the grid take the payoff at its edges
+    var total float64
+    if profit(lo) {
+        total += cdf(lo)
+    }
+    if profit(hi) {
+        total += 1 - cdf(hi)
+    }
+
+    prevX, prevProfit := lo, profit(lo)
+    for i := 1; i <= steps; i++ {
+        x := lo + float64(i)*width
+        xProfit := profit(x)
+        switch {
+        case prevProfit && xProfit:
+            total += cdf(x) - cdf(prevX)
+        case prevProfit != xProfit:
+            a, b := prevX, x
+            for j := 0; j < 40; j++ {
+                mid := (a + b) / 2
+                if profit(mid) == prevProfit {
+                    a = mid
+                } else {
+                    b = mid
+                }
+            }
+            if prevProfit {
+                total += cdf(a) - cdf(prevX)
+            } else {
+                total += cdf(x) - cdf(a)
+            }
+        }
+        prevX, prevProfit = x, xProfit
+    }
+    return clamp01(total)
+}
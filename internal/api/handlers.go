@@ -1,25 +1,59 @@
 package api
 
 import (
+    "context"
     "encoding/json"
+    "errors"
+    "fmt"
     "log"
     "net/http"
+    "net/url"
+    "strconv"
+    "strings"
     "time"
 
     "github.com/gorilla/mux"
     "github.com/gorilla/websocket"
-    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/gex"
+    "github.com/ryanhamamura/options-chain-go/internal/market"
+    "github.com/ryanhamamura/options-chain-go/internal/oi"
+    "github.com/ryanhamamura/options-chain-go/internal/portfolio"
+    "github.com/ryanhamamura/options-chain-go/internal/quality"
+    "github.com/ryanhamamura/options-chain-go/internal/realized"
+    "github.com/ryanhamamura/options-chain-go/internal/scenario"
+    "github.com/ryanhamamura/options-chain-go/internal/screener"
+    "github.com/ryanhamamura/options-chain-go/internal/smile"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
     "github.com/ryanhamamura/options-chain-go/internal/stream"
+    "github.com/ryanhamamura/options-chain-go/internal/surface"
+    "github.com/ryanhamamura/options-chain-go/internal/unusual"
 )
 
+// Services are the components the HTTP handlers serve from
+type Services struct {
+    WSManager *stream.Manager
+    Chains    *store.ChainStore
+    Market    *market.Params
+    Surfaces  *surface.Service
+    Smiles    *smile.Fitter
+    GEX       *gex.Service
+    Realized  *realized.Service
+    Strategy  *strategy.Analyzer
+    Screener  *screener.Screener
+    Screens   *screener.Screens
+    Unusual   *unusual.Detector
+    Quality   *quality.Validator
+    Scenario  *scenario.Engine
+    Portfolio *portfolio.Portfolio
+}
+
 type Handler struct {
-    wsManager *stream.Manager
+    Services
 }
 
-func NewHandler(wsManager *stream.Manager) *Handler {
-    return &Handler{
-        wsManager: wsManager,
-    }
+func NewHandler(services Services) *Handler {
+    return &Handler{Services: services}
 }
 
 // ServeHome handles the main page request
@@ -36,18 +70,22 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
     }
     defer conn.Close()
 
-    h.wsManager.AddClient(conn)
-    defer h.wsManager.RemoveClient(conn)
+    h.WSManager.AddClient(conn)
+    defer h.WSManager.RemoveClient(conn)
 
-    // Keep connection alive and handle messages
+    // Keep connection alive and handle subscription messages
     for {
-        messageType, _, err := conn.ReadMessage()
+        messageType, data, err := conn.ReadMessage()
         if err != nil {
             if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
                 log.Printf("WebSocket error: %v", err)
             }
             break
         }
+        if messageType == websocket.TextMessage || messageType == websocket.BinaryMessage {
+            h.WSManager.HandleMessage(conn, messageType, data)
+            continue
+        }
         if messageType == websocket.PingMessage {
             if err := conn.WriteMessage(websocket.PongMessage, nil); err != nil {
                 log.Printf("Error sending pong: %v", err)
@@ -57,20 +95,492 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
     }
 }
 
-// GetOptionsChain handles requests for options chain data
+// StreamOptionsChain streams chain updates for a symbol as Server-Sent
+// Events. Optional query parameters narrow the contracts: type (call or
+// put), expiration (comma-separated dates), minStrike and maxStrike.
+func (h *Handler) StreamOptionsChain(w http.ResponseWriter, r *http.Request) {
+    symbol := strings.ToUpper(mux.Vars(r)["symbol"])
+
+    filter, err := parseContractFilter(r.URL.Query())
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    var lastSeq *uint64
+    if id := r.Header.Get("Last-Event-ID"); id != "" {
+        seq, err := strconv.ParseUint(id, 10, 64)
+        if err != nil {
+            http.Error(w, "invalid Last-Event-ID", http.StatusBadRequest)
+            return
+        }
+        lastSeq = &seq
+    }
+
+    h.WSManager.ServeEvents(w, r, symbol, filter, lastSeq)
+}
+
+// parseContractFilter builds a contract filter from query parameters
+func parseContractFilter(query url.Values) (stream.ContractFilter, error) {
+    var filter stream.ContractFilter
+
+    switch optionType := strings.ToLower(query.Get("type")); optionType {
+    case "", "call", "put":
+        filter.Type = optionType
+    default:
+        return filter, fmt.Errorf("invalid type: %q", optionType)
+    }
+
+    if expirations := query.Get("expiration"); expirations != "" {
+        filter.Expirations = make(map[string]bool)
+        for _, expiration := range strings.Split(expirations, ",") {
+            if _, err := time.Parse("2006-01-02", expiration); err != nil {
+                return filter, fmt.Errorf("invalid expiration: %q", expiration)
+            }
+            filter.Expirations[expiration] = true
+        }
+    }
+
+    var err error
+    if filter.MinStrike, err = parseFloatParam(query, "minStrike"); err != nil {
+        return filter, err
+    }
+    if filter.MaxStrike, err = parseFloatParam(query, "maxStrike"); err != nil {
+        return filter, err
+    }
+    return filter, nil
+}
+
+// parseFloatParam reads an optional numeric query parameter
+func parseFloatParam(query url.Values, key string) (float64, error) {
+    value := query.Get(key)
+    if value == "" {
+        return 0, nil
+    }
+    f, err := strconv.ParseFloat(value, 64)
+    if err != nil {
+        return 0, fmt.Errorf("invalid %s: %q", key, value)
+    }
+    return f, nil
+}
+
+// GetOptionsChain handles requests for options chain data. The same query
+// parameters as StreamOptionsChain narrow the contracts.
 func (h *Handler) GetOptionsChain(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
-    symbol := vars["symbol"]
+    symbol := strings.ToUpper(vars["symbol"])
+
+    filter, err := parseContractFilter(r.URL.Query())
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    chain, ok := h.Chains.Get(symbol)
+    if !ok {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(filter.FilterChain(chain))
+}
+
+// GetMarketInputs handles requests for the rates, dividends and implied
+// forwards of an underlying
+func (h *Handler) GetMarketInputs(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    chain, ok := h.Chains.Get(symbol)
+    if !ok {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(h.Market.Inputs(chain, time.Now()))
+}
+
+// GetSurface handles requests for the implied volatility surface of an
+// underlying. The axis query parameter selects "moneyness" (default) or
+// "delta" nodes.
+func (h *Handler) GetSurface(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    axis, err := surface.ParseAxis(r.URL.Query().Get("axis"))
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    s, ok, err := h.Surfaces.Surface(symbol, axis)
+    if !ok {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(s)
+}
+
+// GetSmile handles requests for the fitted smiles of an underlying, refit
+// if its chain changed since the last fit. The expiration query parameter
+// narrows the response to one expiration.
+func (h *Handler) GetSmile(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    if !h.Smiles.Refit(symbol) {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+    fit, _ := h.Smiles.Fit(symbol)
+    if expiration := r.URL.Query().Get("expiration"); expiration != "" {
+        slice, ok := fit.Slice(expiration)
+        if !ok {
+            http.Error(w, "no expiration "+expiration+" for "+symbol, http.StatusNotFound)
+            return
+        }
+        fit.Slices = []smile.Slice{slice}
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(fit)
+}
+
+// GetMetrics handles requests for the ATM term structure and skew metrics
+// of an underlying
+func (h *Handler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    chain, ok := h.Chains.Get(symbol)
+    if !ok || chain.Metrics == nil {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(chain.Metrics)
+}
+
+// GetGEX handles requests for the dealer gamma, delta and vanna exposure
+// profile of an underlying
+func (h *Handler) GetGEX(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    profile, ok, err := h.GEX.Profile(symbol)
+    if !ok {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(profile)
+}
+
+// GetOpenInterest handles requests for the open interest and volume
+// distribution and max pain of an underlying. The expiration query
+// parameter narrows the response to one expiration.
+func (h *Handler) GetOpenInterest(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    chain, ok := h.Chains.Get(symbol)
+    if !ok {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+
+    distribution := oi.Compute(chain)
+    var response interface{} = distribution
+    if expiration := r.URL.Query().Get("expiration"); expiration != "" {
+        e, ok := distribution.Expiration(expiration)
+        if !ok {
+            http.Error(w, "no expiration "+expiration+" for "+symbol, http.StatusNotFound)
+            return
+        }
+        response = e
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(response)
+}
+
+// GetQuality handles requests for the crossed quotes and no-arbitrage
+// violations of an underlying's chain
+func (h *Handler) GetQuality(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    report, ok := h.Quality.Report(symbol)
+    if !ok {
+        http.Error(w, "no chain for "+symbol, http.StatusNotFound)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(report)
+}
+
+// GetRealizedVol handles requests for the realized volatility, volatility
+// cone and IV rank of an underlying
+func (h *Handler) GetRealizedVol(w http.ResponseWriter, r *http.Request) {
+    vars := mux.Vars(r)
+    symbol := strings.ToUpper(vars["symbol"])
+
+    report, ok, err := h.Realized.Report(symbol)
+    if !ok {
+        http.Error(w, "no candles for "+symbol, http.StatusNotFound)
+        return
+    }
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(report)
+}
+
+// AnalyzeStrategy handles requests to analyze a multi-leg position posted
+// as a strategy.Request
+func (h *Handler) AnalyzeStrategy(w http.ResponseWriter, r *http.Request) {
+    var req strategy.Request
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    analysis, err := h.Strategy.Analyze(req)
+    if errors.Is(err, strategy.ErrNoChain) {
+        http.Error(w, err.Error(), http.StatusNotFound)
+        return
+    }
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(analysis)
+}
+
+// BuildStrategy handles requests to pick a templated position, posted as a
+// strategy.BuildRequest, from the chain and analyze it
+func (h *Handler) BuildStrategy(w http.ResponseWriter, r *http.Request) {
+    var req strategy.BuildRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    analysis, err := h.Strategy.Build(req)
+    if errors.Is(err, strategy.ErrNoChain) {
+        http.Error(w, err.Error(), http.StatusNotFound)
+        return
+    }
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
 
-    // In a real application, you would fetch this data from Schwab's API
-    chain := models.OptionChain{
-        Symbol:     symbol,
-        Underlying: 150.25,
-        Updated:    time.Now(),
-        Calls:      stream.GenerateSampleOptions("call"),
-        Puts:       stream.GenerateSampleOptions("put"),
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(analysis)
+}
+
+// RunScenario handles requests to reprice positions under shocks, posted
+// as a scenario.Request
+func (h *Handler) RunScenario(w http.ResponseWriter, r *http.Request) {
+    var req scenario.Request
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+        return
     }
 
+    result, err := h.Scenario.Run(r.Context(), req)
+    if errors.Is(err, strategy.ErrNoChain) {
+        http.Error(w, err.Error(), http.StatusNotFound)
+        return
+    }
+    if errors.Is(err, context.DeadlineExceeded) {
+        http.Error(w, err.Error(), http.StatusServiceUnavailable)
+        return
+    }
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(result)
+}
+
+// GetScenarioPresets handles requests for the named stress presets
+func (h *Handler) GetScenarioPresets(w http.ResponseWriter, r *http.Request) {
     w.Header().Set("Content-Type", "application/json")
-    json.NewEncoder(w).Encode(chain)
+    json.NewEncoder(w).Encode(h.Scenario.Presets())
+}
+
+// Screen handles requests to run a contract screen posted as a
+// screener.Query
+func (h *Handler) Screen(w http.ResponseWriter, r *http.Request) {
+    var query screener.Query
+    if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+        http.Error(w, "invalid query: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    result, err := h.Screener.Screen(query)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(result)
+}
+
+// ListScreens handles requests for the saved screens
+func (h *Handler) ListScreens(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(h.Screens.List())
+}
+
+// SaveScreen handles requests to save a screen, posted as a
+// screener.Screen, whose matches then stream on the screen channel
+func (h *Handler) SaveScreen(w http.ResponseWriter, r *http.Request) {
+    var screen screener.Screen
+    if err := json.NewDecoder(r.Body).Decode(&screen); err != nil {
+        http.Error(w, "invalid screen: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    screen, err := h.Screens.Save(screen)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(screen)
+}
+
+// DeleteScreen handles requests to remove a saved screen
+func (h *Handler) DeleteScreen(w http.ResponseWriter, r *http.Request) {
+    name := mux.Vars(r)["name"]
+    if !h.Screens.Delete(name) {
+        http.Error(w, "no screen "+strings.ToUpper(name), http.StatusNotFound)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUnusual handles requests for the unusual activity alerts of the log
+// window, newest first, filtered by the optional symbol and kind query
+// parameters
+func (h *Handler) GetUnusual(w http.ResponseWriter, r *http.Request) {
+    query := r.URL.Query()
+    symbol := strings.ToUpper(query.Get("symbol"))
+
+    var kind unusual.Kind
+    if name := query.Get("kind"); name != "" {
+        var err error
+        kind, err = unusual.ParseKind(name)
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(h.Unusual.Alerts(symbol, kind))
+}
+
+// GetPortfolio handles requests for the portfolio valued from the live
+// chains, with its Greeks beta-weighted to the benchmark
+func (h *Handler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(h.Portfolio.Value())
+}
+
+// ListPositions handles requests for the portfolio's positions
+func (h *Handler) ListPositions(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(h.Portfolio.Holdings())
+}
+
+// AddPosition handles requests to add a position, posted as a
+// portfolio.Holding, replacing any of the same ID
+func (h *Handler) AddPosition(w http.ResponseWriter, r *http.Request) {
+    var holding portfolio.Holding
+    if err := json.NewDecoder(r.Body).Decode(&holding); err != nil {
+        http.Error(w, "invalid position: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    holding, err := h.Portfolio.Add(holding)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(holding)
+}
+
+// ImportPositions handles requests to import positions, posted as a CSV
+// export when the content type is text/csv and as a JSON list of
+// portfolio.Holding otherwise. The replace query parameter drops the
+// existing positions first.
+func (h *Handler) ImportPositions(w http.ResponseWriter, r *http.Request) {
+    replace, _ := strconv.ParseBool(r.URL.Query().Get("replace"))
+
+    var holdings []portfolio.Holding
+    if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+        var err error
+        holdings, err = portfolio.ParseCSV(r.Body)
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+    } else if err := json.NewDecoder(r.Body).Decode(&holdings); err != nil {
+        http.Error(w, "invalid positions: "+err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    holdings, err := h.Portfolio.Import(holdings, replace)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(holdings)
+}
+
+// DeletePosition handles requests to remove a position
+func (h *Handler) DeletePosition(w http.ResponseWriter, r *http.Request) {
+    id := mux.Vars(r)["id"]
+    ok, err := h.Portfolio.Delete(id)
+    if !ok {
+        http.Error(w, "no position "+strings.ToUpper(id), http.StatusNotFound)
+        return
+    }
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
 }
@@ -15,6 +15,29 @@ func SetupRoutes(r *mux.Router, h *Handler) {
     // API endpoints
     r.HandleFunc("/ws", h.HandleWebSocket)
     r.HandleFunc("/api/options/{symbol}", h.GetOptionsChain)
+    r.HandleFunc("/api/stream/{symbol}", h.StreamOptionsChain)
+    r.HandleFunc("/api/market/{symbol}", h.GetMarketInputs)
+    r.HandleFunc("/api/surface/{symbol}", h.GetSurface)
+    r.HandleFunc("/api/smile/{symbol}", h.GetSmile)
+    r.HandleFunc("/api/metrics/{symbol}", h.GetMetrics)
+    r.HandleFunc("/api/gex/{symbol}", h.GetGEX)
+    r.HandleFunc("/api/oi/{symbol}", h.GetOpenInterest)
+    r.HandleFunc("/api/realized/{symbol}", h.GetRealizedVol)
+    r.HandleFunc("/api/quality/{symbol}", h.GetQuality)
+    r.HandleFunc("/api/strategy/analyze", h.AnalyzeStrategy).Methods(http.MethodPost)
+    r.HandleFunc("/api/strategy/build", h.BuildStrategy).Methods(http.MethodPost)
+    r.HandleFunc("/api/scenario", h.RunScenario).Methods(http.MethodPost)
+    r.HandleFunc("/api/scenario/presets", h.GetScenarioPresets).Methods(http.MethodGet)
+    r.HandleFunc("/api/unusual", h.GetUnusual)
+    r.HandleFunc("/api/screen", h.Screen).Methods(http.MethodPost)
+    r.HandleFunc("/api/screens", h.ListScreens).Methods(http.MethodGet)
+    r.HandleFunc("/api/screens", h.SaveScreen).Methods(http.MethodPost)
+    r.HandleFunc("/api/screens/{name}", h.DeleteScreen).Methods(http.MethodDelete)
+    r.HandleFunc("/api/portfolio", h.GetPortfolio).Methods(http.MethodGet)
+    r.HandleFunc("/api/portfolio/positions", h.ListPositions).Methods(http.MethodGet)
+    r.HandleFunc("/api/portfolio/positions", h.AddPosition).Methods(http.MethodPost)
+    r.HandleFunc("/api/portfolio/positions/{id}", h.DeletePosition).Methods(http.MethodDelete)
+    r.HandleFunc("/api/portfolio/import", h.ImportPositions).Methods(http.MethodPost)
     
     // Serve the main page
     r.HandleFunc("/", h.ServeHome)
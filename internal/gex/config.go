@@ -0,0 +1,99 @@
+package gex
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+)
+
+// Position is the side dealers are assumed to hold in a contract type
+type Position string
+
+const (
+    Long  Position = "long"
+    Short Position = "short"
+)
+
+// ParsePosition parses a dealer position
+func ParsePosition(name string) (Position, error) {
+    switch Position(name) {
+    case Long, Short:
+        return Position(name), nil
+    }
+    return "", fmt.Errorf("unknown dealer position: %q", name)
+}
+
+// sign is +1 for a long position and -1 for a short one
+func (p Position) sign() float64 {
+    if p == Short {
+        return -1
+    }
+    return 1
+}
+
+// Config holds the dealer positioning assumptions
+type Config struct {
+    DealerCalls Position // side dealers hold in calls
+    DealerPuts  Position // side dealers hold in puts
+    FlipRange   float64  // fraction of spot searched either side for the zero-gamma level
+    FlipSteps   int      // spot levels evaluated across the search range
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    calls, err := ParsePosition(getEnvOrDefault("GEX_DEALER_CALLS", string(Long)))
+    if err != nil {
+        return nil, err
+    }
+    puts, err := ParsePosition(getEnvOrDefault("GEX_DEALER_PUTS", string(Short)))
+    if err != nil {
+        return nil, err
+    }
+
+    config := &Config{
+        DealerCalls: calls,
+        DealerPuts:  puts,
+        FlipRange:   getFloatOrDefault("GEX_FLIP_RANGE", 0.2),
+        FlipSteps:   getIntOrDefault("GEX_FLIP_STEPS", 200),
+    }
+
+    if config.FlipRange <= 0 || config.FlipRange >= 1 {
+        return nil, fmt.Errorf("invalid zero-gamma search range: %v", config.FlipRange)
+    }
+    if config.FlipSteps < 2 {
+        return nil, fmt.Errorf("invalid zero-gamma search steps: %d", config.FlipSteps)
+    }
+
+    return config, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
+
+func getFloatOrDefault(key string, defaultValue float64) float64 {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := strconv.ParseFloat(str, 64)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
+
+func getIntOrDefault(key string, defaultValue int) int {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := strconv.Atoi(str)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
@@ -0,0 +1,190 @@
+package gex
+
+import (
+    "errors"
+    "math"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// multiplier is the number of shares one contract delivers
+const multiplier = 100
+
+// ErrNoOpenInterest is returned for chains without open interest
+var ErrNoOpenInterest = errors.New("no open interest")
+
+// Strike holds the dealer exposures at one strike, summed across
+// expirations. Gamma exposure is in dollars of delta per 1% move in the
+// underlying, delta exposure in dollars and vanna exposure in dollars of
+// delta per vol point.
+type Strike struct {
+    Strike  float64 `json:"strike"`
+    CallOI  int     `json:"callOpenInterest"`
+    PutOI   int     `json:"putOpenInterest"`
+    CallGEX float64 `json:"callGex"`
+    PutGEX  float64 `json:"putGex"`
+    GEX     float64 `json:"gex"`
+    DEX     float64 `json:"dex"`
+    VEX     float64 `json:"vex"`
+}
+
+// Profile is the dealer positioning of an underlying
+type Profile struct {
+    Symbol      string    `json:"symbol"`
+    Underlying  float64   `json:"underlyingPrice"`
+    Updated     time.Time `json:"lastUpdated"`
+    DealerCalls Position  `json:"dealerCalls"`
+    DealerPuts  Position  `json:"dealerPuts"`
+
+    GEX       float64 `json:"gex"`
+    DEX       float64 `json:"dex"`
+    VEX       float64 `json:"vex"`
+    ZeroGamma float64 `json:"zeroGamma"` // spot where net GEX changes sign, 0 if none in range
+    CallWall  float64 `json:"callWall"`  // strike with the largest call GEX
+    PutWall   float64 `json:"putWall"`   // strike with the largest put GEX
+
+    Strikes []Strike `json:"strikes"`
+}
+
+// Compute builds the dealer positioning profile of a chain. Exposures at
+// the current spot use the chain's Greeks; the zero-gamma level reprices
+// gamma with Black-Scholes-Merton at each spot level searched.
+func Compute(chain models.OptionChain, config Config, market analytics.MarketParams, now time.Time) (Profile, error) {
+    profile := Profile{
+        Symbol:      chain.Symbol,
+        Underlying:  chain.Underlying,
+        Updated:     chain.Updated,
+        DealerCalls: config.DealerCalls,
+        DealerPuts:  config.DealerPuts,
+    }
+
+    spot := chain.Underlying
+    strikes := make(map[float64]*Strike)
+    add := func(options []models.OptionData, position Position) {
+        sign := position.sign()
+        for _, option := range options {
+            if option.OpenInt <= 0 {
+                continue
+            }
+            s, ok := strikes[option.Strike]
+            if !ok {
+                s = &Strike{Strike: option.Strike}
+                strikes[option.Strike] = s
+            }
+            size := float64(option.OpenInt) * multiplier
+            gex := sign * option.Gamma * size * spot * spot * 0.01
+            if option.Type == "call" {
+                s.CallOI += option.OpenInt
+                s.CallGEX += gex
+            } else {
+                s.PutOI += option.OpenInt
+                s.PutGEX += gex
+            }
+            s.GEX += gex
+            s.DEX += sign * option.Delta * size * spot
+            s.VEX += sign * option.Vanna * size * spot
+        }
+    }
+    add(chain.Calls, config.DealerCalls)
+    add(chain.Puts, config.DealerPuts)
+    if len(strikes) == 0 {
+        return profile, ErrNoOpenInterest
+    }
+
+    var callWall, putWall float64
+    for _, s := range strikes {
+        profile.Strikes = append(profile.Strikes, *s)
+        profile.GEX += s.GEX
+        profile.DEX += s.DEX
+        profile.VEX += s.VEX
+        if math.Abs(s.CallGEX) > callWall {
+            callWall = math.Abs(s.CallGEX)
+            profile.CallWall = s.Strike
+        }
+        if math.Abs(s.PutGEX) > putWall {
+            putWall = math.Abs(s.PutGEX)
+            profile.PutWall = s.Strike
+        }
+    }
+    sort.Slice(profile.Strikes, func(i, j int) bool { return profile.Strikes[i].Strike < profile.Strikes[j].Strike })
+
+    profile.ZeroGamma = zeroGamma(chain, config, market, now)
+    return profile, nil
+}
+
+// exposure is an open position repriced across spot levels
+type exposure struct {
+    params analytics.Params
+    size   float64 // signed shares
+}
+
+// zeroGamma searches spot levels around the current spot for the one where
+// dealers' net gamma exposure changes sign, returning the crossing nearest
+// the spot or 0 if there is none
+func zeroGamma(chain models.OptionChain, config Config, market analytics.MarketParams, now time.Time) float64 {
+    spot := chain.Underlying
+    if spot <= 0 {
+        return 0
+    }
+    yield, dividends := market.CashDividends(chain.Symbol, now)
+
+    var positions []exposure
+    add := func(options []models.OptionData, position Position) {
+        for _, option := range options {
+            if option.OpenInt <= 0 || option.ImpliedVol <= 0 {
+                continue
+            }
+            t, err := analytics.YearsToExpiration(option.Expiration, now)
+            if err != nil || t <= 0 {
+                continue
+            }
+            positions = append(positions, exposure{
+                params: analytics.Params{
+                    Strike:    option.Strike,
+                    T:         t,
+                    Rate:      market.RiskFreeRate(t),
+                    Dividend:  yield,
+                    Dividends: dividends,
+                    Vol:       option.ImpliedVol,
+                    Call:      option.Type == "call",
+                },
+                size: position.sign() * float64(option.OpenInt) * multiplier,
+            })
+        }
+    }
+    add(chain.Calls, config.DealerCalls)
+    add(chain.Puts, config.DealerPuts)
+    if len(positions) == 0 {
+        return 0
+    }
+
+    netGamma := func(level float64) float64 {
+        var total float64
+        for _, position := range positions {
+            p := position.params
+            p.Spot = level
+            total += position.size * analytics.BSMGreeks(p).Gamma * level * level * 0.01
+        }
+        return total
+    }
+
+    lower := spot * (1 - config.FlipRange)
+    step := 2 * spot * config.FlipRange / float64(config.FlipSteps)
+    best, flip := math.Inf(1), 0.0
+    prevLevel, prev := lower, netGamma(lower)
+    for i := 1; i <= config.FlipSteps; i++ {
+        level := lower + float64(i)*step
+        value := netGamma(level)
+        if (prev < 0) != (value < 0) {
+            crossing := prevLevel + (level-prevLevel)*prev/(prev-value)
+            if math.Abs(crossing-spot) < best {
+                best, flip = math.Abs(crossing-spot), crossing
+            }
+        }
+        prevLevel, prev = level, value
+    }
+    return flip
+}
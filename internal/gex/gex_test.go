@@ -0,0 +1,131 @@
+package gex
+
+import (
+    "errors"
+    "math"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// flatMarket has no rates and no dividends
+type flatMarket struct{}
+
+func (flatMarket) RiskFreeRate(t float64) float64 {
+    return 0
+}
+
+func (flatMarket) CashDividends(symbol string, now time.Time) (float64, []analytics.CashDividend) {
+    return 0, nil
+}
+
+var (
+    testNow    = time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+    testConfig = Config{DealerCalls: Long, DealerPuts: Short, FlipRange: 0.2, FlipSteps: 400}
+)
+
+func contract(optionType string, strike, gamma float64, openInt int) models.OptionData {
+    return models.OptionData{
+        Strike:     strike,
+        Expiration: "2026-12-18",
+        Type:       optionType,
+        ImpliedVol: 0.2,
+        Gamma:      gamma,
+        Delta:      0.5,
+        OpenInt:    openInt,
+    }
+}
+
+func TestCompute(t *testing.T) {
+    chain := models.OptionChain{
+        Symbol:     "SPY",
+        Underlying: 100,
+        Calls: []models.OptionData{
+            contract("call", 105, 0.03, 2000),
+            contract("call", 110, 0.02, 5000),
+        },
+        Puts: []models.OptionData{
+            contract("put", 90, 0.02, 4000),
+            contract("put", 95, 0.03, 1000),
+            contract("put", 100, 0.04, 0),
+        },
+    }
+
+    profile, err := Compute(chain, testConfig, flatMarket{}, testNow)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // GEX is gamma * OI * 100 * spot^2 * 1%, long calls and short puts
+    want := map[float64]float64{
+        90:  -0.02 * 4000 * 100 * 100,
+        95:  -0.03 * 1000 * 100 * 100,
+        105: 0.03 * 2000 * 100 * 100,
+        110: 0.02 * 5000 * 100 * 100,
+    }
+    if len(profile.Strikes) != len(want) {
+        t.Fatalf("strikes = %+v, want only those with open interest", profile.Strikes)
+    }
+    var total float64
+    for _, s := range profile.Strikes {
+        if math.Abs(s.GEX-want[s.Strike]) > 1e-6 {
+            t.Errorf("GEX at %v = %.0f, want %.0f", s.Strike, s.GEX, want[s.Strike])
+        }
+        total += want[s.Strike]
+    }
+    if math.Abs(profile.GEX-total) > 1e-6 {
+        t.Errorf("net GEX = %.0f, want %.0f", profile.GEX, total)
+    }
+    if profile.CallWall != 110 {
+        t.Errorf("call wall = %v, want 110", profile.CallWall)
+    }
+    if profile.PutWall != 90 {
+        t.Errorf("put wall = %v, want 90", profile.PutWall)
+    }
+}
+
+func TestZeroGamma(t *testing.T) {
+    years, err := analytics.YearsToExpiration("2026-12-18", testNow)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // With no carry, gamma at strike K is phi(d1) / (S vol sqrt(T)), so a
+    // long call at 110 and a short put at 90 of equal size cancel where
+    // d1 is opposite: S = sqrt(110 * 90) * exp(-vol^2 T / 2)
+    flip := math.Sqrt(110*90) * math.Exp(-0.2*0.2*years/2)
+
+    tests := []struct {
+        name   string
+        config Config
+        want   float64
+    }{
+        {"long calls, short puts", testConfig, flip},
+        {"long calls and puts", Config{DealerCalls: Long, DealerPuts: Long, FlipRange: 0.2, FlipSteps: 400}, 0},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            chain := models.OptionChain{
+                Symbol:     "SPY",
+                Underlying: 100,
+                Calls:      []models.OptionData{contract("call", 110, 0.02, 1000)},
+                Puts:       []models.OptionData{contract("put", 90, 0.02, 1000)},
+            }
+            profile, err := Compute(chain, tt.config, flatMarket{}, testNow)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if math.Abs(profile.ZeroGamma-tt.want) > 0.01 {
+                t.Errorf("zero gamma = %.4f, want %.4f", profile.ZeroGamma, tt.want)
+            }
+        })
+    }
+}
+
+func TestComputeWithoutOpenInterest(t *testing.T) {
+    chain := models.OptionChain{Symbol: "SPY", Underlying: 100, Calls: []models.OptionData{contract("call", 100, 0.04, 0)}}
+    if _, err := Compute(chain, testConfig, flatMarket{}, testNow); !errors.Is(err, ErrNoOpenInterest) {
+        t.Errorf("err = %v, want ErrNoOpenInterest", err)
+    }
+}
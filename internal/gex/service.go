@@ -0,0 +1,37 @@
+package gex
+
+import (
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// Service computes dealer positioning from the aggregated chains
+type Service struct {
+    chains *store.ChainStore
+    config Config
+    market analytics.MarketParams
+    now    func() time.Time
+}
+
+// NewService creates a positioning service over the chain store
+func NewService(chains *store.ChainStore, config Config, market analytics.MarketParams) *Service {
+    return &Service{
+        chains: chains,
+        config: config,
+        market: market,
+        now:    time.Now,
+    }
+}
+
+// Profile computes the current profile of an underlying. It reports false
+// if no chain is stored for the symbol.
+func (s *Service) Profile(symbol string) (Profile, bool, error) {
+    chain, ok := s.chains.Get(symbol)
+    if !ok {
+        return Profile{}, false, nil
+    }
+    profile, err := Compute(chain, s.config, s.market, s.now())
+    return profile, true, err
+}
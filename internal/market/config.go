@@ -0,0 +1,106 @@
+package market
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+)
+
+// Config holds the sources of rates and dividends
+type Config struct {
+    RiskFreeRate   float64            // flat rate used when no curve is given
+    RateCurve      []RatePoint        // risk-free term structure from RISK_FREE_CURVE
+    DividendYield  float64            // yield for underlyings without their own
+    DividendYields map[string]float64 // yield per underlying from DIVIDEND_YIELDS
+    File           string             // JSON file with curves and dividend schedules
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        RiskFreeRate:  getFloatOrDefault("RISK_FREE_RATE", 0.045),
+        DividendYield: getFloatOrDefault("DIVIDEND_YIELD", 0),
+        File:          os.Getenv("MARKET_DATA_FILE"),
+    }
+
+    var err error
+    config.RateCurve, err = parseRateCurve(os.Getenv("RISK_FREE_CURVE"))
+    if err != nil {
+        return nil, err
+    }
+    config.DividendYields, err = parseYields(os.Getenv("DIVIDEND_YIELDS"))
+    if err != nil {
+        return nil, err
+    }
+
+    if config.RiskFreeRate < -0.1 || config.RiskFreeRate > 1 {
+        return nil, fmt.Errorf("invalid risk-free rate: %v", config.RiskFreeRate)
+    }
+    if config.DividendYield < 0 || config.DividendYield > 1 {
+        return nil, fmt.Errorf("invalid dividend yield: %v", config.DividendYield)
+    }
+
+    return config, nil
+}
+
+// parseRateCurve parses a term structure in the form "30:0.052,365:0.048",
+// mapping days to expiration to continuously compounded rates
+func parseRateCurve(str string) ([]RatePoint, error) {
+    var points []RatePoint
+    for _, entry := range splitList(str) {
+        days, rate, ok := strings.Cut(entry, ":")
+        if !ok {
+            return nil, fmt.Errorf("invalid rate curve point: %q", entry)
+        }
+        d, err := strconv.ParseFloat(strings.TrimSpace(days), 64)
+        if err != nil || d < 0 {
+            return nil, fmt.Errorf("invalid rate curve tenor: %q", entry)
+        }
+        r, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid rate curve rate: %q", entry)
+        }
+        points = append(points, RatePoint{Days: d, Rate: r})
+    }
+    return points, nil
+}
+
+// parseYields parses dividend yields in the form "SPY:0.013,QQQ:0.006"
+func parseYields(str string) (map[string]float64, error) {
+    yields := make(map[string]float64)
+    for _, entry := range splitList(str) {
+        symbol, yield, ok := strings.Cut(entry, ":")
+        if !ok || strings.TrimSpace(symbol) == "" {
+            return nil, fmt.Errorf("invalid dividend yield entry: %q", entry)
+        }
+        y, err := strconv.ParseFloat(strings.TrimSpace(yield), 64)
+        if err != nil || y < 0 || y > 1 {
+            return nil, fmt.Errorf("invalid dividend yield entry: %q", entry)
+        }
+        yields[strings.ToUpper(strings.TrimSpace(symbol))] = y
+    }
+    return yields, nil
+}
+
+func splitList(str string) []string {
+    var entries []string
+    for _, entry := range strings.Split(str, ",") {
+        if entry = strings.TrimSpace(entry); entry != "" {
+            entries = append(entries, entry)
+        }
+    }
+    return entries
+}
+
+func getFloatOrDefault(key string, defaultValue float64) float64 {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    val, err := strconv.ParseFloat(str, 64)
+    if err != nil {
+        return defaultValue
+    }
+    return val
+}
@@ -0,0 +1,179 @@
+package market
+
+import (
+    "math"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// parityStrikes is the number of strikes nearest the spot used to imply
+// each forward
+const parityStrikes = 5
+
+// ImpliedForward is the forward price of an underlying for one expiration
+// implied by put-call parity on the live chain
+type ImpliedForward struct {
+    Expiration   string  `json:"expiration"`
+    Years        float64 `json:"yearsToExpiration"`
+    Rate         float64 `json:"rate"`
+    Forward      float64 `json:"forward"`
+    ImpliedYield float64 `json:"impliedYield"` // carry yield implied by the forward, beyond discrete dividends
+    Borrow       float64 `json:"borrow"`       // implied yield less the known dividend yield
+    Strikes      int     `json:"strikes"`      // call/put pairs the forward was implied from
+}
+
+// Inputs are the market parameters of one underlying
+type Inputs struct {
+    Symbol     string           `json:"symbol"`
+    Underlying float64          `json:"underlyingPrice"`
+    Rates      []RatePoint      `json:"rates"`
+    Dividends  Dividends        `json:"dividends"`
+    Forwards   []ImpliedForward `json:"forwards"`
+}
+
+// Inputs returns the rates, dividends and implied forwards of a chain
+func (p *Params) Inputs(chain models.OptionChain, now time.Time) Inputs {
+    return Inputs{
+        Symbol:     chain.Symbol,
+        Underlying: chain.Underlying,
+        Rates:      p.RateCurve(),
+        Dividends:  p.Dividends(chain.Symbol),
+        Forwards:   p.ImpliedForwards(chain, now),
+    }
+}
+
+// ImpliedForwards derives the forward of every expiration from put-call
+// parity, C - P = DF * (F - K), using the mid prices of the two-sided,
+// uncrossed call/put pairs nearest the spot and taking the median. The implied
+// yield is the continuous carry that explains the forward after discrete
+// dividends; for American options early exercise biases it slightly.
+func (p *Params) ImpliedForwards(chain models.OptionChain, now time.Time) []ImpliedForward {
+    if chain.Underlying <= 0 {
+        return nil
+    }
+
+    type pair struct{ call, put float64 }
+    pairs := make(map[string]map[float64]*pair)
+    add := func(options []models.OptionData, call bool) {
+        for _, option := range options {
+            if option.Bid <= 0 || option.Ask <= 0 || option.Bid > option.Ask {
+                continue
+            }
+            strikes, ok := pairs[option.Expiration]
+            if !ok {
+                strikes = make(map[float64]*pair)
+                pairs[option.Expiration] = strikes
+            }
+            pr, ok := strikes[option.Strike]
+            if !ok {
+                pr = &pair{}
+                strikes[option.Strike] = pr
+            }
+            if call {
+                pr.call = (option.Bid + option.Ask) / 2
+            } else {
+                pr.put = (option.Bid + option.Ask) / 2
+            }
+        }
+    }
+    add(chain.Calls, true)
+    add(chain.Puts, false)
+
+    yield, dividends := p.CashDividends(chain.Symbol, now)
+    var forwards []ImpliedForward
+    for expiration, strikes := range pairs {
+        t, err := analytics.YearsToExpiration(expiration, now)
+        if err != nil || t <= 0 {
+            continue
+        }
+        rate := p.RiskFreeRate(t)
+
+        var candidates []float64
+        for strike, pr := range strikes {
+            if pr.call > 0 && pr.put > 0 {
+                candidates = append(candidates, strike)
+            }
+        }
+        if len(candidates) == 0 {
+            continue
+        }
+        sort.Slice(candidates, func(i, j int) bool {
+            return math.Abs(candidates[i]-chain.Underlying) < math.Abs(candidates[j]-chain.Underlying)
+        })
+        if len(candidates) > parityStrikes {
+            candidates = candidates[:parityStrikes]
+        }
+
+        estimates := make([]float64, len(candidates))
+        for i, strike := range candidates {
+            pr := strikes[strike]
+            estimates[i] = strike + (pr.call-pr.put)*math.Exp(rate*t)
+        }
+        forward := median(estimates)
+        if forward <= 0 {
+            continue
+        }
+
+        spot := chain.Underlying - presentValue(dividends, t, rate)
+        if spot <= 0 {
+            continue
+        }
+        implied := rate - math.Log(forward/spot)/t
+        forwards = append(forwards, ImpliedForward{
+            Expiration:   expiration,
+            Years:        t,
+            Rate:         rate,
+            Forward:      forward,
+            ImpliedYield: implied,
+            Borrow:       implied - yield,
+            Strikes:      len(candidates),
+        })
+    }
+
+    sort.Slice(forwards, func(i, j int) bool { return forwards[i].Expiration < forwards[j].Expiration })
+    return forwards
+}
+
+// Forward returns the theoretical forward of an underlying for a horizon
+// in years from the rate curve and known dividends
+func (p *Params) Forward(symbol string, spot, t float64, now time.Time) float64 {
+    yield, dividends := p.CashDividends(symbol, now)
+    rate := p.RiskFreeRate(t)
+    return (spot - presentValue(dividends, t, rate)) * math.Exp((rate-yield)*t)
+}
+
+// Forwards returns the forward of every expiration in the chain: implied
+// by put-call parity where the chain has two-sided pairs, and theoretical
+// otherwise
+func (p *Params) Forwards(chain models.OptionChain, now time.Time) map[string]float64 {
+    forwards := make(map[string]float64)
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for _, option := range options {
+            if _, ok := forwards[option.Expiration]; ok {
+                continue
+            }
+            t, err := analytics.YearsToExpiration(option.Expiration, now)
+            if err != nil || t <= 0 {
+                continue
+            }
+            forwards[option.Expiration] = p.Forward(chain.Symbol, chain.Underlying, t, now)
+        }
+    }
+    for _, implied := range p.ImpliedForwards(chain, now) {
+        forwards[implied.Expiration] = implied.Forward
+    }
+    return forwards
+}
+
+func median(values []float64) float64 {
+    sorted := append([]float64(nil), values...)
+    sort.Float64s(sorted)
+    n := len(sorted)
+    if n%2 == 1 {
+        return sorted[n/2]
+    }
+    return (sorted[n/2-1] + sorted[n/2]) / 2
+}
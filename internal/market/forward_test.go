@@ -0,0 +1,122 @@
+package market
+
+import (
+    "math"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+var testNow = time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+
+// testParams has an upward sloping curve, so a dividend discounted along
+// it and at the rate to expiration differ, and a $2 dividend on XYZ going
+// ex in January
+func testParams() *Params {
+    return &Params{
+        curve:        []RatePoint{{Days: 30, Rate: 0.02}, {Days: 365, Rate: 0.05}},
+        defaultYield: 0.01,
+        dividends: map[string]Dividends{
+            "XYZ": {Schedule: []Dividend{{ExDate: "2027-01-15", Amount: 2}}},
+        },
+    }
+}
+
+func TestRiskFreeRate(t *testing.T) {
+    p := testParams()
+    tests := []struct {
+        days float64
+        want float64
+    }{
+        {7, 0.02},
+        {30, 0.02},
+        {197.5, 0.035},
+        {365, 0.05},
+        {730, 0.05},
+    }
+    for _, tt := range tests {
+        if got := p.RiskFreeRate(tt.days / 365); math.Abs(got-tt.want) > 1e-12 {
+            t.Errorf("RiskFreeRate(%v days) = %v, want %v", tt.days, got, tt.want)
+        }
+    }
+}
+
+func TestForward(t *testing.T) {
+    p := testParams()
+    const spot, years = 100.0, 0.5
+    rate := p.RiskFreeRate(years)
+    _, dividends := p.CashDividends("XYZ", testNow)
+    if len(dividends) != 1 {
+        t.Fatalf("dividends = %+v, want the one still to go ex", dividends)
+    }
+
+    tests := []struct {
+        name   string
+        symbol string
+        want   float64
+    }{
+        {"default yield", "SPY", spot * math.Exp((rate-0.01)*years)},
+        {"discrete dividend", "XYZ", (spot - 2*math.Exp(-rate*dividends[0].T)) * math.Exp(rate*years)},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := p.Forward(tt.symbol, spot, years, testNow); math.Abs(got-tt.want) > 1e-9 {
+                t.Errorf("Forward = %.6f, want %.6f", got, tt.want)
+            }
+        })
+    }
+}
+
+// TestImpliedForwardsMatchPricer prices a chain with the pricer and checks
+// put-call parity gives back the theoretical forward and no borrow
+func TestImpliedForwardsMatchPricer(t *testing.T) {
+    p := testParams()
+    const spot, expiration = 100.0, "2027-04-16"
+    years, err := analytics.YearsToExpiration(expiration, testNow)
+    if err != nil {
+        t.Fatal(err)
+    }
+    yield, dividends := p.CashDividends("XYZ", testNow)
+
+    chain := models.OptionChain{Symbol: "XYZ", Underlying: spot}
+    for strike := 90.0; strike <= 110; strike += 5 {
+        for _, call := range []bool{true, false} {
+            price := analytics.BSMPrice(analytics.Params{
+                Spot:      spot,
+                Strike:    strike,
+                T:         years,
+                Rate:      p.RiskFreeRate(years),
+                Dividend:  yield,
+                Dividends: dividends,
+                Vol:       0.3,
+                Call:      call,
+            })
+            option := models.OptionData{Strike: strike, Expiration: expiration, Bid: price - 0.01, Ask: price + 0.01}
+            if call {
+                chain.Calls = append(chain.Calls, option)
+            } else {
+                chain.Puts = append(chain.Puts, option)
+            }
+        }
+    }
+
+    forwards := p.ImpliedForwards(chain, testNow)
+    if len(forwards) != 1 {
+        t.Fatalf("forwards = %+v, want one expiration", forwards)
+    }
+    f := forwards[0]
+    if want := p.Forward("XYZ", spot, years, testNow); math.Abs(f.Forward-want) > 1e-9 {
+        t.Errorf("implied forward = %.6f, want %.6f", f.Forward, want)
+    }
+    if math.Abs(f.ImpliedYield) > 1e-9 || math.Abs(f.Borrow) > 1e-9 {
+        t.Errorf("implied yield, borrow = %g, %g, want the dividend fully explained", f.ImpliedYield, f.Borrow)
+    }
+    if f.Strikes != parityStrikes {
+        t.Errorf("implied from %d strikes, want %d", f.Strikes, parityStrikes)
+    }
+    if got := p.Forwards(chain, testNow)[expiration]; got != f.Forward {
+        t.Errorf("Forwards = %.6f, want the implied %.6f", got, f.Forward)
+    }
+}
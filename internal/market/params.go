@@ -0,0 +1,159 @@
+package market
+
+import (
+    "encoding/json"
+    "fmt"
+    "math"
+    "os"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+)
+
+// RatePoint is one tenor of the risk-free curve
+type RatePoint struct {
+    Days float64 `json:"days"` // days to expiration
+    Rate float64 `json:"rate"` // continuously compounded
+}
+
+// Dividend is a discrete dividend payment
+type Dividend struct {
+    ExDate string  `json:"exDate"` // 2006-01-02
+    Amount float64 `json:"amount"`
+}
+
+// Dividends describes how an underlying pays dividends: a continuous
+// yield, a schedule of discrete payments, or both
+type Dividends struct {
+    Yield    float64    `json:"yield"`
+    Schedule []Dividend `json:"schedule,omitempty"`
+}
+
+// dataFile is the layout of MARKET_DATA_FILE
+type dataFile struct {
+    Rates     []RatePoint          `json:"rates"`
+    Dividends map[string]Dividends `json:"dividends"`
+}
+
+// Params holds the rates and dividends used for pricing. It is read-only
+// once built and safe for concurrent use.
+type Params struct {
+    curve        []RatePoint // sorted by tenor
+    defaultYield float64
+    dividends    map[string]Dividends
+}
+
+// NewParams builds market parameters from the configuration. The data
+// file is read first; the rate curve and dividend yields from the
+// environment take precedence over it. Without any curve the flat
+// RiskFreeRate applies to every tenor.
+func NewParams(config Config) (*Params, error) {
+    p := &Params{
+        defaultYield: config.DividendYield,
+        dividends:    make(map[string]Dividends),
+    }
+
+    if config.File != "" {
+        data, err := os.ReadFile(config.File)
+        if err != nil {
+            return nil, fmt.Errorf("reading market data file: %w", err)
+        }
+        var file dataFile
+        if err := json.Unmarshal(data, &file); err != nil {
+            return nil, fmt.Errorf("parsing market data file: %w", err)
+        }
+        p.curve = file.Rates
+        for symbol, dividends := range file.Dividends {
+            for _, d := range dividends.Schedule {
+                if _, err := time.Parse("2006-01-02", d.ExDate); err != nil {
+                    return nil, fmt.Errorf("invalid ex-dividend date for %s: %q", symbol, d.ExDate)
+                }
+            }
+            p.dividends[strings.ToUpper(symbol)] = dividends
+        }
+    }
+
+    if len(config.RateCurve) > 0 {
+        p.curve = config.RateCurve
+    }
+    if len(p.curve) == 0 {
+        p.curve = []RatePoint{{Days: 0, Rate: config.RiskFreeRate}}
+    }
+    p.curve = append([]RatePoint(nil), p.curve...)
+    sort.Slice(p.curve, func(i, j int) bool { return p.curve[i].Days < p.curve[j].Days })
+
+    for symbol, yield := range config.DividendYields {
+        dividends := p.dividends[symbol]
+        dividends.Yield = yield
+        p.dividends[symbol] = dividends
+    }
+
+    return p, nil
+}
+
+// RateCurve returns the points of the risk-free curve
+func (p *Params) RateCurve() []RatePoint {
+    return append([]RatePoint(nil), p.curve...)
+}
+
+// RiskFreeRate returns the rate to a horizon in years, interpolated
+// linearly in days between curve points and flat beyond the ends
+func (p *Params) RiskFreeRate(t float64) float64 {
+    days := t * 365
+    curve := p.curve
+    if days <= curve[0].Days {
+        return curve[0].Rate
+    }
+    for i := 1; i < len(curve); i++ {
+        if days <= curve[i].Days {
+            lo, hi := curve[i-1], curve[i]
+            return lo.Rate + (hi.Rate-lo.Rate)*(days-lo.Days)/(hi.Days-lo.Days)
+        }
+    }
+    return curve[len(curve)-1].Rate
+}
+
+// Dividends returns the dividend inputs of an underlying
+func (p *Params) Dividends(symbol string) Dividends {
+    dividends, ok := p.dividends[strings.ToUpper(symbol)]
+    if !ok {
+        return Dividends{Yield: p.defaultYield}
+    }
+    return dividends
+}
+
+// CashDividends returns the dividend yield of an underlying and its
+// discrete dividends still to go ex, timed from now. A stock goes ex after
+// the close on the day before the ex-dividend date.
+func (p *Params) CashDividends(symbol string, now time.Time) (float64, []analytics.CashDividend) {
+    dividends := p.Dividends(symbol)
+    var cash []analytics.CashDividend
+    for _, d := range dividends.Schedule {
+        exDate, err := time.Parse("2006-01-02", d.ExDate)
+        if err != nil {
+            continue
+        }
+        t, err := analytics.YearsToExpiration(exDate.AddDate(0, 0, -1).Format("2006-01-02"), now)
+        if err != nil || t <= 0 {
+            continue
+        }
+        cash = append(cash, analytics.CashDividend{T: t, Amount: d.Amount})
+    }
+    return dividends.Yield, cash
+}
+
+// presentValue is the value today of the discrete dividends paid before t,
+// discounted at the flat rate to t rather than along the curve. The pricer
+// takes one rate per contract, so this keeps forwards equal to the forward
+// a contract expiring at t is priced against.
+func presentValue(dividends []analytics.CashDividend, t, rate float64) float64 {
+    var pv float64
+    for _, d := range dividends {
+        if d.T <= t {
+            pv += d.Amount * math.Exp(-rate*d.T)
+        }
+    }
+    return pv
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Candle is the open, high, low and close of one price bar
+type Candle struct {
+    Time  time.Time `json:"time"`
+    Open  float64   `json:"open"`
+    High  float64   `json:"high"`
+    Low   float64   `json:"low"`
+    Close float64   `json:"close"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Leg is one line of a position: an option contract, by streamer symbol,
+// or the underlying stock when Contract is empty
+type Leg struct {
+    Contract string `json:"contract,omitempty"`
+    Quantity int    `json:"quantity"` // contracts, or shares of stock; negative when short
+}
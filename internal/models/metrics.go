@@ -0,0 +1,26 @@
+package models
+
+// ExpirationMetrics holds the volatility level and skew of one expiration.
+// Vols are annualised; a value of 0 means the chain could not support it.
+type ExpirationMetrics struct {
+    Expiration       string  `json:"expiration"`
+    DaysToExpiration float64 `json:"daysToExpiration"`
+    Forward          float64 `json:"forward"`
+    ATMIV            float64 `json:"atmIv"` // interpolated at the forward
+    Call25IV         float64 `json:"call25dIv"`
+    Put25IV          float64 `json:"put25dIv"`
+    RiskReversal25   float64 `json:"riskReversal25d"` // 25-delta call IV less 25-delta put IV
+    Butterfly25      float64 `json:"butterfly25d"`    // average 25-delta wing IV less ATM IV
+    PutSkewSlope     float64 `json:"putSkewSlope"`    // IV change per unit of log-moneyness from ATM to the 25-delta put
+    CallSkewSlope    float64 `json:"callSkewSlope"`   // IV change per unit of log-moneyness from ATM to the 25-delta call
+    TermRatio        float64 `json:"termRatio"`       // ATM IV over the next expiration's ATM IV
+}
+
+// VolMetrics holds the ATM volatility term structure and skew of a chain
+type VolMetrics struct {
+    Expirations    []ExpirationMetrics `json:"expirations"`
+    FrontBackRatio float64             `json:"frontBackRatio"` // front over second expiration ATM IV
+    ATMIV30        float64             `json:"atmIv30"`        // constant-maturity 30-day ATM IV
+    ATMIV90        float64             `json:"atmIv90"`        // constant-maturity 90-day ATM IV
+    Ratio30To90    float64             `json:"ratio30To90"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// ExpectedMove is the market-implied move of the underlying by one
+// expiration, from the ATM straddle and from ATM implied volatility. Bounds
+// are centred on the underlying price; probabilities are the lognormal
+// chance, at ATM IV, of finishing inside them.
+type ExpectedMove struct {
+    Expiration       string  `json:"expiration"`
+    DaysToExpiration float64 `json:"daysToExpiration"`
+
+    StraddleStrike      float64 `json:"straddleStrike"`
+    StraddlePrice       float64 `json:"straddlePrice"` // call mid plus put mid, the straddle's expected move
+    StraddleLower       float64 `json:"straddleLower"`
+    StraddleUpper       float64 `json:"straddleUpper"`
+    StraddleProbability float64 `json:"straddleProbability"`
+
+    ATMIV         float64 `json:"atmIv"`
+    IVMove        float64 `json:"ivMove"` // one standard deviation: price * IV * sqrt(T)
+    IVLower       float64 `json:"ivLower"`
+    IVUpper       float64 `json:"ivUpper"`
+    IVProbability float64 `json:"ivProbability"`
+}
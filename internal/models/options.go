@@ -4,6 +4,7 @@ import "time"
 
 // OptionData represents a single option contract
 type OptionData struct {
+    Symbol      string  `json:"symbol"`
     Strike      float64 `json:"strike"`
     Expiration  string  `json:"expiration"`
     Type        string  `json:"type"` // "call" or "put"
@@ -16,14 +17,42 @@ type OptionData struct {
     Gamma       float64 `json:"gamma"`
     Theta       float64 `json:"theta"`
     Vega        float64 `json:"vega"`
+    Rho         float64 `json:"rho"`
     ImpliedVol  float64 `json:"impliedVolatility"`
+
+    // Implied volatilities solved from the quote (0 when there is none)
+    BidIV       float64 `json:"bidIv"`
+    AskIV       float64 `json:"askIv"`
+    MidIV       float64 `json:"midIv"`
+
+    // Second-order Greeks, computed by the pricing engine
+    Vanna       float64 `json:"vanna"`
+    Charm       float64 `json:"charm"`
+    Vomma       float64 `json:"vomma"`
+    Speed       float64 `json:"speed"`
+
+    // Implied volatility from the fitted smile, a fair-value reference
+    FittedIV    float64 `json:"fittedIv"`
+
+    // Probabilities of expiring in the money, of trading at the strike
+    // before expiration and of profit for a buyer at the mid
+    PITM        float64 `json:"pitm"`
+    PTouch      float64 `json:"probTouch"`
+    POP         float64 `json:"pop"`
+    // Comma-separated quality flags of a quote that is crossed or breaks
+    // no-arbitrage bounds; empty for a clean quote
+    Flags       string  `json:"flags,omitempty"`
 }
 
 // OptionChain represents the full options chain
 type OptionChain struct {
-    Symbol      string       `json:"symbol"`
-    Underlying  float64      `json:"underlyingPrice"`
-    Updated     time.Time    `json:"lastUpdated"`
-    Calls       []OptionData `json:"calls"`
-    Puts        []OptionData `json:"puts"`
+    Symbol      string         `json:"symbol"`
+    Underlying  float64        `json:"underlyingPrice"`
+    Updated     time.Time      `json:"lastUpdated"`
+    Calls       []OptionData   `json:"calls"`
+    Puts        []OptionData   `json:"puts"`
+
+    // Chain-level analytics, streamed as chain fields of deltas
+    Metrics     *VolMetrics    `json:"metrics,omitempty"`
+    Moves       []ExpectedMove `json:"expectedMoves,omitempty"`
 }
@@ -0,0 +1,111 @@
+package models
+
+import (
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Proto converts the chain to its protobuf form
+func (chain OptionChain) Proto() *optionsv1.OptionChain {
+    return &optionsv1.OptionChain{
+        Symbol:          chain.Symbol,
+        UnderlyingPrice: chain.Underlying,
+        LastUpdated:     timestamppb.New(chain.Updated),
+        Calls:           protoOptions(chain.Calls),
+        Puts:            protoOptions(chain.Puts),
+        Metrics:         chain.Metrics.Proto(),
+        ExpectedMoves:   protoExpectedMoves(chain.Moves),
+    }
+}
+
+// protoExpectedMoves converts expected moves to their protobuf form
+func protoExpectedMoves(moves []ExpectedMove) []*optionsv1.ExpectedMove {
+    var out []*optionsv1.ExpectedMove
+    for _, m := range moves {
+        out = append(out, &optionsv1.ExpectedMove{
+            Expiration:          m.Expiration,
+            DaysToExpiration:    m.DaysToExpiration,
+            StraddleStrike:      m.StraddleStrike,
+            StraddlePrice:       m.StraddlePrice,
+            StraddleLower:       m.StraddleLower,
+            StraddleUpper:       m.StraddleUpper,
+            StraddleProbability: m.StraddleProbability,
+            AtmIv:               m.ATMIV,
+            IvMove:              m.IVMove,
+            IvLower:             m.IVLower,
+            IvUpper:             m.IVUpper,
+            IvProbability:       m.IVProbability,
+        })
+    }
+    return out
+}
+
+// Proto converts chain metrics to their protobuf form; nil stays nil
+func (metrics *VolMetrics) Proto() *optionsv1.VolMetrics {
+    if metrics == nil {
+        return nil
+    }
+    out := &optionsv1.VolMetrics{
+        FrontBackRatio: metrics.FrontBackRatio,
+        AtmIv30:        metrics.ATMIV30,
+        AtmIv90:        metrics.ATMIV90,
+        Ratio30To90:    metrics.Ratio30To90,
+    }
+    for _, m := range metrics.Expirations {
+        out.Expirations = append(out.Expirations, &optionsv1.ExpirationMetrics{
+            Expiration:       m.Expiration,
+            DaysToExpiration: m.DaysToExpiration,
+            Forward:          m.Forward,
+            AtmIv:            m.ATMIV,
+            Call25DIv:        m.Call25IV,
+            Put25DIv:         m.Put25IV,
+            RiskReversal25D:  m.RiskReversal25,
+            Butterfly25D:     m.Butterfly25,
+            PutSkewSlope:     m.PutSkewSlope,
+            CallSkewSlope:    m.CallSkewSlope,
+            TermRatio:        m.TermRatio,
+        })
+    }
+    return out
+}
+
+// Proto converts the contract to its protobuf form
+func (option OptionData) Proto() *optionsv1.OptionData {
+    return &optionsv1.OptionData{
+        Symbol:            option.Symbol,
+        Strike:            option.Strike,
+        Expiration:        option.Expiration,
+        Type:              option.Type,
+        Bid:               option.Bid,
+        Ask:               option.Ask,
+        LastPrice:         option.LastPrice,
+        Volume:            int64(option.Volume),
+        OpenInterest:      int64(option.OpenInt),
+        Delta:             option.Delta,
+        Gamma:             option.Gamma,
+        Theta:             option.Theta,
+        Vega:              option.Vega,
+        ImpliedVolatility: option.ImpliedVol,
+        Rho:               option.Rho,
+        Vanna:             option.Vanna,
+        Charm:             option.Charm,
+        Vomma:             option.Vomma,
+        Speed:             option.Speed,
+        BidIv:             option.BidIV,
+        AskIv:             option.AskIV,
+        MidIv:             option.MidIV,
+        FittedIv:          option.FittedIV,
+        Pitm:              option.PITM,
+        ProbTouch:         option.PTouch,
+        Pop:               option.POP,
+        Flags:             option.Flags,
+    }
+}
+
+func protoOptions(options []OptionData) []*optionsv1.OptionData {
+    out := make([]*optionsv1.OptionData, len(options))
+    for i, option := range options {
+        out[i] = option.Proto()
+    }
+    return out
+}
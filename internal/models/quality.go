@@ -0,0 +1,24 @@
+package models
+
+import "strings"
+
+// Quality flags set on contracts whose quotes are crossed or break
+// no-arbitrage bounds
+const (
+    FlagCrossed      = "crossed"      // bid above ask
+    FlagLocked       = "locked"       // bid equal to ask
+    FlagParity       = "parity"       // call and put of the strike break put-call parity
+    FlagMonotonicity = "monotonicity" // priced the wrong way round against the adjacent strike
+    FlagButterfly    = "butterfly"    // leg of a butterfly that can be bought for a credit
+    FlagCalendar     = "calendar"     // bid above the ask of the same strike in the next expiration
+)
+
+// HasFlag reports whether a contract carries a quality flag
+func (o OptionData) HasFlag(flag string) bool {
+    for _, f := range strings.Split(o.Flags, ",") {
+        if f == flag {
+            return true
+        }
+    }
+    return false
+}
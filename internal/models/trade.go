@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Trade is a single time and sale print of a contract
+type Trade struct {
+    Symbol     string    `json:"symbol"`
+    Underlying string    `json:"underlying"` // empty unless an option
+    Time       time.Time `json:"time"`
+    Price      float64   `json:"price"`
+    Size       float64   `json:"size"`
+    Exchange   string    `json:"exchange"`
+    Side       string    `json:"side,omitempty"` // aggressor: "buy", "sell" or empty if unknown
+    Bid        float64   `json:"bid"`            // market at the time of the print
+    Ask        float64   `json:"ask"`
+    SpreadLeg  bool      `json:"spreadLeg,omitempty"` // part of a multi-leg order
+}
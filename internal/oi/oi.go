@@ -0,0 +1,168 @@
+package oi
+
+import (
+    "math"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// Strike holds the open interest and volume of the calls and puts at one
+// strike
+type Strike struct {
+    Strike     float64 `json:"strike"`
+    CallOI     int     `json:"callOpenInterest"`
+    PutOI      int     `json:"putOpenInterest"`
+    CallVolume int     `json:"callVolume"`
+    PutVolume  int     `json:"putVolume"`
+}
+
+// Totals are open interest and volume summed over a set of strikes, with
+// their put/call ratios (0 when there are no calls)
+type Totals struct {
+    CallOI          int     `json:"callOpenInterest"`
+    PutOI           int     `json:"putOpenInterest"`
+    CallVolume      int     `json:"callVolume"`
+    PutVolume       int     `json:"putVolume"`
+    PutCallOIRatio  float64 `json:"putCallOiRatio"`
+    PutCallVolRatio float64 `json:"putCallVolumeRatio"`
+}
+
+// Expiration is the distribution of one expiration. MaxPain is the strike
+// at which expiring options would pay their holders the least.
+type Expiration struct {
+    Expiration string   `json:"expiration"`
+    MaxPain    float64  `json:"maxPain"`
+    Strikes    []Strike `json:"strikes"`
+
+    Totals
+}
+
+// Distribution is the open interest and volume of an underlying by strike,
+// across all expirations and per expiration
+type Distribution struct {
+    Symbol      string       `json:"symbol"`
+    Underlying  float64      `json:"underlyingPrice"`
+    Updated     time.Time    `json:"lastUpdated"`
+    Strikes     []Strike     `json:"strikes"`
+    Expirations []Expiration `json:"expirations"`
+
+    Totals
+}
+
+// Compute builds the open interest distribution of a chain
+func Compute(chain models.OptionChain) Distribution {
+    all := make(map[float64]*Strike)
+    byExpiration := make(map[string]map[float64]*Strike)
+    add := func(options []models.OptionData, call bool) {
+        for _, option := range options {
+            strikes, ok := byExpiration[option.Expiration]
+            if !ok {
+                strikes = make(map[float64]*Strike)
+                byExpiration[option.Expiration] = strikes
+            }
+            for _, s := range []*Strike{strikeOf(all, option.Strike), strikeOf(strikes, option.Strike)} {
+                if call {
+                    s.CallOI += option.OpenInt
+                    s.CallVolume += option.Volume
+                } else {
+                    s.PutOI += option.OpenInt
+                    s.PutVolume += option.Volume
+                }
+            }
+        }
+    }
+    add(chain.Calls, true)
+    add(chain.Puts, false)
+
+    d := Distribution{
+        Symbol:     chain.Symbol,
+        Underlying: chain.Underlying,
+        Updated:    chain.Updated,
+        Strikes:    sorted(all),
+    }
+    d.Totals = totals(d.Strikes)
+
+    for expiration, strikes := range byExpiration {
+        e := Expiration{
+            Expiration: expiration,
+            Strikes:    sorted(strikes),
+        }
+        e.Totals = totals(e.Strikes)
+        e.MaxPain = maxPain(e.Strikes)
+        d.Expirations = append(d.Expirations, e)
+    }
+    sort.Slice(d.Expirations, func(i, j int) bool { return d.Expirations[i].Expiration < d.Expirations[j].Expiration })
+
+    return d
+}
+
+// Expiration returns the distribution of one expiration
+func (d Distribution) Expiration(expiration string) (Expiration, bool) {
+    for _, e := range d.Expirations {
+        if e.Expiration == expiration {
+            return e, true
+        }
+    }
+    return Expiration{}, false
+}
+
+// strikeOf returns the entry for a strike, creating it if needed
+func strikeOf(strikes map[float64]*Strike, strike float64) *Strike {
+    s, ok := strikes[strike]
+    if !ok {
+        s = &Strike{Strike: strike}
+        strikes[strike] = s
+    }
+    return s
+}
+
+// sorted returns the strikes in ascending order
+func sorted(strikes map[float64]*Strike) []Strike {
+    out := make([]Strike, 0, len(strikes))
+    for _, s := range strikes {
+        out = append(out, *s)
+    }
+    sort.Slice(out, func(i, j int) bool { return out[i].Strike < out[j].Strike })
+    return out
+}
+
+// totals sums open interest and volume over strikes
+func totals(strikes []Strike) Totals {
+    var t Totals
+    for _, s := range strikes {
+        t.CallOI += s.CallOI
+        t.PutOI += s.PutOI
+        t.CallVolume += s.CallVolume
+        t.PutVolume += s.PutVolume
+    }
+    if t.CallOI > 0 {
+        t.PutCallOIRatio = float64(t.PutOI) / float64(t.CallOI)
+    }
+    if t.CallVolume > 0 {
+        t.PutCallVolRatio = float64(t.PutVolume) / float64(t.CallVolume)
+    }
+    return t
+}
+
+// maxPain returns the strike, as a settlement price, that minimises the
+// intrinsic value paid out on the open interest, or 0 without open
+// interest
+func maxPain(strikes []Strike) float64 {
+    if t := totals(strikes); t.CallOI+t.PutOI == 0 {
+        return 0
+    }
+    best, pain := 0.0, math.Inf(1)
+    for _, settle := range strikes {
+        var payout float64
+        for _, s := range strikes {
+            payout += float64(s.CallOI) * math.Max(settle.Strike-s.Strike, 0)
+            payout += float64(s.PutOI) * math.Max(s.Strike-settle.Strike, 0)
+        }
+        if payout < pain {
+            best, pain = settle.Strike, payout
+        }
+    }
+    return best
+}
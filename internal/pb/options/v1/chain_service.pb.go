@@ -0,0 +1,734 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: options/v1/chain_service.proto
+
+package optionsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ContractFilter narrows the contracts of a chain. Empty fields match
+// everything.
+type ContractFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`               // "call" or "put"
+	Expirations   []string               `protobuf:"bytes,2,rep,name=expirations,proto3" json:"expirations,omitempty"` // YYYY-MM-DD
+	MinStrike     float64                `protobuf:"fixed64,3,opt,name=min_strike,json=minStrike,proto3" json:"min_strike,omitempty"`
+	MaxStrike     float64                `protobuf:"fixed64,4,opt,name=max_strike,json=maxStrike,proto3" json:"max_strike,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContractFilter) Reset() {
+	*x = ContractFilter{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractFilter) ProtoMessage() {}
+
+func (x *ContractFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractFilter.ProtoReflect.Descriptor instead.
+func (*ContractFilter) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ContractFilter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ContractFilter) GetExpirations() []string {
+	if x != nil {
+		return x.Expirations
+	}
+	return nil
+}
+
+func (x *ContractFilter) GetMinStrike() float64 {
+	if x != nil {
+		return x.MinStrike
+	}
+	return 0
+}
+
+func (x *ContractFilter) GetMaxStrike() float64 {
+	if x != nil {
+		return x.MaxStrike
+	}
+	return 0
+}
+
+type GetChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Filter        *ContractFilter        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChainRequest) Reset() {
+	*x = GetChainRequest{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChainRequest) ProtoMessage() {}
+
+func (x *GetChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChainRequest.ProtoReflect.Descriptor instead.
+func (*GetChainRequest) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetChainRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetChainRequest) GetFilter() *ContractFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GetChainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chain         *OptionChain           `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChainResponse) Reset() {
+	*x = GetChainResponse{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChainResponse) ProtoMessage() {}
+
+func (x *GetChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChainResponse.ProtoReflect.Descriptor instead.
+func (*GetChainResponse) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetChainResponse) GetChain() *OptionChain {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+type ListExpirationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpirationsRequest) Reset() {
+	*x = ListExpirationsRequest{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpirationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpirationsRequest) ProtoMessage() {}
+
+func (x *ListExpirationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpirationsRequest.ProtoReflect.Descriptor instead.
+func (*ListExpirationsRequest) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListExpirationsRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// Expiration summarises one expiration date of a chain
+type Expiration struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Date             string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD
+	DaysToExpiration int32                  `protobuf:"varint,2,opt,name=days_to_expiration,json=daysToExpiration,proto3" json:"days_to_expiration,omitempty"`
+	Calls            int32                  `protobuf:"varint,3,opt,name=calls,proto3" json:"calls,omitempty"`
+	Puts             int32                  `protobuf:"varint,4,opt,name=puts,proto3" json:"puts,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Expiration) Reset() {
+	*x = Expiration{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Expiration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expiration) ProtoMessage() {}
+
+func (x *Expiration) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expiration.ProtoReflect.Descriptor instead.
+func (*Expiration) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Expiration) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Expiration) GetDaysToExpiration() int32 {
+	if x != nil {
+		return x.DaysToExpiration
+	}
+	return 0
+}
+
+func (x *Expiration) GetCalls() int32 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *Expiration) GetPuts() int32 {
+	if x != nil {
+		return x.Puts
+	}
+	return 0
+}
+
+type ListExpirationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expirations   []*Expiration          `protobuf:"bytes,1,rep,name=expirations,proto3" json:"expirations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpirationsResponse) Reset() {
+	*x = ListExpirationsResponse{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpirationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpirationsResponse) ProtoMessage() {}
+
+func (x *ListExpirationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpirationsResponse.ProtoReflect.Descriptor instead.
+func (*ListExpirationsResponse) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListExpirationsResponse) GetExpirations() []*Expiration {
+	if x != nil {
+		return x.Expirations
+	}
+	return nil
+}
+
+type StreamChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Filter        *ContractFilter        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamChainRequest) Reset() {
+	*x = StreamChainRequest{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamChainRequest) ProtoMessage() {}
+
+func (x *StreamChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamChainRequest.ProtoReflect.Descriptor instead.
+func (*StreamChainRequest) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamChainRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StreamChainRequest) GetFilter() *ContractFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type StreamChainResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*StreamChainResponse_Snapshot
+	//	*StreamChainResponse_Delta
+	Message       isStreamChainResponse_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamChainResponse) Reset() {
+	*x = StreamChainResponse{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamChainResponse) ProtoMessage() {}
+
+func (x *StreamChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamChainResponse.ProtoReflect.Descriptor instead.
+func (*StreamChainResponse) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamChainResponse) GetMessage() isStreamChainResponse_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *StreamChainResponse) GetSnapshot() *Snapshot {
+	if x != nil {
+		if x, ok := x.Message.(*StreamChainResponse_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *StreamChainResponse) GetDelta() *Delta {
+	if x != nil {
+		if x, ok := x.Message.(*StreamChainResponse_Delta); ok {
+			return x.Delta
+		}
+	}
+	return nil
+}
+
+type isStreamChainResponse_Message interface {
+	isStreamChainResponse_Message()
+}
+
+type StreamChainResponse_Snapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3,oneof"`
+}
+
+type StreamChainResponse_Delta struct {
+	Delta *Delta `protobuf:"bytes,2,opt,name=delta,proto3,oneof"`
+}
+
+func (*StreamChainResponse_Snapshot) isStreamChainResponse_Message() {}
+
+func (*StreamChainResponse_Delta) isStreamChainResponse_Message() {}
+
+type StreamQuotesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Contract symbols to stream; empty streams every contract passing filter
+	Contracts     []string        `protobuf:"bytes,2,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	Filter        *ContractFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamQuotesRequest) Reset() {
+	*x = StreamQuotesRequest{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamQuotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamQuotesRequest) ProtoMessage() {}
+
+func (x *StreamQuotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamQuotesRequest.ProtoReflect.Descriptor instead.
+func (*StreamQuotesRequest) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamQuotesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StreamQuotesRequest) GetContracts() []string {
+	if x != nil {
+		return x.Contracts
+	}
+	return nil
+}
+
+func (x *StreamQuotesRequest) GetFilter() *ContractFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type StreamQuotesResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Quote           *OptionData            `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	UnderlyingPrice float64                `protobuf:"fixed64,2,opt,name=underlying_price,json=underlyingPrice,proto3" json:"underlying_price,omitempty"`
+	LastUpdated     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StreamQuotesResponse) Reset() {
+	*x = StreamQuotesResponse{}
+	mi := &file_options_v1_chain_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamQuotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamQuotesResponse) ProtoMessage() {}
+
+func (x *StreamQuotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_chain_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamQuotesResponse.ProtoReflect.Descriptor instead.
+func (*StreamQuotesResponse) Descriptor() ([]byte, []int) {
+	return file_options_v1_chain_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamQuotesResponse) GetQuote() *OptionData {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *StreamQuotesResponse) GetUnderlyingPrice() float64 {
+	if x != nil {
+		return x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *StreamQuotesResponse) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+var File_options_v1_chain_service_proto protoreflect.FileDescriptor
+
+const file_options_v1_chain_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1eoptions/v1/chain_service.proto\x12\n" +
+	"options.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18options/v1/options.proto\"\x84\x01\n" +
+	"\x0eContractFilter\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
+	"\vexpirations\x18\x02 \x03(\tR\vexpirations\x12\x1d\n" +
+	"\n" +
+	"min_strike\x18\x03 \x01(\x01R\tminStrike\x12\x1d\n" +
+	"\n" +
+	"max_strike\x18\x04 \x01(\x01R\tmaxStrike\"]\n" +
+	"\x0fGetChainRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x122\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1a.options.v1.ContractFilterR\x06filter\"A\n" +
+	"\x10GetChainResponse\x12-\n" +
+	"\x05chain\x18\x01 \x01(\v2\x17.options.v1.OptionChainR\x05chain\"0\n" +
+	"\x16ListExpirationsRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"x\n" +
+	"\n" +
+	"Expiration\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12,\n" +
+	"\x12days_to_expiration\x18\x02 \x01(\x05R\x10daysToExpiration\x12\x14\n" +
+	"\x05calls\x18\x03 \x01(\x05R\x05calls\x12\x12\n" +
+	"\x04puts\x18\x04 \x01(\x05R\x04puts\"S\n" +
+	"\x17ListExpirationsResponse\x128\n" +
+	"\vexpirations\x18\x01 \x03(\v2\x16.options.v1.ExpirationR\vexpirations\"`\n" +
+	"\x12StreamChainRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x122\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1a.options.v1.ContractFilterR\x06filter\"\x7f\n" +
+	"\x13StreamChainResponse\x122\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x14.options.v1.SnapshotH\x00R\bsnapshot\x12)\n" +
+	"\x05delta\x18\x02 \x01(\v2\x11.options.v1.DeltaH\x00R\x05deltaB\t\n" +
+	"\amessage\"\x7f\n" +
+	"\x13StreamQuotesRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1c\n" +
+	"\tcontracts\x18\x02 \x03(\tR\tcontracts\x122\n" +
+	"\x06filter\x18\x03 \x01(\v2\x1a.options.v1.ContractFilterR\x06filter\"\xae\x01\n" +
+	"\x14StreamQuotesResponse\x12,\n" +
+	"\x05quote\x18\x01 \x01(\v2\x16.options.v1.OptionDataR\x05quote\x12)\n" +
+	"\x10underlying_price\x18\x02 \x01(\x01R\x0funderlyingPrice\x12=\n" +
+	"\flast_updated\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated2\xd8\x02\n" +
+	"\fChainService\x12E\n" +
+	"\bGetChain\x12\x1b.options.v1.GetChainRequest\x1a\x1c.options.v1.GetChainResponse\x12Z\n" +
+	"\x0fListExpirations\x12\".options.v1.ListExpirationsRequest\x1a#.options.v1.ListExpirationsResponse\x12P\n" +
+	"\vStreamChain\x12\x1e.options.v1.StreamChainRequest\x1a\x1f.options.v1.StreamChainResponse0\x01\x12S\n" +
+	"\fStreamQuotes\x12\x1f.options.v1.StreamQuotesRequest\x1a .options.v1.StreamQuotesResponse0\x01BKZIgithub.com/ryanhamamura/options-chain-go/internal/pb/options/v1;optionsv1b\x06proto3"
+
+var (
+	file_options_v1_chain_service_proto_rawDescOnce sync.Once
+	file_options_v1_chain_service_proto_rawDescData []byte
+)
+
+func file_options_v1_chain_service_proto_rawDescGZIP() []byte {
+	file_options_v1_chain_service_proto_rawDescOnce.Do(func() {
+		file_options_v1_chain_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_options_v1_chain_service_proto_rawDesc), len(file_options_v1_chain_service_proto_rawDesc)))
+	})
+	return file_options_v1_chain_service_proto_rawDescData
+}
+
+var file_options_v1_chain_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_options_v1_chain_service_proto_goTypes = []any{
+	(*ContractFilter)(nil),          // 0: options.v1.ContractFilter
+	(*GetChainRequest)(nil),         // 1: options.v1.GetChainRequest
+	(*GetChainResponse)(nil),        // 2: options.v1.GetChainResponse
+	(*ListExpirationsRequest)(nil),  // 3: options.v1.ListExpirationsRequest
+	(*Expiration)(nil),              // 4: options.v1.Expiration
+	(*ListExpirationsResponse)(nil), // 5: options.v1.ListExpirationsResponse
+	(*StreamChainRequest)(nil),      // 6: options.v1.StreamChainRequest
+	(*StreamChainResponse)(nil),     // 7: options.v1.StreamChainResponse
+	(*StreamQuotesRequest)(nil),     // 8: options.v1.StreamQuotesRequest
+	(*StreamQuotesResponse)(nil),    // 9: options.v1.StreamQuotesResponse
+	(*OptionChain)(nil),             // 10: options.v1.OptionChain
+	(*Snapshot)(nil),                // 11: options.v1.Snapshot
+	(*Delta)(nil),                   // 12: options.v1.Delta
+	(*OptionData)(nil),              // 13: options.v1.OptionData
+	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
+}
+var file_options_v1_chain_service_proto_depIdxs = []int32{
+	0,  // 0: options.v1.GetChainRequest.filter:type_name -> options.v1.ContractFilter
+	10, // 1: options.v1.GetChainResponse.chain:type_name -> options.v1.OptionChain
+	4,  // 2: options.v1.ListExpirationsResponse.expirations:type_name -> options.v1.Expiration
+	0,  // 3: options.v1.StreamChainRequest.filter:type_name -> options.v1.ContractFilter
+	11, // 4: options.v1.StreamChainResponse.snapshot:type_name -> options.v1.Snapshot
+	12, // 5: options.v1.StreamChainResponse.delta:type_name -> options.v1.Delta
+	0,  // 6: options.v1.StreamQuotesRequest.filter:type_name -> options.v1.ContractFilter
+	13, // 7: options.v1.StreamQuotesResponse.quote:type_name -> options.v1.OptionData
+	14, // 8: options.v1.StreamQuotesResponse.last_updated:type_name -> google.protobuf.Timestamp
+	1,  // 9: options.v1.ChainService.GetChain:input_type -> options.v1.GetChainRequest
+	3,  // 10: options.v1.ChainService.ListExpirations:input_type -> options.v1.ListExpirationsRequest
+	6,  // 11: options.v1.ChainService.StreamChain:input_type -> options.v1.StreamChainRequest
+	8,  // 12: options.v1.ChainService.StreamQuotes:input_type -> options.v1.StreamQuotesRequest
+	2,  // 13: options.v1.ChainService.GetChain:output_type -> options.v1.GetChainResponse
+	5,  // 14: options.v1.ChainService.ListExpirations:output_type -> options.v1.ListExpirationsResponse
+	7,  // 15: options.v1.ChainService.StreamChain:output_type -> options.v1.StreamChainResponse
+	9,  // 16: options.v1.ChainService.StreamQuotes:output_type -> options.v1.StreamQuotesResponse
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_options_v1_chain_service_proto_init() }
+func file_options_v1_chain_service_proto_init() {
+	if File_options_v1_chain_service_proto != nil {
+		return
+	}
+	file_options_v1_options_proto_init()
+	file_options_v1_chain_service_proto_msgTypes[7].OneofWrappers = []any{
+		(*StreamChainResponse_Snapshot)(nil),
+		(*StreamChainResponse_Delta)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_options_v1_chain_service_proto_rawDesc), len(file_options_v1_chain_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_options_v1_chain_service_proto_goTypes,
+		DependencyIndexes: file_options_v1_chain_service_proto_depIdxs,
+		MessageInfos:      file_options_v1_chain_service_proto_msgTypes,
+	}.Build()
+	File_options_v1_chain_service_proto = out.File
+	file_options_v1_chain_service_proto_goTypes = nil
+	file_options_v1_chain_service_proto_depIdxs = nil
+}
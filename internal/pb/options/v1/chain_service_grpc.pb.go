@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: options/v1/chain_service.proto
+
+package optionsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChainService_GetChain_FullMethodName        = "/options.v1.ChainService/GetChain"
+	ChainService_ListExpirations_FullMethodName = "/options.v1.ChainService/ListExpirations"
+	ChainService_StreamChain_FullMethodName     = "/options.v1.ChainService/StreamChain"
+	ChainService_StreamQuotes_FullMethodName    = "/options.v1.ChainService/StreamQuotes"
+)
+
+// ChainServiceClient is the client API for ChainService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChainService serves the aggregated option chains held by the server
+type ChainServiceClient interface {
+	// GetChain returns the latest chain for an underlying
+	GetChain(ctx context.Context, in *GetChainRequest, opts ...grpc.CallOption) (*GetChainResponse, error)
+	// ListExpirations returns the expirations listed in an underlying's chain
+	ListExpirations(ctx context.Context, in *ListExpirationsRequest, opts ...grpc.CallOption) (*ListExpirationsResponse, error)
+	// StreamChain sends a snapshot of the chain followed by deltas
+	StreamChain(ctx context.Context, in *StreamChainRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamChainResponse], error)
+	// StreamQuotes sends the full state of each contract whenever it changes
+	StreamQuotes(ctx context.Context, in *StreamQuotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamQuotesResponse], error)
+}
+
+type chainServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChainServiceClient(cc grpc.ClientConnInterface) ChainServiceClient {
+	return &chainServiceClient{cc}
+}
+
+func (c *chainServiceClient) GetChain(ctx context.Context, in *GetChainRequest, opts ...grpc.CallOption) (*GetChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChainResponse)
+	err := c.cc.Invoke(ctx, ChainService_GetChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) ListExpirations(ctx context.Context, in *ListExpirationsRequest, opts ...grpc.CallOption) (*ListExpirationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExpirationsResponse)
+	err := c.cc.Invoke(ctx, ChainService_ListExpirations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainServiceClient) StreamChain(ctx context.Context, in *StreamChainRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamChainResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChainService_ServiceDesc.Streams[0], ChainService_StreamChain_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamChainRequest, StreamChainResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChainService_StreamChainClient = grpc.ServerStreamingClient[StreamChainResponse]
+
+func (c *chainServiceClient) StreamQuotes(ctx context.Context, in *StreamQuotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamQuotesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChainService_ServiceDesc.Streams[1], ChainService_StreamQuotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamQuotesRequest, StreamQuotesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChainService_StreamQuotesClient = grpc.ServerStreamingClient[StreamQuotesResponse]
+
+// ChainServiceServer is the server API for ChainService service.
+// All implementations must embed UnimplementedChainServiceServer
+// for forward compatibility.
+//
+// ChainService serves the aggregated option chains held by the server
+type ChainServiceServer interface {
+	// GetChain returns the latest chain for an underlying
+	GetChain(context.Context, *GetChainRequest) (*GetChainResponse, error)
+	// ListExpirations returns the expirations listed in an underlying's chain
+	ListExpirations(context.Context, *ListExpirationsRequest) (*ListExpirationsResponse, error)
+	// StreamChain sends a snapshot of the chain followed by deltas
+	StreamChain(*StreamChainRequest, grpc.ServerStreamingServer[StreamChainResponse]) error
+	// StreamQuotes sends the full state of each contract whenever it changes
+	StreamQuotes(*StreamQuotesRequest, grpc.ServerStreamingServer[StreamQuotesResponse]) error
+	mustEmbedUnimplementedChainServiceServer()
+}
+
+// UnimplementedChainServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChainServiceServer struct{}
+
+func (UnimplementedChainServiceServer) GetChain(context.Context, *GetChainRequest) (*GetChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChain not implemented")
+}
+func (UnimplementedChainServiceServer) ListExpirations(context.Context, *ListExpirationsRequest) (*ListExpirationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpirations not implemented")
+}
+func (UnimplementedChainServiceServer) StreamChain(*StreamChainRequest, grpc.ServerStreamingServer[StreamChainResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamChain not implemented")
+}
+func (UnimplementedChainServiceServer) StreamQuotes(*StreamQuotesRequest, grpc.ServerStreamingServer[StreamQuotesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamQuotes not implemented")
+}
+func (UnimplementedChainServiceServer) mustEmbedUnimplementedChainServiceServer() {}
+func (UnimplementedChainServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeChainServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChainServiceServer will
+// result in compilation errors.
+type UnsafeChainServiceServer interface {
+	mustEmbedUnimplementedChainServiceServer()
+}
+
+func RegisterChainServiceServer(s grpc.ServiceRegistrar, srv ChainServiceServer) {
+	// If the following call pancis, it indicates UnimplementedChainServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChainService_ServiceDesc, srv)
+}
+
+func _ChainService_GetChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).GetChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_GetChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).GetChain(ctx, req.(*GetChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_ListExpirations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpirationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServiceServer).ListExpirations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChainService_ListExpirations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServiceServer).ListExpirations(ctx, req.(*ListExpirationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChainService_StreamChain_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamChainRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainServiceServer).StreamChain(m, &grpc.GenericServerStream[StreamChainRequest, StreamChainResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChainService_StreamChainServer = grpc.ServerStreamingServer[StreamChainResponse]
+
+func _ChainService_StreamQuotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamQuotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainServiceServer).StreamQuotes(m, &grpc.GenericServerStream[StreamQuotesRequest, StreamQuotesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChainService_StreamQuotesServer = grpc.ServerStreamingServer[StreamQuotesResponse]
+
+// ChainService_ServiceDesc is the grpc.ServiceDesc for ChainService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChainService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "options.v1.ChainService",
+	HandlerType: (*ChainServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetChain",
+			Handler:    _ChainService_GetChain_Handler,
+		},
+		{
+			MethodName: "ListExpirations",
+			Handler:    _ChainService_ListExpirations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamChain",
+			Handler:       _ChainService_StreamChain_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamQuotes",
+			Handler:       _ChainService_StreamQuotes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "options/v1/chain_service.proto",
+}
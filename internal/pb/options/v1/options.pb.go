@@ -0,0 +1,2788 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: options/v1/options.proto
+
+package optionsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OptionData mirrors models.OptionData. JSON names match the model's JSON
+// tags so delta field lists apply to both encodings.
+type OptionData struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Symbol            string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Strike            float64                `protobuf:"fixed64,2,opt,name=strike,proto3" json:"strike,omitempty"`
+	Expiration        string                 `protobuf:"bytes,3,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Type              string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // "call" or "put"
+	Bid               float64                `protobuf:"fixed64,5,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask               float64                `protobuf:"fixed64,6,opt,name=ask,proto3" json:"ask,omitempty"`
+	LastPrice         float64                `protobuf:"fixed64,7,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	Volume            int64                  `protobuf:"varint,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	OpenInterest      int64                  `protobuf:"varint,9,opt,name=open_interest,json=openInterest,proto3" json:"open_interest,omitempty"`
+	Delta             float64                `protobuf:"fixed64,10,opt,name=delta,proto3" json:"delta,omitempty"`
+	Gamma             float64                `protobuf:"fixed64,11,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Theta             float64                `protobuf:"fixed64,12,opt,name=theta,proto3" json:"theta,omitempty"`
+	Vega              float64                `protobuf:"fixed64,13,opt,name=vega,proto3" json:"vega,omitempty"`
+	ImpliedVolatility float64                `protobuf:"fixed64,14,opt,name=implied_volatility,json=impliedVolatility,proto3" json:"implied_volatility,omitempty"`
+	Rho               float64                `protobuf:"fixed64,15,opt,name=rho,proto3" json:"rho,omitempty"`
+	Vanna             float64                `protobuf:"fixed64,16,opt,name=vanna,proto3" json:"vanna,omitempty"`
+	Charm             float64                `protobuf:"fixed64,17,opt,name=charm,proto3" json:"charm,omitempty"`
+	Vomma             float64                `protobuf:"fixed64,18,opt,name=vomma,proto3" json:"vomma,omitempty"`
+	Speed             float64                `protobuf:"fixed64,19,opt,name=speed,proto3" json:"speed,omitempty"`
+	BidIv             float64                `protobuf:"fixed64,20,opt,name=bid_iv,json=bidIv,proto3" json:"bid_iv,omitempty"`
+	AskIv             float64                `protobuf:"fixed64,21,opt,name=ask_iv,json=askIv,proto3" json:"ask_iv,omitempty"`
+	MidIv             float64                `protobuf:"fixed64,22,opt,name=mid_iv,json=midIv,proto3" json:"mid_iv,omitempty"`
+	FittedIv          float64                `protobuf:"fixed64,23,opt,name=fitted_iv,json=fittedIv,proto3" json:"fitted_iv,omitempty"`
+	Pitm              float64                `protobuf:"fixed64,24,opt,name=pitm,proto3" json:"pitm,omitempty"`
+	ProbTouch         float64                `protobuf:"fixed64,25,opt,name=prob_touch,json=probTouch,proto3" json:"prob_touch,omitempty"`
+	Pop               float64                `protobuf:"fixed64,26,opt,name=pop,proto3" json:"pop,omitempty"`
+	Flags             string                 `protobuf:"bytes,27,opt,name=flags,proto3" json:"flags,omitempty"` // comma-separated quality flags
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OptionData) Reset() {
+	*x = OptionData{}
+	mi := &file_options_v1_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionData) ProtoMessage() {}
+
+func (x *OptionData) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionData.ProtoReflect.Descriptor instead.
+func (*OptionData) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OptionData) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OptionData) GetStrike() float64 {
+	if x != nil {
+		return x.Strike
+	}
+	return 0
+}
+
+func (x *OptionData) GetExpiration() string {
+	if x != nil {
+		return x.Expiration
+	}
+	return ""
+}
+
+func (x *OptionData) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OptionData) GetBid() float64 {
+	if x != nil {
+		return x.Bid
+	}
+	return 0
+}
+
+func (x *OptionData) GetAsk() float64 {
+	if x != nil {
+		return x.Ask
+	}
+	return 0
+}
+
+func (x *OptionData) GetLastPrice() float64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+func (x *OptionData) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *OptionData) GetOpenInterest() int64 {
+	if x != nil {
+		return x.OpenInterest
+	}
+	return 0
+}
+
+func (x *OptionData) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *OptionData) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *OptionData) GetTheta() float64 {
+	if x != nil {
+		return x.Theta
+	}
+	return 0
+}
+
+func (x *OptionData) GetVega() float64 {
+	if x != nil {
+		return x.Vega
+	}
+	return 0
+}
+
+func (x *OptionData) GetImpliedVolatility() float64 {
+	if x != nil {
+		return x.ImpliedVolatility
+	}
+	return 0
+}
+
+func (x *OptionData) GetRho() float64 {
+	if x != nil {
+		return x.Rho
+	}
+	return 0
+}
+
+func (x *OptionData) GetVanna() float64 {
+	if x != nil {
+		return x.Vanna
+	}
+	return 0
+}
+
+func (x *OptionData) GetCharm() float64 {
+	if x != nil {
+		return x.Charm
+	}
+	return 0
+}
+
+func (x *OptionData) GetVomma() float64 {
+	if x != nil {
+		return x.Vomma
+	}
+	return 0
+}
+
+func (x *OptionData) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *OptionData) GetBidIv() float64 {
+	if x != nil {
+		return x.BidIv
+	}
+	return 0
+}
+
+func (x *OptionData) GetAskIv() float64 {
+	if x != nil {
+		return x.AskIv
+	}
+	return 0
+}
+
+func (x *OptionData) GetMidIv() float64 {
+	if x != nil {
+		return x.MidIv
+	}
+	return 0
+}
+
+func (x *OptionData) GetFittedIv() float64 {
+	if x != nil {
+		return x.FittedIv
+	}
+	return 0
+}
+
+func (x *OptionData) GetPitm() float64 {
+	if x != nil {
+		return x.Pitm
+	}
+	return 0
+}
+
+func (x *OptionData) GetProbTouch() float64 {
+	if x != nil {
+		return x.ProbTouch
+	}
+	return 0
+}
+
+func (x *OptionData) GetPop() float64 {
+	if x != nil {
+		return x.Pop
+	}
+	return 0
+}
+
+func (x *OptionData) GetFlags() string {
+	if x != nil {
+		return x.Flags
+	}
+	return ""
+}
+
+// OptionChain mirrors models.OptionChain
+type OptionChain struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	UnderlyingPrice float64                `protobuf:"fixed64,2,opt,name=underlying_price,json=underlyingPrice,proto3" json:"underlying_price,omitempty"`
+	LastUpdated     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Calls           []*OptionData          `protobuf:"bytes,4,rep,name=calls,proto3" json:"calls,omitempty"`
+	Puts            []*OptionData          `protobuf:"bytes,5,rep,name=puts,proto3" json:"puts,omitempty"`
+	Metrics         *VolMetrics            `protobuf:"bytes,6,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	ExpectedMoves   []*ExpectedMove        `protobuf:"bytes,7,rep,name=expected_moves,json=expectedMoves,proto3" json:"expected_moves,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OptionChain) Reset() {
+	*x = OptionChain{}
+	mi := &file_options_v1_options_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionChain) ProtoMessage() {}
+
+func (x *OptionChain) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionChain.ProtoReflect.Descriptor instead.
+func (*OptionChain) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OptionChain) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OptionChain) GetUnderlyingPrice() float64 {
+	if x != nil {
+		return x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *OptionChain) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *OptionChain) GetCalls() []*OptionData {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *OptionChain) GetPuts() []*OptionData {
+	if x != nil {
+		return x.Puts
+	}
+	return nil
+}
+
+func (x *OptionChain) GetMetrics() *VolMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *OptionChain) GetExpectedMoves() []*ExpectedMove {
+	if x != nil {
+		return x.ExpectedMoves
+	}
+	return nil
+}
+
+// ExpirationMetrics mirrors models.ExpirationMetrics
+type ExpirationMetrics struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Expiration       string                 `protobuf:"bytes,1,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	DaysToExpiration float64                `protobuf:"fixed64,2,opt,name=days_to_expiration,json=daysToExpiration,proto3" json:"days_to_expiration,omitempty"`
+	Forward          float64                `protobuf:"fixed64,3,opt,name=forward,proto3" json:"forward,omitempty"`
+	AtmIv            float64                `protobuf:"fixed64,4,opt,name=atm_iv,json=atmIv,proto3" json:"atm_iv,omitempty"`
+	Call25DIv        float64                `protobuf:"fixed64,5,opt,name=call25d_iv,json=call25dIv,proto3" json:"call25d_iv,omitempty"`
+	Put25DIv         float64                `protobuf:"fixed64,6,opt,name=put25d_iv,json=put25dIv,proto3" json:"put25d_iv,omitempty"`
+	RiskReversal25D  float64                `protobuf:"fixed64,7,opt,name=risk_reversal25d,json=riskReversal25d,proto3" json:"risk_reversal25d,omitempty"`
+	Butterfly25D     float64                `protobuf:"fixed64,8,opt,name=butterfly25d,proto3" json:"butterfly25d,omitempty"`
+	PutSkewSlope     float64                `protobuf:"fixed64,9,opt,name=put_skew_slope,json=putSkewSlope,proto3" json:"put_skew_slope,omitempty"`
+	CallSkewSlope    float64                `protobuf:"fixed64,10,opt,name=call_skew_slope,json=callSkewSlope,proto3" json:"call_skew_slope,omitempty"`
+	TermRatio        float64                `protobuf:"fixed64,11,opt,name=term_ratio,json=termRatio,proto3" json:"term_ratio,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExpirationMetrics) Reset() {
+	*x = ExpirationMetrics{}
+	mi := &file_options_v1_options_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpirationMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpirationMetrics) ProtoMessage() {}
+
+func (x *ExpirationMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpirationMetrics.ProtoReflect.Descriptor instead.
+func (*ExpirationMetrics) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExpirationMetrics) GetExpiration() string {
+	if x != nil {
+		return x.Expiration
+	}
+	return ""
+}
+
+func (x *ExpirationMetrics) GetDaysToExpiration() float64 {
+	if x != nil {
+		return x.DaysToExpiration
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetForward() float64 {
+	if x != nil {
+		return x.Forward
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetAtmIv() float64 {
+	if x != nil {
+		return x.AtmIv
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetCall25DIv() float64 {
+	if x != nil {
+		return x.Call25DIv
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetPut25DIv() float64 {
+	if x != nil {
+		return x.Put25DIv
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetRiskReversal25D() float64 {
+	if x != nil {
+		return x.RiskReversal25D
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetButterfly25D() float64 {
+	if x != nil {
+		return x.Butterfly25D
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetPutSkewSlope() float64 {
+	if x != nil {
+		return x.PutSkewSlope
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetCallSkewSlope() float64 {
+	if x != nil {
+		return x.CallSkewSlope
+	}
+	return 0
+}
+
+func (x *ExpirationMetrics) GetTermRatio() float64 {
+	if x != nil {
+		return x.TermRatio
+	}
+	return 0
+}
+
+// VolMetrics mirrors models.VolMetrics
+type VolMetrics struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Expirations    []*ExpirationMetrics   `protobuf:"bytes,1,rep,name=expirations,proto3" json:"expirations,omitempty"`
+	FrontBackRatio float64                `protobuf:"fixed64,2,opt,name=front_back_ratio,json=frontBackRatio,proto3" json:"front_back_ratio,omitempty"`
+	AtmIv30        float64                `protobuf:"fixed64,3,opt,name=atm_iv30,json=atmIv30,proto3" json:"atm_iv30,omitempty"`
+	AtmIv90        float64                `protobuf:"fixed64,4,opt,name=atm_iv90,json=atmIv90,proto3" json:"atm_iv90,omitempty"`
+	Ratio30To90    float64                `protobuf:"fixed64,5,opt,name=ratio30_to90,json=ratio30To90,proto3" json:"ratio30_to90,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VolMetrics) Reset() {
+	*x = VolMetrics{}
+	mi := &file_options_v1_options_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolMetrics) ProtoMessage() {}
+
+func (x *VolMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolMetrics.ProtoReflect.Descriptor instead.
+func (*VolMetrics) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VolMetrics) GetExpirations() []*ExpirationMetrics {
+	if x != nil {
+		return x.Expirations
+	}
+	return nil
+}
+
+func (x *VolMetrics) GetFrontBackRatio() float64 {
+	if x != nil {
+		return x.FrontBackRatio
+	}
+	return 0
+}
+
+func (x *VolMetrics) GetAtmIv30() float64 {
+	if x != nil {
+		return x.AtmIv30
+	}
+	return 0
+}
+
+func (x *VolMetrics) GetAtmIv90() float64 {
+	if x != nil {
+		return x.AtmIv90
+	}
+	return 0
+}
+
+func (x *VolMetrics) GetRatio30To90() float64 {
+	if x != nil {
+		return x.Ratio30To90
+	}
+	return 0
+}
+
+// ExpectedMove mirrors models.ExpectedMove
+type ExpectedMove struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Expiration          string                 `protobuf:"bytes,1,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	DaysToExpiration    float64                `protobuf:"fixed64,2,opt,name=days_to_expiration,json=daysToExpiration,proto3" json:"days_to_expiration,omitempty"`
+	StraddleStrike      float64                `protobuf:"fixed64,3,opt,name=straddle_strike,json=straddleStrike,proto3" json:"straddle_strike,omitempty"`
+	StraddlePrice       float64                `protobuf:"fixed64,4,opt,name=straddle_price,json=straddlePrice,proto3" json:"straddle_price,omitempty"`
+	StraddleLower       float64                `protobuf:"fixed64,5,opt,name=straddle_lower,json=straddleLower,proto3" json:"straddle_lower,omitempty"`
+	StraddleUpper       float64                `protobuf:"fixed64,6,opt,name=straddle_upper,json=straddleUpper,proto3" json:"straddle_upper,omitempty"`
+	StraddleProbability float64                `protobuf:"fixed64,7,opt,name=straddle_probability,json=straddleProbability,proto3" json:"straddle_probability,omitempty"`
+	AtmIv               float64                `protobuf:"fixed64,8,opt,name=atm_iv,json=atmIv,proto3" json:"atm_iv,omitempty"`
+	IvMove              float64                `protobuf:"fixed64,9,opt,name=iv_move,json=ivMove,proto3" json:"iv_move,omitempty"`
+	IvLower             float64                `protobuf:"fixed64,10,opt,name=iv_lower,json=ivLower,proto3" json:"iv_lower,omitempty"`
+	IvUpper             float64                `protobuf:"fixed64,11,opt,name=iv_upper,json=ivUpper,proto3" json:"iv_upper,omitempty"`
+	IvProbability       float64                `protobuf:"fixed64,12,opt,name=iv_probability,json=ivProbability,proto3" json:"iv_probability,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ExpectedMove) Reset() {
+	*x = ExpectedMove{}
+	mi := &file_options_v1_options_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpectedMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpectedMove) ProtoMessage() {}
+
+func (x *ExpectedMove) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpectedMove.ProtoReflect.Descriptor instead.
+func (*ExpectedMove) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExpectedMove) GetExpiration() string {
+	if x != nil {
+		return x.Expiration
+	}
+	return ""
+}
+
+func (x *ExpectedMove) GetDaysToExpiration() float64 {
+	if x != nil {
+		return x.DaysToExpiration
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetStraddleStrike() float64 {
+	if x != nil {
+		return x.StraddleStrike
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetStraddlePrice() float64 {
+	if x != nil {
+		return x.StraddlePrice
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetStraddleLower() float64 {
+	if x != nil {
+		return x.StraddleLower
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetStraddleUpper() float64 {
+	if x != nil {
+		return x.StraddleUpper
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetStraddleProbability() float64 {
+	if x != nil {
+		return x.StraddleProbability
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetAtmIv() float64 {
+	if x != nil {
+		return x.AtmIv
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetIvMove() float64 {
+	if x != nil {
+		return x.IvMove
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetIvLower() float64 {
+	if x != nil {
+		return x.IvLower
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetIvUpper() float64 {
+	if x != nil {
+		return x.IvUpper
+	}
+	return 0
+}
+
+func (x *ExpectedMove) GetIvProbability() float64 {
+	if x != nil {
+		return x.IvProbability
+	}
+	return 0
+}
+
+// ClientMessage is a request sent by a WebSocket client
+type ClientMessage struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "subscribe", "resync" or "unsubscribe"
+	Symbol  string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Rate    *float64               `protobuf:"fixed64,3,opt,name=rate,proto3,oneof" json:"rate,omitempty"` // updates per second, 0 = unthrottled
+	Channel string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`   // "chain" (default), "surface" or "spread"
+	// Spread definition for the spread channel, where symbol is the
+	// underlying
+	Legs          []*Leg   `protobuf:"bytes,5,rep,name=legs,proto3" json:"legs,omitempty"`
+	Entry         *float64 `protobuf:"fixed64,6,opt,name=entry,proto3,oneof" json:"entry,omitempty"` // opening price in dollars, positive for a debit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientMessage) Reset() {
+	*x = ClientMessage{}
+	mi := &file_options_v1_options_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage) ProtoMessage() {}
+
+func (x *ClientMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage.ProtoReflect.Descriptor instead.
+func (*ClientMessage) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClientMessage) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ClientMessage) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ClientMessage) GetRate() float64 {
+	if x != nil && x.Rate != nil {
+		return *x.Rate
+	}
+	return 0
+}
+
+func (x *ClientMessage) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ClientMessage) GetLegs() []*Leg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetEntry() float64 {
+	if x != nil && x.Entry != nil {
+		return *x.Entry
+	}
+	return 0
+}
+
+// Leg is one line of a spread: an option contract, or the underlying stock
+// when contract is empty
+type Leg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contract      string                 `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // contracts or shares, negative when short
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Leg) Reset() {
+	*x = Leg{}
+	mi := &file_options_v1_options_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Leg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Leg) ProtoMessage() {}
+
+func (x *Leg) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Leg.ProtoReflect.Descriptor instead.
+func (*Leg) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Leg) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *Leg) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Snapshot carries the full option chain for a symbol
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Seq           uint64                 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Chain         *OptionChain           `protobuf:"bytes,3,opt,name=chain,proto3" json:"chain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_options_v1_options_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Snapshot) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Snapshot) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Snapshot) GetChain() *OptionChain {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+// ContractDelta holds the changed fields of a single contract. Only the
+// fields named in fields (by JSON name) are meaningful in values.
+type ContractDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Values        *OptionData            `protobuf:"bytes,2,opt,name=values,proto3" json:"values,omitempty"`
+	Fields        []string               `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContractDelta) Reset() {
+	*x = ContractDelta{}
+	mi := &file_options_v1_options_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractDelta) ProtoMessage() {}
+
+func (x *ContractDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractDelta.ProtoReflect.Descriptor instead.
+func (*ContractDelta) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ContractDelta) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ContractDelta) GetValues() *OptionData {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ContractDelta) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Delta carries the changes to a chain since the previous sequence number
+type Delta struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Seq             uint64                 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	LastUpdated     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	UnderlyingPrice *float64               `protobuf:"fixed64,4,opt,name=underlying_price,json=underlyingPrice,proto3,oneof" json:"underlying_price,omitempty"`
+	Changes         []*ContractDelta       `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	Removed         []string               `protobuf:"bytes,6,rep,name=removed,proto3" json:"removed,omitempty"`
+	// Changed chain-level analytics: only the fields named in chain_fields
+	// (by JSON name) are meaningful in chain
+	Chain         *OptionChain `protobuf:"bytes,7,opt,name=chain,proto3" json:"chain,omitempty"`
+	ChainFields   []string     `protobuf:"bytes,8,rep,name=chain_fields,json=chainFields,proto3" json:"chain_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Delta) Reset() {
+	*x = Delta{}
+	mi := &file_options_v1_options_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Delta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delta) ProtoMessage() {}
+
+func (x *Delta) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delta.ProtoReflect.Descriptor instead.
+func (*Delta) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Delta) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Delta) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Delta) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *Delta) GetUnderlyingPrice() float64 {
+	if x != nil && x.UnderlyingPrice != nil {
+		return *x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *Delta) GetChanges() []*ContractDelta {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *Delta) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *Delta) GetChain() *OptionChain {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+func (x *Delta) GetChainFields() []string {
+	if x != nil {
+		return x.ChainFields
+	}
+	return nil
+}
+
+// Surface is implied volatility on a grid of days to expiration and
+// moneyness or delta
+type Surface struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	UnderlyingPrice float64                `protobuf:"fixed64,2,opt,name=underlying_price,json=underlyingPrice,proto3" json:"underlying_price,omitempty"`
+	LastUpdated     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Axis            string                 `protobuf:"bytes,4,opt,name=axis,proto3" json:"axis,omitempty"`              // "moneyness" or "delta"
+	Tenors          []float64              `protobuf:"fixed64,5,rep,packed,name=tenors,proto3" json:"tenors,omitempty"` // days to expiration
+	Nodes           []float64              `protobuf:"fixed64,6,rep,packed,name=nodes,proto3" json:"nodes,omitempty"`
+	Rows            []*SurfaceRow          `protobuf:"bytes,7,rep,name=rows,proto3" json:"rows,omitempty"` // one per tenor
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Surface) Reset() {
+	*x = Surface{}
+	mi := &file_options_v1_options_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Surface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Surface) ProtoMessage() {}
+
+func (x *Surface) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Surface.ProtoReflect.Descriptor instead.
+func (*Surface) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Surface) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Surface) GetUnderlyingPrice() float64 {
+	if x != nil {
+		return x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *Surface) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *Surface) GetAxis() string {
+	if x != nil {
+		return x.Axis
+	}
+	return ""
+}
+
+func (x *Surface) GetTenors() []float64 {
+	if x != nil {
+		return x.Tenors
+	}
+	return nil
+}
+
+func (x *Surface) GetNodes() []float64 {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Surface) GetRows() []*SurfaceRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// SurfaceRow holds the vols of one tenor at each node
+type SurfaceRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vols          []float64              `protobuf:"fixed64,1,rep,packed,name=vols,proto3" json:"vols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurfaceRow) Reset() {
+	*x = SurfaceRow{}
+	mi := &file_options_v1_options_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurfaceRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurfaceRow) ProtoMessage() {}
+
+func (x *SurfaceRow) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurfaceRow.ProtoReflect.Descriptor instead.
+func (*SurfaceRow) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SurfaceRow) GetVols() []float64 {
+	if x != nil {
+		return x.Vols
+	}
+	return nil
+}
+
+// LegQuote is a spread leg with its market per share
+type LegQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leg           *Leg                   `protobuf:"bytes,1,opt,name=leg,proto3" json:"leg,omitempty"`
+	Option        *OptionData            `protobuf:"bytes,2,opt,name=option,proto3" json:"option,omitempty"` // unset for stock
+	Bid           float64                `protobuf:"fixed64,3,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask           float64                `protobuf:"fixed64,4,opt,name=ask,proto3" json:"ask,omitempty"`
+	Mid           float64                `protobuf:"fixed64,5,opt,name=mid,proto3" json:"mid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegQuote) Reset() {
+	*x = LegQuote{}
+	mi := &file_options_v1_options_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegQuote) ProtoMessage() {}
+
+func (x *LegQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegQuote.ProtoReflect.Descriptor instead.
+func (*LegQuote) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LegQuote) GetLeg() *Leg {
+	if x != nil {
+		return x.Leg
+	}
+	return nil
+}
+
+func (x *LegQuote) GetOption() *OptionData {
+	if x != nil {
+		return x.Option
+	}
+	return nil
+}
+
+func (x *LegQuote) GetBid() float64 {
+	if x != nil {
+		return x.Bid
+	}
+	return 0
+}
+
+func (x *LegQuote) GetAsk() float64 {
+	if x != nil {
+		return x.Ask
+	}
+	return 0
+}
+
+func (x *LegQuote) GetMid() float64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+// SpreadPrice is the market of a whole spread in dollars, positive for a
+// debit
+type SpreadPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bid           float64                `protobuf:"fixed64,1,opt,name=bid,proto3" json:"bid,omitempty"`
+	Mid           float64                `protobuf:"fixed64,2,opt,name=mid,proto3" json:"mid,omitempty"`
+	Ask           float64                `protobuf:"fixed64,3,opt,name=ask,proto3" json:"ask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpreadPrice) Reset() {
+	*x = SpreadPrice{}
+	mi := &file_options_v1_options_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpreadPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpreadPrice) ProtoMessage() {}
+
+func (x *SpreadPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpreadPrice.ProtoReflect.Descriptor instead.
+func (*SpreadPrice) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SpreadPrice) GetBid() float64 {
+	if x != nil {
+		return x.Bid
+	}
+	return 0
+}
+
+func (x *SpreadPrice) GetMid() float64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *SpreadPrice) GetAsk() float64 {
+	if x != nil {
+		return x.Ask
+	}
+	return 0
+}
+
+// PositionGreeks are the sensitivities of a whole position
+type PositionGreeks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delta         float64                `protobuf:"fixed64,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	Gamma         float64                `protobuf:"fixed64,2,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Theta         float64                `protobuf:"fixed64,3,opt,name=theta,proto3" json:"theta,omitempty"`
+	Vega          float64                `protobuf:"fixed64,4,opt,name=vega,proto3" json:"vega,omitempty"`
+	Rho           float64                `protobuf:"fixed64,5,opt,name=rho,proto3" json:"rho,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionGreeks) Reset() {
+	*x = PositionGreeks{}
+	mi := &file_options_v1_options_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionGreeks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionGreeks) ProtoMessage() {}
+
+func (x *PositionGreeks) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionGreeks.ProtoReflect.Descriptor instead.
+func (*PositionGreeks) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PositionGreeks) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *PositionGreeks) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *PositionGreeks) GetTheta() float64 {
+	if x != nil {
+		return x.Theta
+	}
+	return 0
+}
+
+func (x *PositionGreeks) GetVega() float64 {
+	if x != nil {
+		return x.Vega
+	}
+	return 0
+}
+
+func (x *PositionGreeks) GetRho() float64 {
+	if x != nil {
+		return x.Rho
+	}
+	return 0
+}
+
+// SpreadQuote mirrors strategy.SpreadQuote
+type SpreadQuote struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Underlying      string                 `protobuf:"bytes,2,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	UnderlyingPrice float64                `protobuf:"fixed64,3,opt,name=underlying_price,json=underlyingPrice,proto3" json:"underlying_price,omitempty"`
+	LastUpdated     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Legs            []*LegQuote            `protobuf:"bytes,5,rep,name=legs,proto3" json:"legs,omitempty"`
+	Price           *SpreadPrice           `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	Greeks          *PositionGreeks        `protobuf:"bytes,7,opt,name=greeks,proto3" json:"greeks,omitempty"`
+	Entry           float64                `protobuf:"fixed64,8,opt,name=entry,proto3" json:"entry,omitempty"`
+	Pl              float64                `protobuf:"fixed64,9,opt,name=pl,proto3" json:"pl,omitempty"`
+	Pop             float64                `protobuf:"fixed64,10,opt,name=pop,proto3" json:"pop,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SpreadQuote) Reset() {
+	*x = SpreadQuote{}
+	mi := &file_options_v1_options_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpreadQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpreadQuote) ProtoMessage() {}
+
+func (x *SpreadQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpreadQuote.ProtoReflect.Descriptor instead.
+func (*SpreadQuote) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SpreadQuote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SpreadQuote) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *SpreadQuote) GetUnderlyingPrice() float64 {
+	if x != nil {
+		return x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *SpreadQuote) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *SpreadQuote) GetLegs() []*LegQuote {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *SpreadQuote) GetPrice() *SpreadPrice {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *SpreadQuote) GetGreeks() *PositionGreeks {
+	if x != nil {
+		return x.Greeks
+	}
+	return nil
+}
+
+func (x *SpreadQuote) GetEntry() float64 {
+	if x != nil {
+		return x.Entry
+	}
+	return 0
+}
+
+func (x *SpreadQuote) GetPl() float64 {
+	if x != nil {
+		return x.Pl
+	}
+	return 0
+}
+
+func (x *SpreadQuote) GetPop() float64 {
+	if x != nil {
+		return x.Pop
+	}
+	return 0
+}
+
+// ScreenMatch mirrors screener.Match
+type ScreenMatch struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Underlying      string                 `protobuf:"bytes,1,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	UnderlyingPrice float64                `protobuf:"fixed64,2,opt,name=underlying_price,json=underlyingPrice,proto3" json:"underlying_price,omitempty"`
+	Option          *OptionData            `protobuf:"bytes,3,opt,name=option,proto3" json:"option,omitempty"`
+	Values          map[string]float64     `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScreenMatch) Reset() {
+	*x = ScreenMatch{}
+	mi := &file_options_v1_options_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScreenMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreenMatch) ProtoMessage() {}
+
+func (x *ScreenMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreenMatch.ProtoReflect.Descriptor instead.
+func (*ScreenMatch) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ScreenMatch) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *ScreenMatch) GetUnderlyingPrice() float64 {
+	if x != nil {
+		return x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *ScreenMatch) GetOption() *OptionData {
+	if x != nil {
+		return x.Option
+	}
+	return nil
+}
+
+func (x *ScreenMatch) GetValues() map[string]float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// ScreenUpdate mirrors screener.Update
+type ScreenUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LastUpdated   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Matches       []*ScreenMatch         `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	Added         []string               `protobuf:"bytes,4,rep,name=added,proto3" json:"added,omitempty"`
+	Removed       []string               `protobuf:"bytes,5,rep,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScreenUpdate) Reset() {
+	*x = ScreenUpdate{}
+	mi := &file_options_v1_options_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScreenUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreenUpdate) ProtoMessage() {}
+
+func (x *ScreenUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreenUpdate.ProtoReflect.Descriptor instead.
+func (*ScreenUpdate) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ScreenUpdate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScreenUpdate) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *ScreenUpdate) GetMatches() []*ScreenMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ScreenUpdate) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *ScreenUpdate) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+// UnusualAlert mirrors unusual.Alert
+type UnusualAlert struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Kind            string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Underlying      string                 `protobuf:"bytes,4,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	UnderlyingPrice float64                `protobuf:"fixed64,5,opt,name=underlying_price,json=underlyingPrice,proto3" json:"underlying_price,omitempty"`
+	Contract        string                 `protobuf:"bytes,6,opt,name=contract,proto3" json:"contract,omitempty"`
+	Expiration      string                 `protobuf:"bytes,7,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Strike          float64                `protobuf:"fixed64,8,opt,name=strike,proto3" json:"strike,omitempty"`
+	Type            string                 `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	Side            string                 `protobuf:"bytes,10,opt,name=side,proto3" json:"side,omitempty"`
+	Size            float64                `protobuf:"fixed64,11,opt,name=size,proto3" json:"size,omitempty"`
+	Price           float64                `protobuf:"fixed64,12,opt,name=price,proto3" json:"price,omitempty"`
+	Premium         float64                `protobuf:"fixed64,13,opt,name=premium,proto3" json:"premium,omitempty"`
+	Exchanges       []string               `protobuf:"bytes,14,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+	Volume          int64                  `protobuf:"varint,15,opt,name=volume,proto3" json:"volume,omitempty"`
+	OpenInterest    int64                  `protobuf:"varint,16,opt,name=open_interest,json=openInterest,proto3" json:"open_interest,omitempty"`
+	AverageVolume   float64                `protobuf:"fixed64,17,opt,name=average_volume,json=averageVolume,proto3" json:"average_volume,omitempty"`
+	Ratio           float64                `protobuf:"fixed64,18,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UnusualAlert) Reset() {
+	*x = UnusualAlert{}
+	mi := &file_options_v1_options_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnusualAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnusualAlert) ProtoMessage() {}
+
+func (x *UnusualAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnusualAlert.ProtoReflect.Descriptor instead.
+func (*UnusualAlert) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnusualAlert) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *UnusualAlert) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *UnusualAlert) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *UnusualAlert) GetUnderlyingPrice() float64 {
+	if x != nil {
+		return x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *UnusualAlert) GetExpiration() string {
+	if x != nil {
+		return x.Expiration
+	}
+	return ""
+}
+
+func (x *UnusualAlert) GetStrike() float64 {
+	if x != nil {
+		return x.Strike
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UnusualAlert) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *UnusualAlert) GetSize() float64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetPremium() float64 {
+	if x != nil {
+		return x.Premium
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetExchanges() []string {
+	if x != nil {
+		return x.Exchanges
+	}
+	return nil
+}
+
+func (x *UnusualAlert) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetOpenInterest() int64 {
+	if x != nil {
+		return x.OpenInterest
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetAverageVolume() float64 {
+	if x != nil {
+		return x.AverageVolume
+	}
+	return 0
+}
+
+func (x *UnusualAlert) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+// PortfolioWeighted mirrors portfolio.Weighted
+type PortfolioWeighted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delta         float64                `protobuf:"fixed64,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	Gamma         float64                `protobuf:"fixed64,2,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	DollarDelta   float64                `protobuf:"fixed64,3,opt,name=dollar_delta,json=dollarDelta,proto3" json:"dollar_delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortfolioWeighted) Reset() {
+	*x = PortfolioWeighted{}
+	mi := &file_options_v1_options_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioWeighted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioWeighted) ProtoMessage() {}
+
+func (x *PortfolioWeighted) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioWeighted.ProtoReflect.Descriptor instead.
+func (*PortfolioWeighted) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PortfolioWeighted) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *PortfolioWeighted) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *PortfolioWeighted) GetDollarDelta() float64 {
+	if x != nil {
+		return x.DollarDelta
+	}
+	return 0
+}
+
+// PortfolioPosition mirrors portfolio.Valuation
+type PortfolioPosition struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Underlying      string                 `protobuf:"bytes,2,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	UnderlyingPrice float64                `protobuf:"fixed64,3,opt,name=underlying_price,json=underlyingPrice,proto3" json:"underlying_price,omitempty"`
+	Legs            []*LegQuote            `protobuf:"bytes,4,rep,name=legs,proto3" json:"legs,omitempty"`
+	Price           *SpreadPrice           `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Cost            *float64               `protobuf:"fixed64,6,opt,name=cost,proto3,oneof" json:"cost,omitempty"`
+	Pl              *float64               `protobuf:"fixed64,7,opt,name=pl,proto3,oneof" json:"pl,omitempty"`
+	Greeks          *PositionGreeks        `protobuf:"bytes,8,opt,name=greeks,proto3" json:"greeks,omitempty"`
+	Beta            float64                `protobuf:"fixed64,9,opt,name=beta,proto3" json:"beta,omitempty"`
+	BetaSource      string                 `protobuf:"bytes,10,opt,name=beta_source,json=betaSource,proto3" json:"beta_source,omitempty"`
+	BetaReturns     int64                  `protobuf:"varint,11,opt,name=beta_returns,json=betaReturns,proto3" json:"beta_returns,omitempty"`
+	Weighted        *PortfolioWeighted     `protobuf:"bytes,12,opt,name=weighted,proto3" json:"weighted,omitempty"`
+	Error           string                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`
+	Pop             float64                `protobuf:"fixed64,14,opt,name=pop,proto3" json:"pop,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PortfolioPosition) Reset() {
+	*x = PortfolioPosition{}
+	mi := &file_options_v1_options_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioPosition) ProtoMessage() {}
+
+func (x *PortfolioPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioPosition.ProtoReflect.Descriptor instead.
+func (*PortfolioPosition) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PortfolioPosition) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PortfolioPosition) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *PortfolioPosition) GetUnderlyingPrice() float64 {
+	if x != nil {
+		return x.UnderlyingPrice
+	}
+	return 0
+}
+
+func (x *PortfolioPosition) GetLegs() []*LegQuote {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *PortfolioPosition) GetPrice() *SpreadPrice {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PortfolioPosition) GetCost() float64 {
+	if x != nil && x.Cost != nil {
+		return *x.Cost
+	}
+	return 0
+}
+
+func (x *PortfolioPosition) GetPl() float64 {
+	if x != nil && x.Pl != nil {
+		return *x.Pl
+	}
+	return 0
+}
+
+func (x *PortfolioPosition) GetGreeks() *PositionGreeks {
+	if x != nil {
+		return x.Greeks
+	}
+	return nil
+}
+
+func (x *PortfolioPosition) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *PortfolioPosition) GetBetaSource() string {
+	if x != nil {
+		return x.BetaSource
+	}
+	return ""
+}
+
+func (x *PortfolioPosition) GetBetaReturns() int64 {
+	if x != nil {
+		return x.BetaReturns
+	}
+	return 0
+}
+
+func (x *PortfolioPosition) GetWeighted() *PortfolioWeighted {
+	if x != nil {
+		return x.Weighted
+	}
+	return nil
+}
+
+func (x *PortfolioPosition) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PortfolioPosition) GetPop() float64 {
+	if x != nil {
+		return x.Pop
+	}
+	return 0
+}
+
+// PortfolioSummary mirrors portfolio.Summary
+type PortfolioSummary struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LastUpdated    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Benchmark      string                 `protobuf:"bytes,2,opt,name=benchmark,proto3" json:"benchmark,omitempty"`
+	BenchmarkPrice float64                `protobuf:"fixed64,3,opt,name=benchmark_price,json=benchmarkPrice,proto3" json:"benchmark_price,omitempty"`
+	Value          float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	Cost           float64                `protobuf:"fixed64,5,opt,name=cost,proto3" json:"cost,omitempty"`
+	Pl             float64                `protobuf:"fixed64,6,opt,name=pl,proto3" json:"pl,omitempty"`
+	Greeks         *PositionGreeks        `protobuf:"bytes,7,opt,name=greeks,proto3" json:"greeks,omitempty"`
+	Weighted       *PortfolioWeighted     `protobuf:"bytes,8,opt,name=weighted,proto3" json:"weighted,omitempty"`
+	Positions      []*PortfolioPosition   `protobuf:"bytes,9,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PortfolioSummary) Reset() {
+	*x = PortfolioSummary{}
+	mi := &file_options_v1_options_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioSummary) ProtoMessage() {}
+
+func (x *PortfolioSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioSummary.ProtoReflect.Descriptor instead.
+func (*PortfolioSummary) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PortfolioSummary) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *PortfolioSummary) GetBenchmark() string {
+	if x != nil {
+		return x.Benchmark
+	}
+	return ""
+}
+
+func (x *PortfolioSummary) GetBenchmarkPrice() float64 {
+	if x != nil {
+		return x.BenchmarkPrice
+	}
+	return 0
+}
+
+func (x *PortfolioSummary) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PortfolioSummary) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *PortfolioSummary) GetPl() float64 {
+	if x != nil {
+		return x.Pl
+	}
+	return 0
+}
+
+func (x *PortfolioSummary) GetGreeks() *PositionGreeks {
+	if x != nil {
+		return x.Greeks
+	}
+	return nil
+}
+
+func (x *PortfolioSummary) GetWeighted() *PortfolioWeighted {
+	if x != nil {
+		return x.Weighted
+	}
+	return nil
+}
+
+func (x *PortfolioSummary) GetPositions() []*PortfolioPosition {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+// Error reports a rejected client request
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_options_v1_options_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ServerMessage is a message sent to a WebSocket client
+type ServerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*ServerMessage_Snapshot
+	//	*ServerMessage_Delta
+	//	*ServerMessage_Error
+	//	*ServerMessage_Surface
+	//	*ServerMessage_Spread
+	//	*ServerMessage_Screen
+	//	*ServerMessage_Unusual
+	//	*ServerMessage_Portfolio
+	Message       isServerMessage_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerMessage) Reset() {
+	*x = ServerMessage{}
+	mi := &file_options_v1_options_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage) ProtoMessage() {}
+
+func (x *ServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_options_v1_options_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
+func (*ServerMessage) Descriptor() ([]byte, []int) {
+	return file_options_v1_options_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ServerMessage) GetMessage() isServerMessage_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetSnapshot() *Snapshot {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetDelta() *Delta {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Delta); ok {
+			return x.Delta
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetSurface() *Surface {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Surface); ok {
+			return x.Surface
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetSpread() *SpreadQuote {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Spread); ok {
+			return x.Spread
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetScreen() *ScreenUpdate {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Screen); ok {
+			return x.Screen
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetUnusual() *UnusualAlert {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Unusual); ok {
+			return x.Unusual
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetPortfolio() *PortfolioSummary {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Portfolio); ok {
+			return x.Portfolio
+		}
+	}
+	return nil
+}
+
+type isServerMessage_Message interface {
+	isServerMessage_Message()
+}
+
+type ServerMessage_Snapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3,oneof"`
+}
+
+type ServerMessage_Delta struct {
+	Delta *Delta `protobuf:"bytes,2,opt,name=delta,proto3,oneof"`
+}
+
+type ServerMessage_Error struct {
+	Error *Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+type ServerMessage_Surface struct {
+	Surface *Surface `protobuf:"bytes,4,opt,name=surface,proto3,oneof"`
+}
+
+type ServerMessage_Spread struct {
+	Spread *SpreadQuote `protobuf:"bytes,5,opt,name=spread,proto3,oneof"`
+}
+
+type ServerMessage_Screen struct {
+	Screen *ScreenUpdate `protobuf:"bytes,6,opt,name=screen,proto3,oneof"`
+}
+
+type ServerMessage_Unusual struct {
+	Unusual *UnusualAlert `protobuf:"bytes,7,opt,name=unusual,proto3,oneof"`
+}
+
+type ServerMessage_Portfolio struct {
+	Portfolio *PortfolioSummary `protobuf:"bytes,8,opt,name=portfolio,proto3,oneof"`
+}
+
+func (*ServerMessage_Snapshot) isServerMessage_Message() {}
+
+func (*ServerMessage_Delta) isServerMessage_Message() {}
+
+func (*ServerMessage_Error) isServerMessage_Message() {}
+
+func (*ServerMessage_Surface) isServerMessage_Message() {}
+
+func (*ServerMessage_Spread) isServerMessage_Message() {}
+
+func (*ServerMessage_Screen) isServerMessage_Message() {}
+
+func (*ServerMessage_Unusual) isServerMessage_Message() {}
+
+func (*ServerMessage_Portfolio) isServerMessage_Message() {}
+
+var File_options_v1_options_proto protoreflect.FileDescriptor
+
+const file_options_v1_options_proto_rawDesc = "" +
+	"\n" +
+	"\x18options/v1/options.proto\x12\n" +
+	"options.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9c\x05\n" +
+	"\n" +
+	"OptionData\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06strike\x18\x02 \x01(\x01R\x06strike\x12\x1e\n" +
+	"\n" +
+	"expiration\x18\x03 \x01(\tR\n" +
+	"expiration\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x10\n" +
+	"\x03bid\x18\x05 \x01(\x01R\x03bid\x12\x10\n" +
+	"\x03ask\x18\x06 \x01(\x01R\x03ask\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\a \x01(\x01R\tlastPrice\x12\x16\n" +
+	"\x06volume\x18\b \x01(\x03R\x06volume\x12#\n" +
+	"\ropen_interest\x18\t \x01(\x03R\fopenInterest\x12\x14\n" +
+	"\x05delta\x18\n" +
+	" \x01(\x01R\x05delta\x12\x14\n" +
+	"\x05gamma\x18\v \x01(\x01R\x05gamma\x12\x14\n" +
+	"\x05theta\x18\f \x01(\x01R\x05theta\x12\x12\n" +
+	"\x04vega\x18\r \x01(\x01R\x04vega\x12-\n" +
+	"\x12implied_volatility\x18\x0e \x01(\x01R\x11impliedVolatility\x12\x10\n" +
+	"\x03rho\x18\x0f \x01(\x01R\x03rho\x12\x14\n" +
+	"\x05vanna\x18\x10 \x01(\x01R\x05vanna\x12\x14\n" +
+	"\x05charm\x18\x11 \x01(\x01R\x05charm\x12\x14\n" +
+	"\x05vomma\x18\x12 \x01(\x01R\x05vomma\x12\x14\n" +
+	"\x05speed\x18\x13 \x01(\x01R\x05speed\x12\x15\n" +
+	"\x06bid_iv\x18\x14 \x01(\x01R\x05bidIv\x12\x15\n" +
+	"\x06ask_iv\x18\x15 \x01(\x01R\x05askIv\x12\x15\n" +
+	"\x06mid_iv\x18\x16 \x01(\x01R\x05midIv\x12\x1b\n" +
+	"\tfitted_iv\x18\x17 \x01(\x01R\bfittedIv\x12\x12\n" +
+	"\x04pitm\x18\x18 \x01(\x01R\x04pitm\x12\x1d\n" +
+	"\n" +
+	"prob_touch\x18\x19 \x01(\x01R\tprobTouch\x12\x10\n" +
+	"\x03pop\x18\x1a \x01(\x01R\x03pop\x12\x14\n" +
+	"\x05flags\x18\x1b \x01(\tR\x05flags\"\xdc\x02\n" +
+	"\vOptionChain\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12)\n" +
+	"\x10underlying_price\x18\x02 \x01(\x01R\x0funderlyingPrice\x12=\n" +
+	"\flast_updated\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12,\n" +
+	"\x05calls\x18\x04 \x03(\v2\x16.options.v1.OptionDataR\x05calls\x12*\n" +
+	"\x04puts\x18\x05 \x03(\v2\x16.options.v1.OptionDataR\x04puts\x120\n" +
+	"\ametrics\x18\x06 \x01(\v2\x16.options.v1.VolMetricsR\ametrics\x12?\n" +
+	"\x0eexpected_moves\x18\a \x03(\v2\x18.options.v1.ExpectedMoveR\rexpectedMoves\"\x8a\x03\n" +
+	"\x11ExpirationMetrics\x12\x1e\n" +
+	"\n" +
+	"expiration\x18\x01 \x01(\tR\n" +
+	"expiration\x12,\n" +
+	"\x12days_to_expiration\x18\x02 \x01(\x01R\x10daysToExpiration\x12\x18\n" +
+	"\aforward\x18\x03 \x01(\x01R\aforward\x12\x15\n" +
+	"\x06atm_iv\x18\x04 \x01(\x01R\x05atmIv\x12\x1d\n" +
+	"\n" +
+	"call25d_iv\x18\x05 \x01(\x01R\tcall25dIv\x12\x1b\n" +
+	"\tput25d_iv\x18\x06 \x01(\x01R\bput25dIv\x12)\n" +
+	"\x10risk_reversal25d\x18\a \x01(\x01R\x0friskReversal25d\x12\"\n" +
+	"\fbutterfly25d\x18\b \x01(\x01R\fbutterfly25d\x12$\n" +
+	"\x0eput_skew_slope\x18\t \x01(\x01R\fputSkewSlope\x12&\n" +
+	"\x0fcall_skew_slope\x18\n" +
+	" \x01(\x01R\rcallSkewSlope\x12\x1d\n" +
+	"\n" +
+	"term_ratio\x18\v \x01(\x01R\ttermRatio\"\xd0\x01\n" +
+	"\n" +
+	"VolMetrics\x12?\n" +
+	"\vexpirations\x18\x01 \x03(\v2\x1d.options.v1.ExpirationMetricsR\vexpirations\x12(\n" +
+	"\x10front_back_ratio\x18\x02 \x01(\x01R\x0efrontBackRatio\x12\x19\n" +
+	"\batm_iv30\x18\x03 \x01(\x01R\aatmIv30\x12\x19\n" +
+	"\batm_iv90\x18\x04 \x01(\x01R\aatmIv90\x12!\n" +
+	"\fratio30_to90\x18\x05 \x01(\x01R\vratio30To90\"\xba\x03\n" +
+	"\fExpectedMove\x12\x1e\n" +
+	"\n" +
+	"expiration\x18\x01 \x01(\tR\n" +
+	"expiration\x12,\n" +
+	"\x12days_to_expiration\x18\x02 \x01(\x01R\x10daysToExpiration\x12'\n" +
+	"\x0fstraddle_strike\x18\x03 \x01(\x01R\x0estraddleStrike\x12%\n" +
+	"\x0estraddle_price\x18\x04 \x01(\x01R\rstraddlePrice\x12%\n" +
+	"\x0estraddle_lower\x18\x05 \x01(\x01R\rstraddleLower\x12%\n" +
+	"\x0estraddle_upper\x18\x06 \x01(\x01R\rstraddleUpper\x121\n" +
+	"\x14straddle_probability\x18\a \x01(\x01R\x13straddleProbability\x12\x15\n" +
+	"\x06atm_iv\x18\b \x01(\x01R\x05atmIv\x12\x17\n" +
+	"\aiv_move\x18\t \x01(\x01R\x06ivMove\x12\x19\n" +
+	"\biv_lower\x18\n" +
+	" \x01(\x01R\aivLower\x12\x19\n" +
+	"\biv_upper\x18\v \x01(\x01R\aivUpper\x12%\n" +
+	"\x0eiv_probability\x18\f \x01(\x01R\rivProbability\"\xc1\x01\n" +
+	"\rClientMessage\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x17\n" +
+	"\x04rate\x18\x03 \x01(\x01H\x00R\x04rate\x88\x01\x01\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\x12#\n" +
+	"\x04legs\x18\x05 \x03(\v2\x0f.options.v1.LegR\x04legs\x12\x19\n" +
+	"\x05entry\x18\x06 \x01(\x01H\x01R\x05entry\x88\x01\x01B\a\n" +
+	"\x05_rateB\b\n" +
+	"\x06_entry\"=\n" +
+	"\x03Leg\x12\x1a\n" +
+	"\bcontract\x18\x01 \x01(\tR\bcontract\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x03R\bquantity\"c\n" +
+	"\bSnapshot\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x04R\x03seq\x12-\n" +
+	"\x05chain\x18\x03 \x01(\v2\x17.options.v1.OptionChainR\x05chain\"o\n" +
+	"\rContractDelta\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12.\n" +
+	"\x06values\x18\x02 \x01(\v2\x16.options.v1.OptionDataR\x06values\x12\x16\n" +
+	"\x06fields\x18\x03 \x03(\tR\x06fields\"\xd6\x02\n" +
+	"\x05Delta\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x04R\x03seq\x12=\n" +
+	"\flast_updated\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12.\n" +
+	"\x10underlying_price\x18\x04 \x01(\x01H\x00R\x0funderlyingPrice\x88\x01\x01\x123\n" +
+	"\achanges\x18\x05 \x03(\v2\x19.options.v1.ContractDeltaR\achanges\x12\x18\n" +
+	"\aremoved\x18\x06 \x03(\tR\aremoved\x12-\n" +
+	"\x05chain\x18\a \x01(\v2\x17.options.v1.OptionChainR\x05chain\x12!\n" +
+	"\fchain_fields\x18\b \x03(\tR\vchainFieldsB\x13\n" +
+	"\x11_underlying_price\"\xf9\x01\n" +
+	"\aSurface\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12)\n" +
+	"\x10underlying_price\x18\x02 \x01(\x01R\x0funderlyingPrice\x12=\n" +
+	"\flast_updated\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12\x12\n" +
+	"\x04axis\x18\x04 \x01(\tR\x04axis\x12\x16\n" +
+	"\x06tenors\x18\x05 \x03(\x01R\x06tenors\x12\x14\n" +
+	"\x05nodes\x18\x06 \x03(\x01R\x05nodes\x12*\n" +
+	"\x04rows\x18\a \x03(\v2\x16.options.v1.SurfaceRowR\x04rows\" \n" +
+	"\n" +
+	"SurfaceRow\x12\x12\n" +
+	"\x04vols\x18\x01 \x03(\x01R\x04vols\"\x93\x01\n" +
+	"\bLegQuote\x12!\n" +
+	"\x03leg\x18\x01 \x01(\v2\x0f.options.v1.LegR\x03leg\x12.\n" +
+	"\x06option\x18\x02 \x01(\v2\x16.options.v1.OptionDataR\x06option\x12\x10\n" +
+	"\x03bid\x18\x03 \x01(\x01R\x03bid\x12\x10\n" +
+	"\x03ask\x18\x04 \x01(\x01R\x03ask\x12\x10\n" +
+	"\x03mid\x18\x05 \x01(\x01R\x03mid\"C\n" +
+	"\vSpreadPrice\x12\x10\n" +
+	"\x03bid\x18\x01 \x01(\x01R\x03bid\x12\x10\n" +
+	"\x03mid\x18\x02 \x01(\x01R\x03mid\x12\x10\n" +
+	"\x03ask\x18\x03 \x01(\x01R\x03ask\"x\n" +
+	"\x0ePositionGreeks\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\x01R\x05delta\x12\x14\n" +
+	"\x05gamma\x18\x02 \x01(\x01R\x05gamma\x12\x14\n" +
+	"\x05theta\x18\x03 \x01(\x01R\x05theta\x12\x12\n" +
+	"\x04vega\x18\x04 \x01(\x01R\x04vega\x12\x10\n" +
+	"\x03rho\x18\x05 \x01(\x01R\x03rho\"\xec\x02\n" +
+	"\vSpreadQuote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"underlying\x18\x02 \x01(\tR\n" +
+	"underlying\x12)\n" +
+	"\x10underlying_price\x18\x03 \x01(\x01R\x0funderlyingPrice\x12=\n" +
+	"\flast_updated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12(\n" +
+	"\x04legs\x18\x05 \x03(\v2\x14.options.v1.LegQuoteR\x04legs\x12-\n" +
+	"\x05price\x18\x06 \x01(\v2\x17.options.v1.SpreadPriceR\x05price\x122\n" +
+	"\x06greeks\x18\a \x01(\v2\x1a.options.v1.PositionGreeksR\x06greeks\x12\x14\n" +
+	"\x05entry\x18\b \x01(\x01R\x05entry\x12\x0e\n" +
+	"\x02pl\x18\t \x01(\x01R\x02pl\x12\x10\n" +
+	"\x03pop\x18\n" +
+	" \x01(\x01R\x03pop\"\x80\x02\n" +
+	"\vScreenMatch\x12\x1e\n" +
+	"\n" +
+	"underlying\x18\x01 \x01(\tR\n" +
+	"underlying\x12)\n" +
+	"\x10underlying_price\x18\x02 \x01(\x01R\x0funderlyingPrice\x12.\n" +
+	"\x06option\x18\x03 \x01(\v2\x16.options.v1.OptionDataR\x06option\x12;\n" +
+	"\x06values\x18\x04 \x03(\v2#.options.v1.ScreenMatch.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xc4\x01\n" +
+	"\fScreenUpdate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12=\n" +
+	"\flast_updated\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x121\n" +
+	"\amatches\x18\x03 \x03(\v2\x17.options.v1.ScreenMatchR\amatches\x12\x14\n" +
+	"\x05added\x18\x04 \x03(\tR\x05added\x12\x18\n" +
+	"\aremoved\x18\x05 \x03(\tR\aremoved\"\x85\x04\n" +
+	"\fUnusualAlert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1e\n" +
+	"\n" +
+	"underlying\x18\x04 \x01(\tR\n" +
+	"underlying\x12)\n" +
+	"\x10underlying_price\x18\x05 \x01(\x01R\x0funderlyingPrice\x12\x1a\n" +
+	"\bcontract\x18\x06 \x01(\tR\bcontract\x12\x1e\n" +
+	"\n" +
+	"expiration\x18\a \x01(\tR\n" +
+	"expiration\x12\x16\n" +
+	"\x06strike\x18\b \x01(\x01R\x06strike\x12\x12\n" +
+	"\x04type\x18\t \x01(\tR\x04type\x12\x12\n" +
+	"\x04side\x18\n" +
+	" \x01(\tR\x04side\x12\x12\n" +
+	"\x04size\x18\v \x01(\x01R\x04size\x12\x14\n" +
+	"\x05price\x18\f \x01(\x01R\x05price\x12\x18\n" +
+	"\apremium\x18\r \x01(\x01R\apremium\x12\x1c\n" +
+	"\texchanges\x18\x0e \x03(\tR\texchanges\x12\x16\n" +
+	"\x06volume\x18\x0f \x01(\x03R\x06volume\x12#\n" +
+	"\ropen_interest\x18\x10 \x01(\x03R\fopenInterest\x12%\n" +
+	"\x0eaverage_volume\x18\x11 \x01(\x01R\raverageVolume\x12\x14\n" +
+	"\x05ratio\x18\x12 \x01(\x01R\x05ratio\"b\n" +
+	"\x11PortfolioWeighted\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\x01R\x05delta\x12\x14\n" +
+	"\x05gamma\x18\x02 \x01(\x01R\x05gamma\x12!\n" +
+	"\fdollar_delta\x18\x03 \x01(\x01R\vdollarDelta\"\xf4\x03\n" +
+	"\x11PortfolioPosition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"underlying\x18\x02 \x01(\tR\n" +
+	"underlying\x12)\n" +
+	"\x10underlying_price\x18\x03 \x01(\x01R\x0funderlyingPrice\x12(\n" +
+	"\x04legs\x18\x04 \x03(\v2\x14.options.v1.LegQuoteR\x04legs\x12-\n" +
+	"\x05price\x18\x05 \x01(\v2\x17.options.v1.SpreadPriceR\x05price\x12\x17\n" +
+	"\x04cost\x18\x06 \x01(\x01H\x00R\x04cost\x88\x01\x01\x12\x13\n" +
+	"\x02pl\x18\a \x01(\x01H\x01R\x02pl\x88\x01\x01\x122\n" +
+	"\x06greeks\x18\b \x01(\v2\x1a.options.v1.PositionGreeksR\x06greeks\x12\x12\n" +
+	"\x04beta\x18\t \x01(\x01R\x04beta\x12\x1f\n" +
+	"\vbeta_source\x18\n" +
+	" \x01(\tR\n" +
+	"betaSource\x12!\n" +
+	"\fbeta_returns\x18\v \x01(\x03R\vbetaReturns\x129\n" +
+	"\bweighted\x18\f \x01(\v2\x1d.options.v1.PortfolioWeightedR\bweighted\x12\x14\n" +
+	"\x05error\x18\r \x01(\tR\x05error\x12\x10\n" +
+	"\x03pop\x18\x0e \x01(\x01R\x03popB\a\n" +
+	"\x05_costB\x05\n" +
+	"\x03_pl\"\xfe\x02\n" +
+	"\x10PortfolioSummary\x12=\n" +
+	"\flast_updated\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12\x1c\n" +
+	"\tbenchmark\x18\x02 \x01(\tR\tbenchmark\x12'\n" +
+	"\x0fbenchmark_price\x18\x03 \x01(\x01R\x0ebenchmarkPrice\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12\x12\n" +
+	"\x04cost\x18\x05 \x01(\x01R\x04cost\x12\x0e\n" +
+	"\x02pl\x18\x06 \x01(\x01R\x02pl\x122\n" +
+	"\x06greeks\x18\a \x01(\v2\x1a.options.v1.PositionGreeksR\x06greeks\x129\n" +
+	"\bweighted\x18\b \x01(\v2\x1d.options.v1.PortfolioWeightedR\bweighted\x12;\n" +
+	"\tpositions\x18\t \x03(\v2\x1d.options.v1.PortfolioPositionR\tpositions\"!\n" +
+	"\x05Error\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xb0\x03\n" +
+	"\rServerMessage\x122\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x14.options.v1.SnapshotH\x00R\bsnapshot\x12)\n" +
+	"\x05delta\x18\x02 \x01(\v2\x11.options.v1.DeltaH\x00R\x05delta\x12)\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.options.v1.ErrorH\x00R\x05error\x12/\n" +
+	"\asurface\x18\x04 \x01(\v2\x13.options.v1.SurfaceH\x00R\asurface\x121\n" +
+	"\x06spread\x18\x05 \x01(\v2\x17.options.v1.SpreadQuoteH\x00R\x06spread\x122\n" +
+	"\x06screen\x18\x06 \x01(\v2\x18.options.v1.ScreenUpdateH\x00R\x06screen\x124\n" +
+	"\aunusual\x18\a \x01(\v2\x18.options.v1.UnusualAlertH\x00R\aunusual\x12<\n" +
+	"\tportfolio\x18\b \x01(\v2\x1c.options.v1.PortfolioSummaryH\x00R\tportfolioB\t\n" +
+	"\amessageBKZIgithub.com/ryanhamamura/options-chain-go/internal/pb/options/v1;optionsv1b\x06proto3"
+
+var (
+	file_options_v1_options_proto_rawDescOnce sync.Once
+	file_options_v1_options_proto_rawDescData []byte
+)
+
+func file_options_v1_options_proto_rawDescGZIP() []byte {
+	file_options_v1_options_proto_rawDescOnce.Do(func() {
+		file_options_v1_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_options_v1_options_proto_rawDesc), len(file_options_v1_options_proto_rawDesc)))
+	})
+	return file_options_v1_options_proto_rawDescData
+}
+
+var file_options_v1_options_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_options_v1_options_proto_goTypes = []any{
+	(*OptionData)(nil),            // 0: options.v1.OptionData
+	(*OptionChain)(nil),           // 1: options.v1.OptionChain
+	(*ExpirationMetrics)(nil),     // 2: options.v1.ExpirationMetrics
+	(*VolMetrics)(nil),            // 3: options.v1.VolMetrics
+	(*ExpectedMove)(nil),          // 4: options.v1.ExpectedMove
+	(*ClientMessage)(nil),         // 5: options.v1.ClientMessage
+	(*Leg)(nil),                   // 6: options.v1.Leg
+	(*Snapshot)(nil),              // 7: options.v1.Snapshot
+	(*ContractDelta)(nil),         // 8: options.v1.ContractDelta
+	(*Delta)(nil),                 // 9: options.v1.Delta
+	(*Surface)(nil),               // 10: options.v1.Surface
+	(*SurfaceRow)(nil),            // 11: options.v1.SurfaceRow
+	(*LegQuote)(nil),              // 12: options.v1.LegQuote
+	(*SpreadPrice)(nil),           // 13: options.v1.SpreadPrice
+	(*PositionGreeks)(nil),        // 14: options.v1.PositionGreeks
+	(*SpreadQuote)(nil),           // 15: options.v1.SpreadQuote
+	(*ScreenMatch)(nil),           // 16: options.v1.ScreenMatch
+	(*ScreenUpdate)(nil),          // 17: options.v1.ScreenUpdate
+	(*UnusualAlert)(nil),          // 18: options.v1.UnusualAlert
+	(*PortfolioWeighted)(nil),     // 19: options.v1.PortfolioWeighted
+	(*PortfolioPosition)(nil),     // 20: options.v1.PortfolioPosition
+	(*PortfolioSummary)(nil),      // 21: options.v1.PortfolioSummary
+	(*Error)(nil),                 // 22: options.v1.Error
+	(*ServerMessage)(nil),         // 23: options.v1.ServerMessage
+	nil,                           // 24: options.v1.ScreenMatch.ValuesEntry
+	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
+}
+var file_options_v1_options_proto_depIdxs = []int32{
+	25, // 0: options.v1.OptionChain.last_updated:type_name -> google.protobuf.Timestamp
+	0,  // 1: options.v1.OptionChain.calls:type_name -> options.v1.OptionData
+	0,  // 2: options.v1.OptionChain.puts:type_name -> options.v1.OptionData
+	3,  // 3: options.v1.OptionChain.metrics:type_name -> options.v1.VolMetrics
+	4,  // 4: options.v1.OptionChain.expected_moves:type_name -> options.v1.ExpectedMove
+	2,  // 5: options.v1.VolMetrics.expirations:type_name -> options.v1.ExpirationMetrics
+	6,  // 6: options.v1.ClientMessage.legs:type_name -> options.v1.Leg
+	1,  // 7: options.v1.Snapshot.chain:type_name -> options.v1.OptionChain
+	0,  // 8: options.v1.ContractDelta.values:type_name -> options.v1.OptionData
+	25, // 9: options.v1.Delta.last_updated:type_name -> google.protobuf.Timestamp
+	8,  // 10: options.v1.Delta.changes:type_name -> options.v1.ContractDelta
+	1,  // 11: options.v1.Delta.chain:type_name -> options.v1.OptionChain
+	25, // 12: options.v1.Surface.last_updated:type_name -> google.protobuf.Timestamp
+	11, // 13: options.v1.Surface.rows:type_name -> options.v1.SurfaceRow
+	6,  // 14: options.v1.LegQuote.leg:type_name -> options.v1.Leg
+	0,  // 15: options.v1.LegQuote.option:type_name -> options.v1.OptionData
+	25, // 16: options.v1.SpreadQuote.last_updated:type_name -> google.protobuf.Timestamp
+	12, // 17: options.v1.SpreadQuote.legs:type_name -> options.v1.LegQuote
+	13, // 18: options.v1.SpreadQuote.price:type_name -> options.v1.SpreadPrice
+	14, // 19: options.v1.SpreadQuote.greeks:type_name -> options.v1.PositionGreeks
+	0,  // 20: options.v1.ScreenMatch.option:type_name -> options.v1.OptionData
+	24, // 21: options.v1.ScreenMatch.values:type_name -> options.v1.ScreenMatch.ValuesEntry
+	25, // 22: options.v1.ScreenUpdate.last_updated:type_name -> google.protobuf.Timestamp
+	16, // 23: options.v1.ScreenUpdate.matches:type_name -> options.v1.ScreenMatch
+	25, // 24: options.v1.UnusualAlert.time:type_name -> google.protobuf.Timestamp
+	12, // 25: options.v1.PortfolioPosition.legs:type_name -> options.v1.LegQuote
+	13, // 26: options.v1.PortfolioPosition.price:type_name -> options.v1.SpreadPrice
+	14, // 27: options.v1.PortfolioPosition.greeks:type_name -> options.v1.PositionGreeks
+	19, // 28: options.v1.PortfolioPosition.weighted:type_name -> options.v1.PortfolioWeighted
+	25, // 29: options.v1.PortfolioSummary.last_updated:type_name -> google.protobuf.Timestamp
+	14, // 30: options.v1.PortfolioSummary.greeks:type_name -> options.v1.PositionGreeks
+	19, // 31: options.v1.PortfolioSummary.weighted:type_name -> options.v1.PortfolioWeighted
+	20, // 32: options.v1.PortfolioSummary.positions:type_name -> options.v1.PortfolioPosition
+	7,  // 33: options.v1.ServerMessage.snapshot:type_name -> options.v1.Snapshot
+	9,  // 34: options.v1.ServerMessage.delta:type_name -> options.v1.Delta
+	22, // 35: options.v1.ServerMessage.error:type_name -> options.v1.Error
+	10, // 36: options.v1.ServerMessage.surface:type_name -> options.v1.Surface
+	15, // 37: options.v1.ServerMessage.spread:type_name -> options.v1.SpreadQuote
+	17, // 38: options.v1.ServerMessage.screen:type_name -> options.v1.ScreenUpdate
+	18, // 39: options.v1.ServerMessage.unusual:type_name -> options.v1.UnusualAlert
+	21, // 40: options.v1.ServerMessage.portfolio:type_name -> options.v1.PortfolioSummary
+	41, // [41:41] is the sub-list for method output_type
+	41, // [41:41] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
+}
+
+func init() { file_options_v1_options_proto_init() }
+func file_options_v1_options_proto_init() {
+	if File_options_v1_options_proto != nil {
+		return
+	}
+	file_options_v1_options_proto_msgTypes[5].OneofWrappers = []any{}
+	file_options_v1_options_proto_msgTypes[9].OneofWrappers = []any{}
+	file_options_v1_options_proto_msgTypes[20].OneofWrappers = []any{}
+	file_options_v1_options_proto_msgTypes[23].OneofWrappers = []any{
+		(*ServerMessage_Snapshot)(nil),
+		(*ServerMessage_Delta)(nil),
+		(*ServerMessage_Error)(nil),
+		(*ServerMessage_Surface)(nil),
+		(*ServerMessage_Spread)(nil),
+		(*ServerMessage_Screen)(nil),
+		(*ServerMessage_Unusual)(nil),
+		(*ServerMessage_Portfolio)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_options_v1_options_proto_rawDesc), len(file_options_v1_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_options_v1_options_proto_goTypes,
+		DependencyIndexes: file_options_v1_options_proto_depIdxs,
+		MessageInfos:      file_options_v1_options_proto_msgTypes,
+	}.Build()
+	File_options_v1_options_proto = out.File
+	file_options_v1_options_proto_goTypes = nil
+	file_options_v1_options_proto_depIdxs = nil
+}
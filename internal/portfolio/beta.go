@@ -0,0 +1,71 @@
+package portfolio
+
+import (
+    "math"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// minReturns is the fewest daily returns a beta is estimated from
+const minReturns = 20
+
+// Beta sources
+const (
+    BetaBenchmark = "benchmark" // the benchmark itself, beta 1
+    BetaFixed     = "fixed"     // set in the configuration
+    BetaCandles   = "candles"   // estimated from daily candles
+    BetaDefault   = "default"   // too little history; assumed 1
+)
+
+// Beta regresses the daily log returns of an underlying on those of the
+// benchmark over the last window days both have closes for. It reports
+// the number of returns used and false if there are too few.
+func Beta(candles, benchmark []models.Candle, window int) (float64, int, bool) {
+    closes := make(map[string]float64, len(benchmark))
+    for _, c := range benchmark {
+        closes[day(c)] = c.Close
+    }
+
+    // Closes of both on the days they share, oldest first
+    var asset, bench []float64
+    for _, c := range candles {
+        if b, ok := closes[day(c)]; ok && c.Close > 0 && b > 0 {
+            asset = append(asset, c.Close)
+            bench = append(bench, b)
+        }
+    }
+    if len(asset) > window+1 {
+        asset = asset[len(asset)-window-1:]
+        bench = bench[len(bench)-window-1:]
+    }
+    n := len(asset) - 1
+    if n < minReturns {
+        return 0, n, false
+    }
+
+    var meanA, meanB float64
+    ra, rb := make([]float64, n), make([]float64, n)
+    for i := 0; i < n; i++ {
+        ra[i] = math.Log(asset[i+1] / asset[i])
+        rb[i] = math.Log(bench[i+1] / bench[i])
+        meanA += ra[i]
+        meanB += rb[i]
+    }
+    meanA /= float64(n)
+    meanB /= float64(n)
+
+    var cov, variance float64
+    for i := 0; i < n; i++ {
+        cov += (ra[i] - meanA) * (rb[i] - meanB)
+        variance += (rb[i] - meanB) * (rb[i] - meanB)
+    }
+    if variance == 0 {
+        return 0, n, false
+    }
+    return cov / variance, n, true
+}
+
+// day is the trading day of a daily candle
+func day(c models.Candle) string {
+    return c.Time.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,95 @@
+package portfolio
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Config holds the portfolio settings
+type Config struct {
+    Benchmark  string             // underlying Greeks are beta-weighted to
+    BetaWindow int                // daily returns betas are estimated over
+    Betas      map[string]float64 // fixed betas that override the estimates
+    Interval   time.Duration      // how often a changed portfolio is republished
+    File       string             // file the positions persist to, if set
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        Benchmark:  strings.ToUpper(getEnvOrDefault("PORTFOLIO_BENCHMARK", "SPY")),
+        BetaWindow: getIntOrDefault("PORTFOLIO_BETA_WINDOW", 120),
+        Interval:   getDurationOrDefault("PORTFOLIO_INTERVAL", time.Second),
+        File:       os.Getenv("PORTFOLIO_FILE"),
+    }
+
+    var err error
+    config.Betas, err = parseBetas(os.Getenv("PORTFOLIO_BETAS"))
+    if err != nil {
+        return nil, err
+    }
+
+    if config.BetaWindow < minReturns {
+        return nil, fmt.Errorf("invalid beta window: %d", config.BetaWindow)
+    }
+    if config.Interval <= 0 {
+        return nil, fmt.Errorf("invalid portfolio interval: %v", config.Interval)
+    }
+
+    return config, nil
+}
+
+// parseBetas parses fixed betas in the form "TSLA:2.1,XLU:0.4"
+func parseBetas(str string) (map[string]float64, error) {
+    betas := make(map[string]float64)
+    for _, entry := range strings.Split(str, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        symbol, beta, ok := strings.Cut(entry, ":")
+        if !ok || strings.TrimSpace(symbol) == "" {
+            return nil, fmt.Errorf("invalid beta entry: %q", entry)
+        }
+        b, err := strconv.ParseFloat(strings.TrimSpace(beta), 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid beta entry: %q", entry)
+        }
+        betas[strings.ToUpper(strings.TrimSpace(symbol))] = b
+    }
+    return betas, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
+
+func getIntOrDefault(key string, defaultValue int) int {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := strconv.Atoi(str)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
+
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := time.ParseDuration(str)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
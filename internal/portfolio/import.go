@@ -0,0 +1,93 @@
+package portfolio
+
+import (
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
+)
+
+// ParseCSV reads holdings from a CSV export with a header row naming the
+// columns underlying, contract, quantity and, optionally, id and cost. Each
+// row is a leg; rows sharing an ID, or the underlying when there is no ID
+// column, make up one holding, whose cost is the sum of its rows' costs.
+// An empty contract is stock.
+func ParseCSV(r io.Reader) ([]Holding, error) {
+    reader := csv.NewReader(r)
+    reader.TrimLeadingSpace = true
+
+    header, err := reader.Read()
+    if errors.Is(err, io.EOF) {
+        return nil, fmt.Errorf("empty portfolio CSV")
+    }
+    if err != nil {
+        return nil, fmt.Errorf("reading portfolio CSV: %w", err)
+    }
+    columns := make(map[string]int, len(header))
+    for i, name := range header {
+        columns[strings.ToLower(strings.TrimSpace(name))] = i
+    }
+    for _, name := range []string{"underlying", "contract", "quantity"} {
+        if _, ok := columns[name]; !ok {
+            return nil, fmt.Errorf("portfolio CSV has no %q column", name)
+        }
+    }
+    field := func(record []string, name string) string {
+        i, ok := columns[name]
+        if !ok || i >= len(record) {
+            return ""
+        }
+        return strings.TrimSpace(record[i])
+    }
+
+    var holdings []Holding
+    index := make(map[string]int)
+    for line := 2; ; line++ {
+        record, err := reader.Read()
+        if errors.Is(err, io.EOF) {
+            break
+        }
+        if err != nil {
+            return nil, fmt.Errorf("reading portfolio CSV: %w", err)
+        }
+
+        underlying := strings.ToUpper(field(record, "underlying"))
+        id := strings.ToUpper(field(record, "id"))
+        if id == "" {
+            id = underlying
+        }
+        quantity, err := strconv.Atoi(field(record, "quantity"))
+        if err != nil {
+            return nil, fmt.Errorf("line %d: invalid quantity %q", line, field(record, "quantity"))
+        }
+
+        i, ok := index[id]
+        if !ok {
+            i = len(holdings)
+            index[id] = i
+            holdings = append(holdings, Holding{ID: id, Position: strategy.Position{Underlying: underlying}})
+        }
+        h := &holdings[i]
+        if h.Underlying != underlying {
+            return nil, fmt.Errorf("line %d: position %q is on %s, not %s", line, id, h.Underlying, underlying)
+        }
+        h.Legs = append(h.Legs, models.Leg{Contract: field(record, "contract"), Quantity: quantity})
+
+        if str := field(record, "cost"); str != "" {
+            cost, err := strconv.ParseFloat(str, 64)
+            if err != nil {
+                return nil, fmt.Errorf("line %d: invalid cost %q", line, str)
+            }
+            if h.Cost == nil {
+                h.Cost = new(float64)
+            }
+            *h.Cost += cost
+        }
+    }
+    return holdings, nil
+}
@@ -0,0 +1,437 @@
+package portfolio
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "os"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
+)
+
+// Holding is a named position of the portfolio
+type Holding struct {
+    ID string `json:"id"`
+    strategy.Position
+    Cost *float64 `json:"cost,omitempty"` // opening price in dollars, positive for a debit
+}
+
+// Weighted are Greeks beta-weighted to the benchmark: delta in benchmark
+// shares, gamma in benchmark shares per benchmark dollar, and dollar delta,
+// the benchmark shares' worth at the benchmark price
+type Weighted struct {
+    Delta       float64 `json:"delta"`
+    Gamma       float64 `json:"gamma"`
+    DollarDelta float64 `json:"dollarDelta"`
+}
+
+// Valuation is a holding valued from its underlying's live chain. Error is
+// set, and the values left empty, when the holding cannot be valued.
+type Valuation struct {
+    ID              string              `json:"id"`
+    Underlying      string              `json:"underlying"`
+    UnderlyingPrice float64             `json:"underlyingPrice"`
+    Legs            []strategy.LegQuote `json:"legs,omitempty"`
+    Price           strategy.Price      `json:"price"`
+    Cost            *float64            `json:"cost,omitempty"`
+    PL              *float64            `json:"pl,omitempty"` // mid against the cost, in dollars
+    POP             float64             `json:"pop"`          // probability of a profit against the cost, or the mid without one
+    Greeks          strategy.Greeks     `json:"greeks"`
+    Beta            float64             `json:"beta"`
+    BetaSource      string              `json:"betaSource"`
+    BetaReturns     int                 `json:"betaReturns,omitempty"` // daily returns an estimated beta is from
+    Weighted        Weighted            `json:"weighted"`
+    Error           string              `json:"error,omitempty"`
+}
+
+// Summary is the valued portfolio. Greeks are net across holdings, each in
+// its own underlying's shares; Weighted adds them up on the benchmark and
+// is left empty until the benchmark has a price. Cost and PL cover the
+// holdings with a cost.
+type Summary struct {
+    Updated        time.Time       `json:"lastUpdated"`
+    Benchmark      string          `json:"benchmark"`
+    BenchmarkPrice float64         `json:"benchmarkPrice"`
+    Value          float64         `json:"value"` // mid, dollars
+    Cost           float64         `json:"cost"`
+    PL             float64         `json:"pl"`
+    Greeks         strategy.Greeks `json:"greeks"`
+    Weighted       Weighted        `json:"weighted"`
+    Positions      []Valuation     `json:"positions"`
+}
+
+// CandleSource supplies the daily candles betas are estimated from
+type CandleSource interface {
+    Candles(symbol string) []models.Candle
+}
+
+// Portfolio holds positions, values them from the live chains and
+// republishes the summary when a chain they depend on updates
+type Portfolio struct {
+    chains    *store.ChainStore
+    candles   CandleSource
+    valuer    strategy.Valuer
+    config    Config
+    publish     func(Summary)
+    subscribe   func(underlying string, contracts []string)
+    unsubscribe func(underlying string, contracts []string)
+    now         func() time.Time
+
+    mu       sync.Mutex
+    holdings map[string]Holding
+    changed  bool
+
+    watchMu sync.Mutex         // orders subscription changes
+    watched map[string]Holding // holdings subscribed to, by ID
+}
+
+// NewPortfolio creates a portfolio over the chain store that values legs
+// for the probability of profit with the given valuer, loading the
+// positions file if one is configured. Summaries go to publish, and the
+// underlyings and contracts of holdings to subscribe once Watch is called;
+// those of holdings removed or replaced go to unsubscribe.
+func NewPortfolio(chains *store.ChainStore, candles CandleSource, valuer strategy.Valuer, config Config, publish func(Summary), subscribe, unsubscribe func(underlying string, contracts []string)) (*Portfolio, error) {
+    p := &Portfolio{
+        chains:      chains,
+        candles:     candles,
+        valuer:      valuer,
+        config:      config,
+        publish:     publish,
+        subscribe:   subscribe,
+        unsubscribe: unsubscribe,
+        now:         time.Now,
+        holdings:    make(map[string]Holding),
+        watched:     make(map[string]Holding),
+    }
+    if config.File == "" {
+        return p, nil
+    }
+
+    data, err := os.ReadFile(config.File)
+    if errors.Is(err, os.ErrNotExist) {
+        return p, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("reading portfolio: %w", err)
+    }
+    var holdings []Holding
+    if err := json.Unmarshal(data, &holdings); err != nil {
+        return nil, fmt.Errorf("parsing portfolio: %w", err)
+    }
+    for _, h := range holdings {
+        h, err := normalize(h)
+        if err != nil {
+            return nil, fmt.Errorf("portfolio position %q: %w", h.ID, err)
+        }
+        p.holdings[h.ID] = h
+    }
+    return p, nil
+}
+
+// Watch subscribes to the benchmark and to every holding's underlying and
+// contracts
+func (p *Portfolio) Watch() {
+    p.subscribe(p.config.Benchmark, nil)
+    for _, h := range p.Holdings() {
+        p.watch(h)
+    }
+}
+
+// Add validates a holding, replacing any of the same ID
+func (p *Portfolio) Add(h Holding) (Holding, error) {
+    h, err := normalize(h)
+    if err != nil {
+        return Holding{}, err
+    }
+
+    p.mu.Lock()
+    p.holdings[h.ID] = h
+    p.changed = true
+    err = p.save()
+    p.mu.Unlock()
+
+    p.watch(h)
+    return h, err
+}
+
+// Import adds holdings, replacing those of the same IDs, or every holding
+// if replace is set. Nothing is imported if any holding is invalid.
+func (p *Portfolio) Import(holdings []Holding, replace bool) ([]Holding, error) {
+    for i, h := range holdings {
+        var err error
+        if holdings[i], err = normalize(h); err != nil {
+            return nil, fmt.Errorf("position %q: %w", h.ID, err)
+        }
+    }
+
+    p.mu.Lock()
+    previous := p.holdings
+    if replace {
+        p.holdings = make(map[string]Holding, len(holdings))
+    }
+    for _, h := range holdings {
+        p.holdings[h.ID] = h
+    }
+    var removed []string
+    for id := range previous {
+        if _, ok := p.holdings[id]; !ok {
+            removed = append(removed, id)
+        }
+    }
+    p.changed = true
+    err := p.save()
+    p.mu.Unlock()
+
+    for _, h := range holdings {
+        p.watch(h)
+    }
+    for _, id := range removed {
+        p.unwatch(id)
+    }
+    return holdings, err
+}
+
+// Delete removes a holding. It reports false if there was no such
+// holding.
+func (p *Portfolio) Delete(id string) (bool, error) {
+    id = strings.ToUpper(strings.TrimSpace(id))
+
+    p.mu.Lock()
+    if _, ok := p.holdings[id]; !ok {
+        p.mu.Unlock()
+        return false, nil
+    }
+    delete(p.holdings, id)
+    p.changed = true
+    err := p.save()
+    p.mu.Unlock()
+
+    p.unwatch(id)
+    return true, err
+}
+
+// Holdings returns the holdings by ID
+func (p *Portfolio) Holdings() []Holding {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    holdings := make([]Holding, 0, len(p.holdings))
+    for _, h := range p.holdings {
+        holdings = append(holdings, h)
+    }
+    sort.Slice(holdings, func(i, j int) bool { return holdings[i].ID < holdings[j].ID })
+    return holdings
+}
+
+// Update marks the portfolio changed when a chain it depends on updates
+func (p *Portfolio) Update(chain models.OptionChain) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    if chain.Symbol == p.config.Benchmark {
+        p.changed = true
+        return
+    }
+    for _, h := range p.holdings {
+        if h.Underlying == chain.Symbol {
+            p.changed = true
+            return
+        }
+    }
+}
+
+// Run republishes the summary at the configured interval whenever the
+// portfolio or a chain it depends on changed, until the context is
+// cancelled
+func (p *Portfolio) Run(ctx context.Context) {
+    ticker := time.NewTicker(p.config.Interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            p.mu.Lock()
+            changed := p.changed
+            p.changed = false
+            p.mu.Unlock()
+            if changed {
+                p.publish(p.Value())
+            }
+        }
+    }
+}
+
+// Value values every holding from its underlying's stored chain and adds
+// up their Greeks
+func (p *Portfolio) Value() Summary {
+    summary := Summary{
+        Updated:   p.now(),
+        Benchmark: p.config.Benchmark,
+        Positions: []Valuation{},
+    }
+    if chain, ok := p.chains.Get(p.config.Benchmark); ok {
+        summary.BenchmarkPrice = chain.Underlying
+    }
+    benchmark := p.candles.Candles(p.config.Benchmark)
+
+    for _, h := range p.Holdings() {
+        v := p.value(h, summary.BenchmarkPrice, benchmark)
+        summary.Positions = append(summary.Positions, v)
+        if v.Error != "" {
+            continue
+        }
+        summary.Value += v.Price.Mid
+        if v.Cost != nil {
+            summary.Cost += *v.Cost
+            summary.PL += *v.PL
+        }
+        summary.Greeks.Delta += v.Greeks.Delta
+        summary.Greeks.Gamma += v.Greeks.Gamma
+        summary.Greeks.Theta += v.Greeks.Theta
+        summary.Greeks.Vega += v.Greeks.Vega
+        summary.Greeks.Rho += v.Greeks.Rho
+        summary.Weighted.Delta += v.Weighted.Delta
+        summary.Weighted.Gamma += v.Weighted.Gamma
+        summary.Weighted.DollarDelta += v.Weighted.DollarDelta
+    }
+    return summary
+}
+
+// value values a holding and weights its Greeks to the benchmark, whose
+// price and candles are given
+func (p *Portfolio) value(h Holding, benchmarkPrice float64, benchmark []models.Candle) Valuation {
+    v := Valuation{ID: h.ID, Underlying: h.Underlying}
+    chain, ok := p.chains.Get(h.Underlying)
+    if !ok || chain.Underlying <= 0 {
+        v.Error = fmt.Sprintf("%v %s", strategy.ErrNoChain, h.Underlying)
+        return v
+    }
+    legs, err := h.Resolve(chain)
+    if err != nil {
+        v.Error = err.Error()
+        return v
+    }
+
+    v.UnderlyingPrice = chain.Underlying
+    v.Legs = legs
+    v.Price = strategy.PriceOf(legs)
+    v.Greeks = strategy.GreeksOf(legs)
+    entry := v.Price.Mid
+    if h.Cost != nil {
+        cost, pl := *h.Cost, v.Price.Mid-*h.Cost
+        v.Cost, v.PL = &cost, &pl
+        entry = cost
+    }
+    v.POP, _ = strategy.ProbabilityOfProfit(p.valuer, h.Underlying, legs, chain.Underlying, entry, p.now())
+
+    v.Beta, v.BetaSource, v.BetaReturns = p.beta(h.Underlying, benchmark)
+    if benchmarkPrice > 0 {
+        // A benchmark move of dB moves the underlying by beta * S/B * dB
+        ratio := v.Beta * chain.Underlying / benchmarkPrice
+        v.Weighted = Weighted{
+            Delta:       v.Greeks.Delta * ratio,
+            Gamma:       v.Greeks.Gamma * ratio * ratio,
+            DollarDelta: v.Greeks.Delta * ratio * benchmarkPrice,
+        }
+    }
+    return v
+}
+
+// beta returns the beta of an underlying to the benchmark, where it came
+// from and the returns it was estimated from
+func (p *Portfolio) beta(symbol string, benchmark []models.Candle) (float64, string, int) {
+    if symbol == p.config.Benchmark {
+        return 1, BetaBenchmark, 0
+    }
+    if beta, ok := p.config.Betas[symbol]; ok {
+        return beta, BetaFixed, 0
+    }
+    beta, n, ok := Beta(p.candles.Candles(symbol), benchmark, p.config.BetaWindow)
+    if !ok {
+        return 1, BetaDefault, n
+    }
+    return beta, BetaCandles, n
+}
+
+// watch subscribes to a holding's underlying and contracts, then releases
+// those of the holding of the same ID it replaces, so shared subscriptions
+// are never dropped in between
+func (p *Portfolio) watch(h Holding) {
+    p.watchMu.Lock()
+    defer p.watchMu.Unlock()
+
+    previous, ok := p.watched[h.ID]
+    p.watched[h.ID] = h
+    p.subscribe(h.Underlying, contracts(h))
+    if ok {
+        p.unsubscribe(previous.Underlying, contracts(previous))
+    }
+}
+
+// unwatch releases the subscriptions of a holding no longer held
+func (p *Portfolio) unwatch(id string) {
+    p.watchMu.Lock()
+    defer p.watchMu.Unlock()
+
+    h, ok := p.watched[id]
+    if !ok {
+        return
+    }
+    delete(p.watched, id)
+    p.unsubscribe(h.Underlying, contracts(h))
+}
+
+// contracts returns the option contracts of a holding's legs
+func contracts(h Holding) []string {
+    var contracts []string
+    for _, leg := range h.Legs {
+        if leg.Contract != "" {
+            contracts = append(contracts, leg.Contract)
+        }
+    }
+    return contracts
+}
+
+// save writes the positions file, if one is configured. Callers must hold
+// the lock.
+func (p *Portfolio) save() error {
+    if p.config.File == "" {
+        return nil
+    }
+    holdings := make([]Holding, 0, len(p.holdings))
+    for _, h := range p.holdings {
+        holdings = append(holdings, h)
+    }
+    sort.Slice(holdings, func(i, j int) bool { return holdings[i].ID < holdings[j].ID })
+    data, err := json.MarshalIndent(holdings, "", "  ")
+    if err != nil {
+        return fmt.Errorf("encoding portfolio: %w", err)
+    }
+    if err := os.WriteFile(p.config.File, data, 0o644); err != nil {
+        return fmt.Errorf("writing portfolio: %w", err)
+    }
+    return nil
+}
+
+// normalize upper-cases a holding's ID and underlying, like the symbols
+// clients subscribe to, and validates it
+func normalize(h Holding) (Holding, error) {
+    h.ID = strings.ToUpper(strings.TrimSpace(h.ID))
+    h.Underlying = strings.ToUpper(strings.TrimSpace(h.Underlying))
+    if h.ID == "" {
+        return h, fmt.Errorf("position id is required")
+    }
+    if err := h.Validate(); err != nil {
+        return h, err
+    }
+    return h, nil
+}
@@ -0,0 +1,95 @@
+package portfolio
+
+import (
+    "testing"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
+)
+
+// subscriptions counts the requests holding each underlying and contract,
+// as the feed client's reference counts do
+type subscriptions map[string]int
+
+func (s subscriptions) add(underlying string, contracts []string) {
+    for _, symbol := range append([]string{underlying}, contracts...) {
+        s[symbol]++
+    }
+}
+
+func (s subscriptions) remove(underlying string, contracts []string) {
+    for _, symbol := range append([]string{underlying}, contracts...) {
+        if s[symbol]--; s[symbol] == 0 {
+            delete(s, symbol)
+        }
+    }
+}
+
+func holding(id, underlying, contract string) Holding {
+    return Holding{ID: id, Position: strategy.Position{
+        Underlying: underlying,
+        Legs:       []models.Leg{{Contract: contract, Quantity: 1}},
+    }}
+}
+
+func TestHoldingSubscriptions(t *testing.T) {
+    subs := subscriptions{}
+    p, err := NewPortfolio(nil, nil, nil, Config{Benchmark: "SPY"}, func(Summary) {}, subs.add, subs.remove)
+    if err != nil {
+        t.Fatal(err)
+    }
+    p.Watch()
+
+    steps := []struct {
+        name string
+        do   func() error
+        want subscriptions
+    }{
+        {
+            name: "add",
+            do:   func() error { _, err := p.Add(holding("a", "QQQ", ".QQQ261218C500")); return err },
+            want: subscriptions{"SPY": 1, "QQQ": 1, ".QQQ261218C500": 1},
+        },
+        {
+            name: "re-add the same holding",
+            do:   func() error { _, err := p.Add(holding("a", "QQQ", ".QQQ261218C500")); return err },
+            want: subscriptions{"SPY": 1, "QQQ": 1, ".QQQ261218C500": 1},
+        },
+        {
+            name: "replace a holding's legs",
+            do:   func() error { _, err := p.Add(holding("a", "QQQ", ".QQQ261218C510")); return err },
+            want: subscriptions{"SPY": 1, "QQQ": 1, ".QQQ261218C510": 1},
+        },
+        {
+            name: "add a holding on the benchmark",
+            do:   func() error { _, err := p.Add(holding("b", "SPY", ".SPY261218P600")); return err },
+            want: subscriptions{"SPY": 2, "QQQ": 1, ".QQQ261218C510": 1, ".SPY261218P600": 1},
+        },
+        {
+            name: "delete",
+            do:   func() error { _, err := p.Delete("b"); return err },
+            want: subscriptions{"SPY": 1, "QQQ": 1, ".QQQ261218C510": 1},
+        },
+        {
+            name: "import replacing every holding",
+            do: func() error {
+                _, err := p.Import([]Holding{holding("c", "IWM", ".IWM261218C220")}, true)
+                return err
+            },
+            want: subscriptions{"SPY": 1, "IWM": 1, ".IWM261218C220": 1},
+        },
+    }
+    for _, step := range steps {
+        if err := step.do(); err != nil {
+            t.Fatalf("%s: %v", step.name, err)
+        }
+        if len(subs) != len(step.want) {
+            t.Fatalf("%s: subscriptions = %v, want %v", step.name, subs, step.want)
+        }
+        for symbol, n := range step.want {
+            if subs[symbol] != n {
+                t.Fatalf("%s: subscriptions = %v, want %v", step.name, subs, step.want)
+            }
+        }
+    }
+}
@@ -0,0 +1,68 @@
+package portfolio
+
+import (
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Message carries the valued portfolio, named by the benchmark its Greeks
+// are weighted to, to stream clients
+type Message struct {
+    Type      string  `json:"type"` // "portfolio"
+    Symbol    string  `json:"symbol"`
+    Portfolio Summary `json:"portfolio"`
+}
+
+// NewMessage wraps a portfolio summary for streaming
+func NewMessage(s Summary) Message {
+    return Message{Type: "portfolio", Symbol: s.Benchmark, Portfolio: s}
+}
+
+// ServerMessage returns the message in its protobuf form
+func (m Message) ServerMessage() *optionsv1.ServerMessage {
+    return &optionsv1.ServerMessage{Message: &optionsv1.ServerMessage_Portfolio{Portfolio: m.Portfolio.Proto()}}
+}
+
+// Proto converts the valued portfolio to its protobuf form
+func (s Summary) Proto() *optionsv1.PortfolioSummary {
+    positions := make([]*optionsv1.PortfolioPosition, len(s.Positions))
+    for i, v := range s.Positions {
+        positions[i] = &optionsv1.PortfolioPosition{
+            Id:              v.ID,
+            Underlying:      v.Underlying,
+            UnderlyingPrice: v.UnderlyingPrice,
+            Legs:            strategy.ProtoLegs(v.Legs),
+            Price:           v.Price.Proto(),
+            Cost:            v.Cost,
+            Pl:              v.PL,
+            Greeks:          v.Greeks.Proto(),
+            Beta:            v.Beta,
+            BetaSource:      v.BetaSource,
+            BetaReturns:     int64(v.BetaReturns),
+            Weighted:        v.Weighted.Proto(),
+            Error:           v.Error,
+            Pop:             v.POP,
+        }
+    }
+    return &optionsv1.PortfolioSummary{
+        LastUpdated:    timestamppb.New(s.Updated),
+        Benchmark:      s.Benchmark,
+        BenchmarkPrice: s.BenchmarkPrice,
+        Value:          s.Value,
+        Cost:           s.Cost,
+        Pl:             s.PL,
+        Greeks:         s.Greeks.Proto(),
+        Weighted:       s.Weighted.Proto(),
+        Positions:      positions,
+    }
+}
+
+// Proto converts the weighted Greeks to their protobuf form
+func (w Weighted) Proto() *optionsv1.PortfolioWeighted {
+    return &optionsv1.PortfolioWeighted{
+        Delta:       w.Delta,
+        Gamma:       w.Gamma,
+        DollarDelta: w.DollarDelta,
+    }
+}
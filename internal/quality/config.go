@@ -0,0 +1,57 @@
+package quality
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+)
+
+// Config holds the quote validation thresholds, in dollars per share
+type Config struct {
+    ParityThreshold float64         // how far put-call parity must be broken beyond the bid/ask
+    Tolerance       float64         // how far strike and calendar bounds must be broken
+    European        map[string]bool // underlyings with European-style options; the rest are checked as American
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        ParityThreshold: getFloatOrDefault("QUALITY_PARITY_THRESHOLD", 0.05),
+        Tolerance:       getFloatOrDefault("QUALITY_TOLERANCE", 0.01),
+        European:        make(map[string]bool),
+    }
+    for _, symbol := range strings.Split(getEnvOrDefault("QUALITY_EUROPEAN", "SPX,SPXW,XSP,NDX,RUT,VIX,DJX"), ",") {
+        if symbol = strings.ToUpper(strings.TrimSpace(symbol)); symbol != "" {
+            config.European[symbol] = true
+        }
+    }
+
+    if config.ParityThreshold < 0 {
+        return nil, fmt.Errorf("invalid parity threshold: %v", config.ParityThreshold)
+    }
+    if config.Tolerance < 0 {
+        return nil, fmt.Errorf("invalid quality tolerance: %v", config.Tolerance)
+    }
+
+    return config, nil
+}
+
+func getFloatOrDefault(key string, defaultValue float64) float64 {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := strconv.ParseFloat(str, 64)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
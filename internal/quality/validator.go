@@ -0,0 +1,302 @@
+package quality
+
+import (
+    "math"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// flagOrder is the order flags are listed in on a contract
+var flagOrder = []string{
+    models.FlagCrossed,
+    models.FlagLocked,
+    models.FlagParity,
+    models.FlagMonotonicity,
+    models.FlagButterfly,
+    models.FlagCalendar,
+}
+
+// Market supplies the discounting and forwards parity is checked against
+type Market interface {
+    RiskFreeRate(t float64) float64
+    Forwards(chain models.OptionChain, now time.Time) map[string]float64
+}
+
+// Violation is a crossed quote or a broken no-arbitrage bound. Amount is
+// how far, in dollars per share, the quotes break it by; for a calendar
+// the expiration is the earlier one.
+type Violation struct {
+    Flag       string    `json:"flag"`
+    Expiration string    `json:"expiration"`
+    Type       string    `json:"type,omitempty"` // empty for parity, which pairs a call and put
+    Strikes    []float64 `json:"strikes"`
+    Contracts  []string  `json:"contracts"`
+    Amount     float64   `json:"amount"`
+}
+
+// Report lists the violations of a chain
+type Report struct {
+    Symbol     string      `json:"symbol"`
+    Updated    time.Time   `json:"lastUpdated"`
+    Checked    int         `json:"checked"`
+    Flagged    int         `json:"flagged"` // contracts with at least one flag
+    Violations []Violation `json:"violations"`
+}
+
+// Validator checks chains for crossed and locked markets and for quotes
+// that break put-call parity, strike monotonicity, butterfly convexity or
+// calendar ordering. Bounds are checked against tradeable prices: a
+// violation means the quotes themselves could be traded for a riskless
+// credit. Crossed quotes are left out of every other check.
+type Validator struct {
+    chains *store.ChainStore
+    config Config
+    market Market
+    now    func() time.Time
+}
+
+// NewValidator creates a validator checking parity against the market's
+// forwards
+func NewValidator(chains *store.ChainStore, config Config, market Market) *Validator {
+    return &Validator{
+        chains: chains,
+        config: config,
+        market: market,
+        now:    time.Now,
+    }
+}
+
+// Apply sets the quality flags of every contract of a chain
+func (v *Validator) Apply(chain *models.OptionChain) {
+    flags := make(map[string]map[string]bool)
+    for _, violation := range v.Check(*chain).Violations {
+        for _, contract := range violation.Contracts {
+            if flags[contract] == nil {
+                flags[contract] = make(map[string]bool)
+            }
+            flags[contract][violation.Flag] = true
+        }
+    }
+
+    set := func(option *models.OptionData) {
+        var list []string
+        for _, flag := range flagOrder {
+            if flags[option.Symbol][flag] {
+                list = append(list, flag)
+            }
+        }
+        option.Flags = strings.Join(list, ",")
+    }
+    for i := range chain.Calls {
+        set(&chain.Calls[i])
+    }
+    for i := range chain.Puts {
+        set(&chain.Puts[i])
+    }
+}
+
+// Report checks the stored chain of an underlying. It reports false if no
+// chain is stored for the symbol.
+func (v *Validator) Report(symbol string) (Report, bool) {
+    chain, ok := v.chains.Get(symbol)
+    if !ok {
+        return Report{}, false
+    }
+    return v.Check(chain), true
+}
+
+// Check finds the violations of a chain
+func (v *Validator) Check(chain models.OptionChain) Report {
+    now := v.now()
+    report := Report{
+        Symbol:     chain.Symbol,
+        Updated:    now,
+        Checked:    len(chain.Calls) + len(chain.Puts),
+        Violations: []Violation{},
+    }
+
+    // Uncrossed contracts by expiration and type, in strike order
+    contracts := make(map[string]map[string][]models.OptionData)
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for _, option := range options {
+            if option.Bid > 0 && option.Ask > 0 && option.Bid >= option.Ask {
+                flag := models.FlagLocked
+                if option.Bid > option.Ask {
+                    flag = models.FlagCrossed
+                }
+                report.add(flag, option.Bid-option.Ask, option)
+                if flag == models.FlagCrossed {
+                    continue
+                }
+            }
+            if contracts[option.Expiration] == nil {
+                contracts[option.Expiration] = make(map[string][]models.OptionData)
+            }
+            contracts[option.Expiration][option.Type] = append(contracts[option.Expiration][option.Type], option)
+        }
+    }
+    expirations := make([]string, 0, len(contracts))
+    for expiration, types := range contracts {
+        expirations = append(expirations, expiration)
+        for _, options := range types {
+            sort.Slice(options, func(i, j int) bool { return options[i].Strike < options[j].Strike })
+        }
+    }
+    sort.Strings(expirations)
+
+    forwards := v.market.Forwards(chain, now)
+    american := !v.config.European[strings.ToUpper(chain.Symbol)]
+    for _, expiration := range expirations {
+        calls, puts := contracts[expiration]["call"], contracts[expiration]["put"]
+        v.parity(&report, calls, puts, chain.Underlying, forwards[expiration], american, now)
+        v.strikes(&report, calls, true)
+        v.strikes(&report, puts, false)
+    }
+    for i := 1; i < len(expirations); i++ {
+        for _, optionType := range []string{"call", "put"} {
+            v.calendar(&report, contracts[expirations[i-1]][optionType], contracts[expirations[i]][optionType])
+        }
+    }
+
+    flagged := make(map[string]bool)
+    for _, violation := range report.Violations {
+        for _, contract := range violation.Contracts {
+            flagged[contract] = true
+        }
+    }
+    report.Flagged = len(flagged)
+    return report
+}
+
+// parity checks each two-sided call/put pair of an expiration against
+// put-call parity. The bound is broken when the range C - P must lie in
+// misses the range the pair can be traded at, from selling the call and
+// buying the put to the reverse. European pairs must price at
+// DF * (F - K); early exercise widens the range of American pairs to
+// S - D - K <= C - P <= S - DF * K, where D, the present value of the
+// dividends, is S - DF * F.
+func (v *Validator) parity(report *Report, calls, puts []models.OptionData, spot, forward float64, american bool, now time.Time) {
+    if forward <= 0 || len(calls) == 0 || (american && spot <= 0) {
+        return
+    }
+    t, err := analytics.YearsToExpiration(calls[0].Expiration, now)
+    if err != nil || t <= 0 {
+        return
+    }
+    df := math.Exp(-v.market.RiskFreeRate(t) * t)
+
+    byStrike := make(map[float64]models.OptionData, len(puts))
+    for _, put := range puts {
+        byStrike[put.Strike] = put
+    }
+    for _, call := range calls {
+        put, ok := byStrike[call.Strike]
+        if !ok || !twoSided(call) || !twoSided(put) {
+            continue
+        }
+        low := df * (forward - call.Strike)
+        high := low
+        if american {
+            low, high = math.Min(df*forward, spot)-call.Strike, spot-df*call.Strike
+        }
+        lower, upper := call.Bid-put.Ask, call.Ask-put.Bid
+        var amount float64
+        switch {
+        case high < lower:
+            amount = lower - high
+        case low > upper:
+            amount = low - upper
+        }
+        if amount > v.config.ParityThreshold {
+            report.add(models.FlagParity, amount, call, put)
+        }
+    }
+}
+
+// strikes checks the contracts of one type and expiration, in strike
+// order. Calls must not gain and puts must not lose value with the
+// strike, and each must be convex: a butterfly of adjacent strikes can
+// never be bought for a credit.
+func (v *Validator) strikes(report *Report, options []models.OptionData, call bool) {
+    for i := 1; i < len(options); i++ {
+        low, high := options[i-1], options[i]
+        // The cheaper-by-bound contract is bought at its ask and the other
+        // sold at its bid
+        buy, sell := low, high
+        if !call {
+            buy, sell = high, low
+        }
+        if buy.Ask > 0 && sell.Bid > 0 && sell.Bid-buy.Ask > v.config.Tolerance {
+            report.add(models.FlagMonotonicity, sell.Bid-buy.Ask, low, high)
+        }
+    }
+
+    for i := 2; i < len(options); i++ {
+        low, body, high := options[i-2], options[i-1], options[i]
+        if low.Ask <= 0 || high.Ask <= 0 || body.Bid <= 0 {
+            continue
+        }
+        // Wings weighted so the butterfly has no payoff outside them
+        weight := (high.Strike - body.Strike) / (high.Strike - low.Strike)
+        cost := weight*low.Ask + (1-weight)*high.Ask - body.Bid
+        if -cost > v.config.Tolerance {
+            report.add(models.FlagButterfly, -cost, low, body, high)
+        }
+    }
+}
+
+// calendar checks that no contract bids above the ask of the same strike
+// and type in the next expiration
+func (v *Validator) calendar(report *Report, earlier, later []models.OptionData) {
+    byStrike := make(map[float64]models.OptionData, len(later))
+    for _, option := range later {
+        byStrike[option.Strike] = option
+    }
+    for _, near := range earlier {
+        far, ok := byStrike[near.Strike]
+        if !ok || near.Bid <= 0 || far.Ask <= 0 {
+            continue
+        }
+        if near.Bid-far.Ask > v.config.Tolerance {
+            report.add(models.FlagCalendar, near.Bid-far.Ask, near, far)
+        }
+    }
+}
+
+// add records a violation across the given contracts
+func (r *Report) add(flag string, amount float64, options ...models.OptionData) {
+    violation := Violation{
+        Flag:       flag,
+        Expiration: options[0].Expiration,
+        Amount:     amount,
+    }
+    if flag != models.FlagParity {
+        violation.Type = options[0].Type
+    }
+    for _, option := range options {
+        violation.Contracts = append(violation.Contracts, option.Symbol)
+        if !containsStrike(violation.Strikes, option.Strike) {
+            violation.Strikes = append(violation.Strikes, option.Strike)
+        }
+    }
+    r.Violations = append(r.Violations, violation)
+}
+
+// twoSided reports whether a contract has both a bid and an ask
+func twoSided(option models.OptionData) bool {
+    return option.Bid > 0 && option.Ask > 0
+}
+
+func containsStrike(strikes []float64, strike float64) bool {
+    for _, s := range strikes {
+        if s == strike {
+            return true
+        }
+    }
+    return false
+}
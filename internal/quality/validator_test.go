@@ -0,0 +1,84 @@
+package quality
+
+import (
+    "math"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// carryMarket is a flat rate with no dividends, so forwards are the spot
+// carried at the rate
+type carryMarket struct {
+    rate float64
+}
+
+func (m carryMarket) RiskFreeRate(t float64) float64 {
+    return m.rate
+}
+
+func (m carryMarket) Forwards(chain models.OptionChain, now time.Time) map[string]float64 {
+    forwards := make(map[string]float64)
+    for _, option := range append(chain.Calls, chain.Puts...) {
+        t, _ := analytics.YearsToExpiration(option.Expiration, now)
+        forwards[option.Expiration] = chain.Underlying * math.Exp(m.rate*t)
+    }
+    return forwards
+}
+
+func TestParity(t *testing.T) {
+    now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+    quote := func(optionType string, bid, ask float64) models.OptionData {
+        return models.OptionData{
+            Symbol:     optionType,
+            Strike:     130,
+            Expiration: "2027-10-15",
+            Type:       optionType,
+            Bid:        bid,
+            Ask:        ask,
+        }
+    }
+
+    // Spot 100 at 5% for a year: European C - P is DF * (F - K) = -23.66;
+    // American C - P lies in [-30, -23.66]
+    tests := []struct {
+        name    string
+        symbol  string
+        putBid  float64
+        putAsk  float64
+        flagged bool
+    }{
+        {"European pair at parity", "SPX", 24.1, 24.3, false},
+        {"European pair with an early exercise premium", "SPX", 30.0, 30.2, true},
+        {"American pair at European parity", "SPY", 24.1, 24.3, false},
+        {"American pair with an early exercise premium", "SPY", 30.0, 30.2, false},
+        {"American put above the strike less spot", "SPY", 31.0, 31.2, true},
+        {"American put below the discounted strike less spot", "SPY", 23.2, 23.4, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            config := Config{ParityThreshold: 0.05, European: map[string]bool{"SPX": true}}
+            v := NewValidator(nil, config, carryMarket{rate: 0.05})
+            v.now = func() time.Time { return now }
+            chain := models.OptionChain{
+                Symbol:     tt.symbol,
+                Underlying: 100,
+                Calls:      []models.OptionData{quote("call", 0.5, 0.6)},
+                Puts:       []models.OptionData{quote("put", tt.putBid, tt.putAsk)},
+            }
+
+            report := v.Check(chain)
+            flagged := false
+            for _, violation := range report.Violations {
+                if violation.Flag == models.FlagParity {
+                    flagged = true
+                }
+            }
+            if flagged != tt.flagged {
+                t.Errorf("parity flagged = %v, want %v (%+v)", flagged, tt.flagged, report.Violations)
+            }
+        })
+    }
+}
@@ -0,0 +1,79 @@
+package realized
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+)
+
+// Config holds the realized volatility settings
+type Config struct {
+    Windows     []int     // estimation windows in trading days
+    Estimator   Estimator // estimator of the cone and of IV minus RV
+    Lookback    int       // calendar days of candle and IV history kept
+    HistoryFile string    // file the daily ATM IV history persists to, if set
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        Lookback:    getIntOrDefault("REALIZED_LOOKBACK", 365),
+        HistoryFile: os.Getenv("IV_HISTORY_FILE"),
+    }
+
+    var err error
+    config.Windows, err = parseWindows(getEnvOrDefault("REALIZED_WINDOWS", "10,20,30,60,90,120"))
+    if err != nil {
+        return nil, err
+    }
+    config.Estimator, err = ParseEstimator(getEnvOrDefault("REALIZED_ESTIMATOR", string(YangZhang)))
+    if err != nil {
+        return nil, err
+    }
+
+    if config.Lookback < 30 {
+        return nil, fmt.Errorf("invalid realized vol lookback: %d", config.Lookback)
+    }
+
+    return config, nil
+}
+
+// parseWindows parses a comma-separated list of windows in trading days
+func parseWindows(str string) ([]int, error) {
+    var windows []int
+    for _, entry := range strings.Split(str, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        window, err := strconv.Atoi(entry)
+        if err != nil || window < 2 {
+            return nil, fmt.Errorf("invalid realized vol window: %q", entry)
+        }
+        windows = append(windows, window)
+    }
+    if len(windows) == 0 {
+        return nil, fmt.Errorf("no realized vol windows")
+    }
+    return windows, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
+
+func getIntOrDefault(key string, defaultValue int) int {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := strconv.Atoi(str)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
@@ -0,0 +1,95 @@
+package realized
+
+import (
+    "fmt"
+    "math"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// tradingDays annualises daily variance
+const tradingDays = 252.0
+
+// Estimator is a realized volatility estimator
+type Estimator string
+
+const (
+    CloseToClose Estimator = "close-to-close"
+    Parkinson    Estimator = "parkinson"    // high-low range
+    GarmanKlass  Estimator = "garman-klass" // range and open-close
+    YangZhang    Estimator = "yang-zhang"   // overnight, open-close and range; robust to drift and gaps
+)
+
+// Estimators lists every estimator in report order
+var Estimators = []Estimator{CloseToClose, Parkinson, GarmanKlass, YangZhang}
+
+// ParseEstimator parses an estimator name
+func ParseEstimator(name string) (Estimator, error) {
+    for _, e := range Estimators {
+        if Estimator(name) == e {
+            return e, nil
+        }
+    }
+    return "", fmt.Errorf("unknown realized vol estimator: %q", name)
+}
+
+// Vol returns the annualised realized volatility of the bars after the
+// first; the first bar only supplies the previous close. It returns 0 for
+// fewer than two bars after the first.
+func (e Estimator) Vol(candles []models.Candle) float64 {
+    n := len(candles) - 1
+    if n < 2 {
+        return 0
+    }
+    var variance float64
+    switch e {
+    case CloseToClose:
+        returns := make([]float64, n)
+        for i := range returns {
+            returns[i] = math.Log(candles[i+1].Close / candles[i].Close)
+        }
+        variance = sampleVariance(returns)
+    case Parkinson:
+        for _, c := range candles[1:] {
+            hl := math.Log(c.High / c.Low)
+            variance += hl * hl
+        }
+        variance /= 4 * math.Ln2 * float64(n)
+    case GarmanKlass:
+        for _, c := range candles[1:] {
+            hl := math.Log(c.High / c.Low)
+            co := math.Log(c.Close / c.Open)
+            variance += hl*hl/2 - (2*math.Ln2-1)*co*co
+        }
+        variance /= float64(n)
+    case YangZhang:
+        overnight := make([]float64, n)
+        intraday := make([]float64, n)
+        var rs float64
+        for i, c := range candles[1:] {
+            overnight[i] = math.Log(c.Open / candles[i].Close)
+            intraday[i] = math.Log(c.Close / c.Open)
+            rs += math.Log(c.High/c.Close)*math.Log(c.High/c.Open) + math.Log(c.Low/c.Close)*math.Log(c.Low/c.Open)
+        }
+        k := 0.34 / (1.34 + float64(n+1)/float64(n-1))
+        variance = sampleVariance(overnight) + k*sampleVariance(intraday) + (1-k)*rs/float64(n)
+    }
+    if variance <= 0 {
+        return 0
+    }
+    return math.Sqrt(variance * tradingDays)
+}
+
+// sampleVariance is the unbiased variance of values
+func sampleVariance(values []float64) float64 {
+    var mean float64
+    for _, v := range values {
+        mean += v
+    }
+    mean /= float64(len(values))
+    var sum float64
+    for _, v := range values {
+        sum += (v - mean) * (v - mean)
+    }
+    return sum / float64(len(values)-1)
+}
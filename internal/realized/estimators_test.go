@@ -0,0 +1,128 @@
+package realized
+
+import (
+    "math"
+    "testing"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// bars builds n bars after an opening close of 100. Each bar opens a gap
+// away from the previous close, closes a move away from its open, and
+// trades up and down from the higher and lower of the two.
+func bars(n int, gap, move, up, down func(i int) float64) []models.Candle {
+    candles := []models.Candle{{Open: 100, High: 100, Low: 100, Close: 100}}
+    for i := 0; i < n; i++ {
+        open := candles[i].Close * math.Exp(gap(i))
+        close := open * math.Exp(move(i))
+        candles = append(candles, models.Candle{
+            Open:  open,
+            High:  math.Max(open, close) * math.Exp(up(i)),
+            Low:   math.Min(open, close) * math.Exp(-down(i)),
+            Close: close,
+        })
+    }
+    return candles
+}
+
+func constant(x float64) func(int) float64 {
+    return func(int) float64 { return x }
+}
+
+// alternating is +x on even bars and -x on odd ones, so it has no mean
+func alternating(x float64) func(int) float64 {
+    return func(i int) float64 {
+        if i%2 == 0 {
+            return x
+        }
+        return -x
+    }
+}
+
+func TestEstimatorVol(t *testing.T) {
+    const n = 20
+    none := constant(0)
+    // Sample variance of n alternating +-x returns
+    alternatingVar := func(x float64) float64 { return x * x * n / (n - 1) }
+    k := 0.34 / (1.34 + float64(n+1)/float64(n-1))
+
+    tests := []struct {
+        name    string
+        candles []models.Candle
+        want    map[Estimator]float64 // daily variance
+    }{
+        {
+            // Each bar trades only between its open and close
+            name:    "alternating closes",
+            candles: bars(n, none, alternating(0.01), none, none),
+            want: map[Estimator]float64{
+                CloseToClose: alternatingVar(0.01),
+                Parkinson:    0.01 * 0.01 / (4 * math.Ln2),
+                GarmanKlass:  (0.5 - (2*math.Ln2 - 1)) * 0.01 * 0.01,
+                YangZhang:    k * alternatingVar(0.01),
+            },
+        },
+        {
+            name:    "flat closes with symmetric ranges",
+            candles: bars(n, none, none, constant(0.01), constant(0.01)),
+            want: map[Estimator]float64{
+                CloseToClose: 0,
+                Parkinson:    0.02 * 0.02 / (4 * math.Ln2),
+                GarmanKlass:  0.02 * 0.02 / 2,
+                YangZhang:    (1 - k) * 2 * 0.01 * 0.01,
+            },
+        },
+        {
+            // Each bar runs from its low at the open to its high at the
+            // close: a pure trend with no variance about it
+            name:    "steady drift",
+            candles: bars(n, none, constant(0.01), none, none),
+            want: map[Estimator]float64{
+                CloseToClose: 0,
+                Parkinson:    0.01 * 0.01 / (4 * math.Ln2),
+                GarmanKlass:  (0.5 - (2*math.Ln2 - 1)) * 0.01 * 0.01,
+                YangZhang:    0,
+            },
+        },
+        {
+            name:    "overnight gaps only",
+            candles: bars(n, alternating(0.02), none, none, none),
+            want: map[Estimator]float64{
+                CloseToClose: alternatingVar(0.02),
+                Parkinson:    0,
+                GarmanKlass:  0,
+                YangZhang:    alternatingVar(0.02),
+            },
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            for _, e := range Estimators {
+                want := math.Sqrt(tt.want[e] * tradingDays)
+                if got := e.Vol(tt.candles); math.Abs(got-want) > 1e-9 {
+                    t.Errorf("%s = %.6f, want %.6f", e, got, want)
+                }
+            }
+        })
+    }
+}
+
+func TestEstimatorVolTooFewBars(t *testing.T) {
+    candles := bars(1, constant(0.01), constant(0.01), constant(0.01), constant(0.01))
+    for _, e := range Estimators {
+        if got := e.Vol(candles); got != 0 {
+            t.Errorf("%s of one bar = %v, want 0", e, got)
+        }
+    }
+}
+
+func TestParseEstimator(t *testing.T) {
+    for _, e := range Estimators {
+        if got, err := ParseEstimator(string(e)); err != nil || got != e {
+            t.Errorf("ParseEstimator(%q) = %q, %v", e, got, err)
+        }
+    }
+    if _, err := ParseEstimator("range"); err == nil {
+        t.Error("parsed an unknown estimator")
+    }
+}
@@ -0,0 +1,162 @@
+package realized
+
+import (
+    "errors"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// ErrTooFewCandles is returned when the history is too short to estimate
+// volatility over any window
+var ErrTooFewCandles = errors.New("too few candles")
+
+// Window holds the realized vols over one window, against the ATM implied
+// vol of the same calendar length
+type Window struct {
+    Days         int     `json:"days"` // trading days
+    CloseToClose float64 `json:"closeToClose"`
+    Parkinson    float64 `json:"parkinson"`
+    GarmanKlass  float64 `json:"garmanKlass"`
+    YangZhang    float64 `json:"yangZhang"`
+    ImpliedVol   float64 `json:"impliedVol"`
+    IVMinusRV    float64 `json:"ivMinusRv"` // against the configured estimator
+}
+
+// vol returns the window's vol from an estimator
+func (w Window) vol(e Estimator) float64 {
+    switch e {
+    case CloseToClose:
+        return w.CloseToClose
+    case Parkinson:
+        return w.Parkinson
+    case GarmanKlass:
+        return w.GarmanKlass
+    }
+    return w.YangZhang
+}
+
+// ConePoint is the distribution of rolling realized vol over one window
+type ConePoint struct {
+    Days    int     `json:"days"`
+    Samples int     `json:"samples"`
+    Min     float64 `json:"min"`
+    P25     float64 `json:"p25"`
+    Median  float64 `json:"median"`
+    P75     float64 `json:"p75"`
+    Max     float64 `json:"max"`
+    Current float64 `json:"current"`
+}
+
+// Report compares realized and implied volatility of an underlying
+type Report struct {
+    Symbol    string      `json:"symbol"`
+    Updated   time.Time   `json:"lastUpdated"`
+    Estimator Estimator   `json:"estimator"`
+    Candles   int         `json:"candles"`
+    Windows   []Window    `json:"windows"`
+    Cone      []ConePoint `json:"cone"`
+
+    // ATM 30-day implied vol against its own daily history
+    ATMIV30       float64 `json:"atmIv30"`
+    IVRank        float64 `json:"ivRank"`       // position between the low and high, 0 to 1
+    IVPercentile  float64 `json:"ivPercentile"` // share of prior days below the current level
+    IVHistoryDays int     `json:"ivHistoryDays"`
+}
+
+// Compute builds the report of an underlying from its daily candles, its
+// chain's vol metrics (nil if there is no chain) and its daily ATM IV
+// history, oldest first
+func Compute(symbol string, candles []models.Candle, metrics *models.VolMetrics, ivs []float64, config Config, now time.Time) (Report, error) {
+    report := Report{
+        Symbol:    symbol,
+        Updated:   now,
+        Estimator: config.Estimator,
+        Candles:   len(candles),
+    }
+    if len(candles) < 3 {
+        return report, ErrTooFewCandles
+    }
+
+    for _, days := range config.Windows {
+        if days >= len(candles) {
+            continue
+        }
+        recent := candles[len(candles)-days-1:]
+        w := Window{
+            Days:         days,
+            CloseToClose: CloseToClose.Vol(recent),
+            Parkinson:    Parkinson.Vol(recent),
+            GarmanKlass:  GarmanKlass.Vol(recent),
+            YangZhang:    YangZhang.Vol(recent),
+            ImpliedVol:   analytics.ATMVolAt(metrics, float64(days)*365/tradingDays),
+        }
+        if w.ImpliedVol > 0 && w.vol(config.Estimator) > 0 {
+            w.IVMinusRV = w.ImpliedVol - w.vol(config.Estimator)
+        }
+        report.Windows = append(report.Windows, w)
+        report.Cone = append(report.Cone, cone(candles, days, config.Estimator))
+    }
+
+    if metrics != nil {
+        report.ATMIV30 = metrics.ATMIV30
+    }
+    report.IVHistoryDays = len(ivs)
+    report.IVRank, report.IVPercentile = ivRank(report.ATMIV30, ivs)
+    return report, nil
+}
+
+// cone returns the percentiles of an estimator's vol over every window of
+// the given length in the history
+func cone(candles []models.Candle, days int, e Estimator) ConePoint {
+    var vols []float64
+    for end := days + 1; end <= len(candles); end++ {
+        vols = append(vols, e.Vol(candles[end-days-1:end]))
+    }
+    point := ConePoint{Days: days, Samples: len(vols)}
+    if len(vols) == 0 {
+        return point
+    }
+    point.Current = vols[len(vols)-1]
+    sort.Float64s(vols)
+    point.Min = vols[0]
+    point.P25 = percentile(vols, 0.25)
+    point.Median = percentile(vols, 0.5)
+    point.P75 = percentile(vols, 0.75)
+    point.Max = vols[len(vols)-1]
+    return point
+}
+
+// percentile interpolates between the nearest ranks of sorted values
+func percentile(sorted []float64, q float64) float64 {
+    pos := q * float64(len(sorted)-1)
+    i := int(pos)
+    if i >= len(sorted)-1 {
+        return sorted[len(sorted)-1]
+    }
+    return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
+
+// ivRank places the current IV within the history: its rank between the
+// low and high of the history, and the share of prior days below it
+func ivRank(current float64, history []float64) (float64, float64) {
+    if current <= 0 || len(history) < 2 {
+        return 0, 0
+    }
+    low, high := current, current
+    var below int
+    for _, iv := range history {
+        low = min(low, iv)
+        high = max(high, iv)
+        if iv < current {
+            below++
+        }
+    }
+    var rank float64
+    if high > low {
+        rank = (current - low) / (high - low)
+    }
+    return rank, float64(below) / float64(len(history))
+}
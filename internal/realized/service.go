@@ -0,0 +1,200 @@
+package realized
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log"
+    "os"
+    "sort"
+    "sync"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// ivSample is the ATM 30-day implied vol of an underlying on one day
+type ivSample struct {
+    Date  string  `json:"date"` // 2006-01-02
+    ATMIV float64 `json:"atmIv"`
+}
+
+// Service keeps daily candles and ATM IV history per underlying and
+// reports realized against implied volatility
+type Service struct {
+    chains *store.ChainStore
+    config Config
+    now    func() time.Time
+
+    mu      sync.RWMutex
+    candles map[string][]models.Candle // by time
+    ivs     map[string][]ivSample      // by date
+}
+
+// NewService creates a realized vol service over the chain store, loading
+// the IV history file if one is configured
+func NewService(chains *store.ChainStore, config Config) (*Service, error) {
+    s := &Service{
+        chains:  chains,
+        config:  config,
+        now:     time.Now,
+        candles: make(map[string][]models.Candle),
+        ivs:     make(map[string][]ivSample),
+    }
+    if config.HistoryFile == "" {
+        return s, nil
+    }
+
+    data, err := os.ReadFile(config.HistoryFile)
+    if errors.Is(err, os.ErrNotExist) {
+        return s, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("reading IV history: %w", err)
+    }
+    if err := json.Unmarshal(data, &s.ivs); err != nil {
+        return nil, fmt.Errorf("parsing IV history: %w", err)
+    }
+    return s, nil
+}
+
+// HistoryStart is the first day of candle history to request
+func (s *Service) HistoryStart() time.Time {
+    return s.now().AddDate(0, 0, -s.config.Lookback)
+}
+
+// AddCandle stores a daily candle, replacing any earlier bar for the same
+// time as the day's candle updates
+func (s *Service) AddCandle(symbol string, candle models.Candle) {
+    if candle.Open <= 0 || candle.Close <= 0 || candle.Low <= 0 || candle.High < candle.Low {
+        return
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    candles := s.candles[symbol]
+    i := sort.Search(len(candles), func(i int) bool { return !candles[i].Time.Before(candle.Time) })
+    if i < len(candles) && candles[i].Time.Equal(candle.Time) {
+        candles[i] = candle
+        return
+    }
+    candles = append(candles, models.Candle{})
+    copy(candles[i+1:], candles[i:])
+    candles[i] = candle
+
+    cutoff := s.HistoryStart()
+    for len(candles) > 0 && candles[0].Time.Before(cutoff) {
+        candles = candles[1:]
+    }
+    s.candles[symbol] = candles
+}
+
+// Candles returns the daily candles of an underlying, oldest first
+func (s *Service) Candles(symbol string) []models.Candle {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return append([]models.Candle(nil), s.candles[symbol]...)
+}
+
+// Observe records the ATM 30-day implied vol of a chain as its value for
+// the day. The history file is written when a new day starts.
+func (s *Service) Observe(chain models.OptionChain) {
+    if chain.Metrics == nil || chain.Metrics.ATMIV30 <= 0 {
+        return
+    }
+    today := s.now().Format("2006-01-02")
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    ivs := s.ivs[chain.Symbol]
+    if n := len(ivs); n > 0 && ivs[n-1].Date == today {
+        ivs[n-1].ATMIV = chain.Metrics.ATMIV30
+        return
+    }
+    ivs = append(ivs, ivSample{Date: today, ATMIV: chain.Metrics.ATMIV30})
+    cutoff := s.HistoryStart().Format("2006-01-02")
+    for len(ivs) > 0 && ivs[0].Date < cutoff {
+        ivs = ivs[1:]
+    }
+    s.ivs[chain.Symbol] = ivs
+
+    if err := s.save(); err != nil {
+        log.Printf("Error saving IV history: %v", err)
+    }
+}
+
+// Save writes the IV history file, if one is configured
+func (s *Service) Save() error {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return s.save()
+}
+
+// save writes the IV history file. Callers must hold the lock.
+func (s *Service) save() error {
+    if s.config.HistoryFile == "" {
+        return nil
+    }
+    data, err := json.Marshal(s.ivs)
+    if err != nil {
+        return fmt.Errorf("encoding IV history: %w", err)
+    }
+    if err := os.WriteFile(s.config.HistoryFile, data, 0o644); err != nil {
+        return fmt.Errorf("writing IV history: %w", err)
+    }
+    return nil
+}
+
+// Report builds the realized vol report of an underlying. It reports
+// false if no candles are stored for the symbol.
+func (s *Service) Report(symbol string) (Report, bool, error) {
+    s.mu.RLock()
+    candles := append([]models.Candle(nil), s.candles[symbol]...)
+    history := s.history(symbol)
+    s.mu.RUnlock()
+
+    if len(candles) == 0 {
+        return Report{}, false, nil
+    }
+    var metrics *models.VolMetrics
+    if chain, ok := s.chains.Get(symbol); ok {
+        metrics = chain.Metrics
+    }
+    report, err := Compute(symbol, candles, metrics, history, s.config, s.now())
+    return report, true, err
+}
+
+// IVRank places the current ATM 30-day IV of an underlying within its
+// daily history. It reports false without a chain or at least two days of
+// history.
+func (s *Service) IVRank(symbol string) (rank, percentile float64, ok bool) {
+    chain, ok := s.chains.Get(symbol)
+    if !ok || chain.Metrics == nil {
+        return 0, 0, false
+    }
+    s.mu.RLock()
+    history := s.history(symbol)
+    s.mu.RUnlock()
+
+    if len(history) < 2 || chain.Metrics.ATMIV30 <= 0 {
+        return 0, 0, false
+    }
+    rank, percentile = ivRank(chain.Metrics.ATMIV30, history)
+    return rank, percentile, true
+}
+
+// history returns the ATM IVs of an underlying before today, oldest
+// first. The caller must hold the lock.
+func (s *Service) history(symbol string) []float64 {
+    today := s.now().Format("2006-01-02")
+    var history []float64
+    for _, sample := range s.ivs[symbol] {
+        if sample.Date != today {
+            history = append(history, sample.ATMIV)
+        }
+    }
+    return history
+}
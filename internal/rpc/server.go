@@ -0,0 +1,286 @@
+package rpc
+
+import (
+    "context"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+    "github.com/ryanhamamura/options-chain-go/internal/stream"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements the options.v1.ChainService gRPC API on top of the
+// chain store and the stream manager's fan-out
+type Server struct {
+    optionsv1.UnimplementedChainServiceServer
+
+    chains    *store.ChainStore
+    wsManager *stream.Manager
+}
+
+// NewServer creates a gRPC chain service
+func NewServer(chains *store.ChainStore, wsManager *stream.Manager) *Server {
+    return &Server{
+        chains:    chains,
+        wsManager: wsManager,
+    }
+}
+
+// GetChain returns the latest chain for an underlying
+func (s *Server) GetChain(ctx context.Context, req *optionsv1.GetChainRequest) (*optionsv1.GetChainResponse, error) {
+    filter, err := contractFilter(req.GetFilter())
+    if err != nil {
+        return nil, err
+    }
+    chain, err := s.chain(req.GetSymbol())
+    if err != nil {
+        return nil, err
+    }
+    return &optionsv1.GetChainResponse{
+        Chain: filter.FilterChain(chain).Proto(),
+    }, nil
+}
+
+// ListExpirations returns the expirations listed in an underlying's chain
+func (s *Server) ListExpirations(ctx context.Context, req *optionsv1.ListExpirationsRequest) (*optionsv1.ListExpirationsResponse, error) {
+    chain, err := s.chain(req.GetSymbol())
+    if err != nil {
+        return nil, err
+    }
+
+    byDate := make(map[string]*optionsv1.Expiration)
+    count := func(options []models.OptionData, calls bool) {
+        for _, option := range options {
+            expiration, ok := byDate[option.Expiration]
+            if !ok {
+                expiration = &optionsv1.Expiration{
+                    Date:             option.Expiration,
+                    DaysToExpiration: daysToExpiration(option.Expiration, time.Now()),
+                }
+                byDate[option.Expiration] = expiration
+            }
+            if calls {
+                expiration.Calls++
+            } else {
+                expiration.Puts++
+            }
+        }
+    }
+    count(chain.Calls, true)
+    count(chain.Puts, false)
+
+    resp := &optionsv1.ListExpirationsResponse{}
+    for _, expiration := range byDate {
+        resp.Expirations = append(resp.Expirations, expiration)
+    }
+    sort.Slice(resp.Expirations, func(i, j int) bool {
+        return resp.Expirations[i].Date < resp.Expirations[j].Date
+    })
+    return resp, nil
+}
+
+// StreamChain sends a snapshot of the chain followed by deltas, using the
+// same sequence numbers as the Server-Sent Events stream
+func (s *Server) StreamChain(req *optionsv1.StreamChainRequest, srv optionsv1.ChainService_StreamChainServer) error {
+    filter, err := contractFilter(req.GetFilter())
+    if err != nil {
+        return err
+    }
+    symbol, err := normalizeSymbol(req.GetSymbol())
+    if err != nil {
+        return err
+    }
+
+    listener := s.wsManager.Listen(symbol, filter, nil)
+    defer s.wsManager.Unlisten(listener)
+
+    for {
+        select {
+        case <-srv.Context().Done():
+            return nil
+        case event, ok := <-listener.Events:
+            if !ok {
+                return status.Error(codes.ResourceExhausted, "client fell too far behind")
+            }
+            resp, err := streamChainResponse(event)
+            if err != nil {
+                return err
+            }
+            if err := srv.Send(resp); err != nil {
+                return err
+            }
+        }
+    }
+}
+
+// StreamQuotes sends the full state of each requested contract whenever it
+// changes, starting with every requested contract's current state
+func (s *Server) StreamQuotes(req *optionsv1.StreamQuotesRequest, srv optionsv1.ChainService_StreamQuotesServer) error {
+    filter, err := contractFilter(req.GetFilter())
+    if err != nil {
+        return err
+    }
+    symbol, err := normalizeSymbol(req.GetSymbol())
+    if err != nil {
+        return err
+    }
+    wanted := make(map[string]bool, len(req.GetContracts()))
+    for _, contract := range req.GetContracts() {
+        wanted[contract] = true
+    }
+
+    listener := s.wsManager.Listen(symbol, filter, nil)
+    defer s.wsManager.Unlisten(listener)
+
+    // Rebuild the chain locally so every quote carries all fields
+    var contracts map[string]models.OptionData
+    var underlying float64
+    send := func(symbols []string, updated time.Time) error {
+        for _, contract := range symbols {
+            if len(wanted) > 0 && !wanted[contract] {
+                continue
+            }
+            option, ok := contracts[contract]
+            if !ok {
+                continue
+            }
+            err := srv.Send(&optionsv1.StreamQuotesResponse{
+                Quote:           option.Proto(),
+                UnderlyingPrice: underlying,
+                LastUpdated:     timestamppb.New(updated),
+            })
+            if err != nil {
+                return err
+            }
+        }
+        return nil
+    }
+
+    for {
+        select {
+        case <-srv.Context().Done():
+            return nil
+        case event, ok := <-listener.Events:
+            if !ok {
+                return status.Error(codes.ResourceExhausted, "client fell too far behind")
+            }
+            var changed []string
+            var updated time.Time
+            switch msg := event.(type) {
+            case stream.SnapshotMessage:
+                contracts = stream.IndexChain(msg.Chain)
+                underlying = msg.Chain.Underlying
+                updated = msg.Chain.Updated
+                for _, options := range [][]models.OptionData{msg.Chain.Calls, msg.Chain.Puts} {
+                    for _, option := range options {
+                        changed = append(changed, option.Symbol)
+                    }
+                }
+            case stream.DeltaMessage:
+                if contracts == nil {
+                    continue
+                }
+                stream.ApplyDelta(contracts, msg)
+                if msg.Underlying != nil {
+                    underlying = *msg.Underlying
+                }
+                updated = msg.Updated
+                for _, change := range msg.Changes {
+                    changed = append(changed, change.Symbol)
+                }
+            }
+            if err := send(changed, updated); err != nil {
+                return err
+            }
+        }
+    }
+}
+
+// chain looks up the stored chain for a symbol
+func (s *Server) chain(symbol string) (models.OptionChain, error) {
+    symbol, err := normalizeSymbol(symbol)
+    if err != nil {
+        return models.OptionChain{}, err
+    }
+    chain, ok := s.chains.Get(symbol)
+    if !ok {
+        return models.OptionChain{}, status.Errorf(codes.NotFound, "no chain for %s", symbol)
+    }
+    return chain, nil
+}
+
+// streamChainResponse converts a listener event to a stream message
+func streamChainResponse(event interface{}) (*optionsv1.StreamChainResponse, error) {
+    switch msg := event.(type) {
+    case stream.SnapshotMessage:
+        return &optionsv1.StreamChainResponse{
+            Message: &optionsv1.StreamChainResponse_Snapshot{Snapshot: &optionsv1.Snapshot{
+                Symbol: msg.Symbol,
+                Seq:    msg.Seq,
+                Chain:  msg.Chain.Proto(),
+            }},
+        }, nil
+    case stream.DeltaMessage:
+        delta, err := stream.ProtoDelta(msg)
+        if err != nil {
+            return nil, status.Errorf(codes.Internal, "encoding delta: %v", err)
+        }
+        return &optionsv1.StreamChainResponse{
+            Message: &optionsv1.StreamChainResponse_Delta{Delta: delta},
+        }, nil
+    default:
+        return nil, status.Errorf(codes.Internal, "unexpected event %T", event)
+    }
+}
+
+// contractFilter converts a protobuf filter to a stream filter
+func contractFilter(f *optionsv1.ContractFilter) (stream.ContractFilter, error) {
+    var filter stream.ContractFilter
+    if f == nil {
+        return filter, nil
+    }
+
+    switch optionType := strings.ToLower(f.GetType()); optionType {
+    case "", "call", "put":
+        filter.Type = optionType
+    default:
+        return filter, status.Errorf(codes.InvalidArgument, "invalid type: %q", f.GetType())
+    }
+    if len(f.GetExpirations()) > 0 {
+        filter.Expirations = make(map[string]bool)
+        for _, expiration := range f.GetExpirations() {
+            if _, err := time.Parse("2006-01-02", expiration); err != nil {
+                return filter, status.Errorf(codes.InvalidArgument, "invalid expiration: %q", expiration)
+            }
+            filter.Expirations[expiration] = true
+        }
+    }
+    filter.MinStrike = f.GetMinStrike()
+    filter.MaxStrike = f.GetMaxStrike()
+    return filter, nil
+}
+
+// normalizeSymbol upper-cases a requested symbol and rejects empty ones
+func normalizeSymbol(symbol string) (string, error) {
+    symbol = strings.ToUpper(strings.TrimSpace(symbol))
+    if symbol == "" {
+        return "", status.Error(codes.InvalidArgument, "symbol is required")
+    }
+    return symbol, nil
+}
+
+// daysToExpiration counts calendar days from now until an expiration date
+func daysToExpiration(expiration string, now time.Time) int32 {
+    date, err := time.Parse("2006-01-02", expiration)
+    if err != nil {
+        return 0
+    }
+    today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+    return int32(date.Sub(today).Hours() / 24)
+}
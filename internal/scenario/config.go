@@ -0,0 +1,39 @@
+package scenario
+
+import (
+    "fmt"
+    "os"
+    "time"
+)
+
+// Config holds the scenario engine settings
+type Config struct {
+    PresetsFile string        // JSON file of named shocks added to the built-in presets, if set
+    Timeout     time.Duration // how long a request may spend repricing
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        PresetsFile: os.Getenv("SCENARIO_PRESETS_FILE"),
+        Timeout:     getDurationOrDefault("SCENARIO_TIMEOUT", 5*time.Second),
+    }
+
+    if config.Timeout <= 0 {
+        return nil, fmt.Errorf("invalid scenario timeout: %v", config.Timeout)
+    }
+
+    return config, nil
+}
+
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    duration, err := time.ParseDuration(str)
+    if err != nil {
+        return defaultValue
+    }
+    return duration
+}
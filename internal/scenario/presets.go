@@ -0,0 +1,85 @@
+package scenario
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "sort"
+)
+
+// Shock moves the market: the underlying by a fraction of its price,
+// implied vols by a number of vol points (0.05 is 5 vols) and the clock
+// forward by a number of days
+type Shock struct {
+    Spot float64 `json:"spot"`
+    Vol  float64 `json:"vol"`
+    Days float64 `json:"days"`
+}
+
+// validate checks that a shock leaves a positive price and a forward
+// clock
+func (s Shock) validate() error {
+    if s.Spot <= -1 {
+        return fmt.Errorf("spot shock %v leaves no price", s.Spot)
+    }
+    if s.Days < 0 {
+        return fmt.Errorf("days %v are in the past", s.Days)
+    }
+    return nil
+}
+
+// Preset is a named stress
+type Preset struct {
+    Name string `json:"name"`
+    Shock
+}
+
+// builtinPresets are the stresses available without a presets file
+var builtinPresets = map[string]Shock{
+    "black-monday": {Spot: -0.20, Vol: 0.25},
+    "crash":        {Spot: -0.10, Vol: 0.10},
+    "correction":   {Spot: -0.05, Vol: 0.05},
+    "rally":        {Spot: 0.05, Vol: -0.03},
+    "melt-up":      {Spot: 0.10, Vol: 0.02},
+    "vol-spike":    {Vol: 0.10},
+    "vol-crush":    {Vol: -0.05, Days: 1}, // the day after an event
+    "weekend":      {Days: 3},
+}
+
+// loadPresets returns the built-in presets with those of the presets file,
+// if set, added over them
+func loadPresets(file string) (map[string]Shock, error) {
+    presets := make(map[string]Shock, len(builtinPresets))
+    for name, shock := range builtinPresets {
+        presets[name] = shock
+    }
+    if file == "" {
+        return presets, nil
+    }
+
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return nil, fmt.Errorf("reading scenario presets: %w", err)
+    }
+    var custom map[string]Shock
+    if err := json.Unmarshal(data, &custom); err != nil {
+        return nil, fmt.Errorf("parsing scenario presets: %w", err)
+    }
+    for name, shock := range custom {
+        if err := shock.validate(); err != nil {
+            return nil, fmt.Errorf("scenario preset %q: %w", name, err)
+        }
+        presets[name] = shock
+    }
+    return presets, nil
+}
+
+// sortedPresets lists presets by name
+func sortedPresets(presets map[string]Shock) []Preset {
+    list := make([]Preset, 0, len(presets))
+    for name, shock := range presets {
+        list = append(list, Preset{Name: name, Shock: shock})
+    }
+    sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+    return list
+}
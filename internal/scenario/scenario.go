@@ -0,0 +1,261 @@
+package scenario
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "strings"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
+)
+
+const (
+    // minVol floors shocked implied vols
+    minVol = 0.01
+
+    // maxCells caps the size of a scenario grid
+    maxCells = 10000
+
+    // maxValuations caps the option legs priced by a request, over every
+    // position, grid cell and preset
+    maxValuations = 100000
+)
+
+// defaultSpot is the grid of spot shocks used when a request has none
+var defaultSpot = []float64{-0.10, -0.05, 0, 0.05, 0.10}
+
+// Request asks for positions to be repriced on a grid of shocks and under
+// named stresses. Spot, Vol and Days are the grid axes and default to
+// ±10% in 5% steps, no vol change and today.
+type Request struct {
+    Positions []strategy.Position `json:"positions"`
+    Spot      []float64           `json:"spot,omitempty"`    // underlying moves as fractions of its price
+    Vol       []float64           `json:"vol,omitempty"`     // implied vol shifts, 0.05 for 5 vols
+    Days      []float64           `json:"days,omitempty"`    // days from now
+    Presets   []string            `json:"presets,omitempty"` // named stresses to run as well
+}
+
+// Position is a position resolved against its chain with its P&L over the
+// grid, indexed by days, vol and spot shock
+type Position struct {
+    Underlying      string              `json:"underlying"`
+    UnderlyingPrice float64             `json:"underlyingPrice"`
+    Legs            []strategy.LegQuote `json:"legs"`
+    Value           float64             `json:"value"` // model value now, dollars
+    PL              [][][]float64       `json:"pl"`
+}
+
+// Stress is the P&L of a named preset, in total and by position
+type Stress struct {
+    Preset
+    PL        float64   `json:"pl"`
+    Positions []float64 `json:"positions"`
+}
+
+// Result holds the P&L matrices of a scenario request. P&L is in dollars
+// against each position's model value now, so the zero shock is zero
+// however far the model sits from the mid.
+type Result struct {
+    Updated   time.Time     `json:"lastUpdated"`
+    Spot      []float64     `json:"spot"`
+    Vol       []float64     `json:"vol"`
+    Days      []float64     `json:"days"`
+    Value     float64       `json:"value"`
+    PL        [][][]float64 `json:"pl"` // total, indexed by days, vol and spot shock
+    Positions []Position    `json:"positions"`
+    Stresses  []Stress      `json:"stresses,omitempty"`
+}
+
+// Engine reprices positions under market shocks
+type Engine struct {
+    chains  *store.ChainStore
+    valuer  strategy.Valuer
+    presets map[string]Shock
+    timeout time.Duration
+    now     func() time.Time
+}
+
+// NewEngine creates a scenario engine that values legs with the given
+// valuer, normally the pricing engine, loading the presets file if one is
+// configured
+func NewEngine(chains *store.ChainStore, valuer strategy.Valuer, config Config) (*Engine, error) {
+    presets, err := loadPresets(config.PresetsFile)
+    if err != nil {
+        return nil, err
+    }
+    return &Engine{
+        chains:  chains,
+        valuer:  valuer,
+        presets: presets,
+        timeout: config.Timeout,
+        now:     time.Now,
+    }, nil
+}
+
+// Presets lists the named stresses by name
+func (e *Engine) Presets() []Preset {
+    return sortedPresets(e.presets)
+}
+
+// Run resolves the positions of a request against their chains and
+// reprices them on the grid and under each requested preset. Repricing
+// stops with the context's error once the context is done or the
+// configured timeout passes.
+func (e *Engine) Run(ctx context.Context, req Request) (Result, error) {
+    if len(req.Positions) == 0 {
+        return Result{}, fmt.Errorf("no positions")
+    }
+    if len(req.Spot) == 0 {
+        req.Spot = defaultSpot
+    }
+    if len(req.Vol) == 0 {
+        req.Vol = []float64{0}
+    }
+    if len(req.Days) == 0 {
+        req.Days = []float64{0}
+    }
+    if cells := len(req.Spot) * len(req.Vol) * len(req.Days); cells > maxCells {
+        return Result{}, fmt.Errorf("grid of %d scenarios exceeds %d", cells, maxCells)
+    }
+    for _, spot := range req.Spot {
+        if err := (Shock{Spot: spot}).validate(); err != nil {
+            return Result{}, err
+        }
+    }
+    for _, days := range req.Days {
+        if err := (Shock{Days: days}).validate(); err != nil {
+            return Result{}, err
+        }
+    }
+    stresses := make([]Stress, len(req.Presets))
+    for i, name := range req.Presets {
+        shock, ok := e.presets[name]
+        if !ok {
+            return Result{}, fmt.Errorf("unknown preset %q", name)
+        }
+        stresses[i] = Stress{Preset: Preset{Name: name, Shock: shock}}
+    }
+    var options int
+    for _, p := range req.Positions {
+        for _, leg := range p.Legs {
+            if leg.Contract != "" {
+                options++
+            }
+        }
+    }
+    scenarios := len(req.Spot)*len(req.Vol)*len(req.Days) + len(stresses) + 1
+    if valuations := options * scenarios; valuations > maxValuations {
+        return Result{}, fmt.Errorf("%d option legs over %d scenarios exceed %d valuations", options, scenarios, maxValuations)
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, e.timeout)
+    defer cancel()
+
+    now := e.now()
+    result := Result{
+        Updated: now,
+        Spot:    req.Spot,
+        Vol:     req.Vol,
+        Days:    req.Days,
+        PL:      grid(req, func(Shock) float64 { return 0 }),
+    }
+    for _, p := range req.Positions {
+        p.Underlying = strings.ToUpper(p.Underlying)
+        if err := p.Validate(); err != nil {
+            return Result{}, err
+        }
+        chain, ok := e.chains.Get(p.Underlying)
+        if !ok {
+            return Result{}, fmt.Errorf("%w %s", strategy.ErrNoChain, p.Underlying)
+        }
+        if chain.Underlying <= 0 {
+            return Result{}, fmt.Errorf("no underlying price for %s", p.Underlying)
+        }
+        legs, err := p.Resolve(chain)
+        if err != nil {
+            return Result{}, err
+        }
+
+        value := func(s Shock) float64 {
+            if ctx.Err() != nil {
+                return 0
+            }
+            return e.value(p.Underlying, legs, chain.Underlying, s, now)
+        }
+        base := value(Shock{})
+        position := Position{
+            Underlying:      p.Underlying,
+            UnderlyingPrice: chain.Underlying,
+            Legs:            legs,
+            Value:           base,
+            PL:              grid(req, func(s Shock) float64 { return value(s) - base }),
+        }
+        add(result.PL, position.PL)
+        result.Value += base
+        result.Positions = append(result.Positions, position)
+
+        for i := range stresses {
+            pl := value(stresses[i].Shock) - base
+            stresses[i].PL += pl
+            stresses[i].Positions = append(stresses[i].Positions, pl)
+        }
+        if err := ctx.Err(); err != nil {
+            return Result{}, fmt.Errorf("repricing positions: %w", err)
+        }
+    }
+    result.Stresses = stresses
+    return result, nil
+}
+
+// value prices resolved legs under a shock. Stock moves with the spot;
+// options are repriced at the shocked spot, vol and time.
+func (e *Engine) value(symbol string, legs []strategy.LegQuote, spot float64, s Shock, now time.Time) float64 {
+    price := spot * (1 + s.Spot)
+    at := now.Add(time.Duration(s.Days * 24 * float64(time.Hour)))
+    var total float64
+    for _, leg := range legs {
+        if leg.Option == nil {
+            total += leg.Size() * price
+            continue
+        }
+        option := *leg.Option
+        vol := option.ImpliedVol
+        if vol <= 0 {
+            vol = option.MidIV
+        }
+        if vol > 0 {
+            option.ImpliedVol = math.Max(vol+s.Vol, minVol)
+        }
+        total += leg.Size() * e.valuer.Value(symbol, option, price, at)
+    }
+    return total
+}
+
+// grid evaluates a function over the request's shocks, indexed by days,
+// vol and spot
+func grid(req Request, f func(Shock) float64) [][][]float64 {
+    out := make([][][]float64, len(req.Days))
+    for i, days := range req.Days {
+        out[i] = make([][]float64, len(req.Vol))
+        for j, vol := range req.Vol {
+            out[i][j] = make([]float64, len(req.Spot))
+            for k, spot := range req.Spot {
+                out[i][j][k] = f(Shock{Spot: spot, Vol: vol, Days: days})
+            }
+        }
+    }
+    return out
+}
+
+// add sums a grid into another of the same shape
+func add(total, g [][][]float64) {
+    for i := range g {
+        for j := range g[i] {
+            for k := range g[i][j] {
+                total[i][j][k] += g[i][j][k]
+            }
+        }
+    }
+}
@@ -0,0 +1,105 @@
+package scenario
+
+import (
+    "context"
+    "errors"
+    "math"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+    "github.com/ryanhamamura/options-chain-go/internal/strategy"
+)
+
+// intrinsicValuer values contracts at their intrinsic value and counts
+// the valuations
+type intrinsicValuer struct {
+    calls int
+}
+
+func (v *intrinsicValuer) Value(symbol string, option models.OptionData, spot float64, at time.Time) float64 {
+    v.calls++
+    if option.Type == "call" {
+        return math.Max(spot-option.Strike, 0)
+    }
+    return math.Max(option.Strike-spot, 0)
+}
+
+func (v *intrinsicValuer) Distribution(symbol string, spot, vol float64, expiration string, now time.Time) analytics.Lognormal {
+    return analytics.Lognormal{Spot: spot}
+}
+
+func testEngine(valuer strategy.Valuer) *Engine {
+    chains := store.NewChainStore()
+    chains.Put(models.OptionChain{
+        Symbol:     "SPY",
+        Underlying: 100,
+        Calls: []models.OptionData{
+            {Symbol: ".SPY261218C95", Strike: 95, Type: "call", Expiration: "2026-12-18", ImpliedVol: 0.2},
+        },
+    })
+    return &Engine{chains: chains, valuer: valuer, timeout: time.Second, now: time.Now}
+}
+
+func TestRun(t *testing.T) {
+    e := testEngine(&intrinsicValuer{})
+    result, err := e.Run(context.Background(), Request{
+        Positions: []strategy.Position{{
+            Underlying: "spy",
+            Legs:       []models.Leg{{Contract: ".SPY261218C95", Quantity: 1}, {Quantity: -100}},
+        }},
+        Spot: []float64{-0.10, 0, 0.10},
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // A long call against short stock gains below the strike only
+    want := []float64{500, 0, 0}
+    for i, pl := range result.PL[0][0] {
+        if math.Abs(pl-want[i]) > 1e-9 {
+            t.Errorf("P&L at %+.0f%% = %v, want %v", result.Spot[i]*100, pl, want[i])
+        }
+    }
+}
+
+func TestRunCapsValuations(t *testing.T) {
+    valuer := &intrinsicValuer{}
+    legs := make([]models.Leg, 100)
+    for i := range legs {
+        legs[i] = models.Leg{Contract: ".SPY261218C95", Quantity: 1}
+    }
+    axis := make([]float64, 10)
+
+    _, err := testEngine(valuer).Run(context.Background(), Request{
+        Positions: []strategy.Position{{Underlying: "SPY", Legs: legs}},
+        Spot:      axis,
+        Vol:       axis,
+        Days:      axis,
+    })
+    if err == nil || !strings.Contains(err.Error(), "valuations") {
+        t.Fatalf("err = %v, want the valuation cap", err)
+    }
+    if valuer.calls != 0 {
+        t.Errorf("valued %d legs before rejecting the request", valuer.calls)
+    }
+}
+
+func TestRunStopsWithContext(t *testing.T) {
+    valuer := &intrinsicValuer{}
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    _, err := testEngine(valuer).Run(ctx, Request{
+        Positions: []strategy.Position{{Underlying: "SPY", Legs: []models.Leg{{Contract: ".SPY261218C95", Quantity: 1}}}},
+    })
+    if !errors.Is(err, context.Canceled) {
+        t.Fatalf("err = %v, want context.Canceled", err)
+    }
+    if valuer.calls != 0 {
+        t.Errorf("valued %d legs after the context was done", valuer.calls)
+    }
+}
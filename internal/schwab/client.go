@@ -9,6 +9,8 @@ import (
     "time"
 
     "github.com/gorilla/websocket"
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
 )
 
 // Client interfaces with the Schwab API
@@ -18,6 +20,7 @@ type Client struct {
     httpClient  *http.Client
     creds       Credentials
     rateLimiter *RateLimiter
+    engine      *analytics.Engine
 }
 
 // NewClient creates a new Schwab API client
@@ -33,6 +36,25 @@ func NewClient(baseURL string, wsURL string, creds Credentials) *Client {
     }
 }
 
+// SetPricingEngine sets the engine OptionChain solves implied
+// volatilities and fills in Greeks with
+func (c *Client) SetPricingEngine(engine *analytics.Engine) {
+    c.engine = engine
+}
+
+// OptionChain fetches the options chain for a symbol and converts it to our
+// internal model, priced with the pricing engine if one is set
+func (c *Client) OptionChain(ctx context.Context, symbol string) (models.OptionChain, error) {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return models.OptionChain{}, err
+    }
+    resp, err := c.GetOptionsChain(ctx, symbol)
+    if err != nil {
+        return models.OptionChain{}, err
+    }
+    return convertToOptionChain(resp, c.engine), nil
+}
+
 // GetOptionsChain fetches the full options chain for a symbol
 func (c *Client) GetOptionsChain(ctx context.Context, symbol string) (*OptionsChainResponse, error) {
     endpoint := fmt.Sprintf("%s/v1/markets/options/%s", c.baseURL, url.PathEscape(symbol))
@@ -112,6 +134,7 @@ func (c *Client) addAuthHeaders(req *http.Request) {
 // Helper method to convert Schwab's option quote to our internal model
 func convertToOptionData(quote OptionQuote) models.OptionData {
     return models.OptionData{
+        Symbol:     quote.ContractID,
         Strike:     quote.Strike,
         Expiration: quote.Expiration.Format("2006-01-02"),
         Type:       quote.Type,
@@ -124,6 +147,30 @@ func convertToOptionData(quote OptionQuote) models.OptionData {
         Gamma:      quote.Greeks.Gamma,
         Theta:      quote.Greeks.Theta,
         Vega:       quote.Greeks.Vega,
-        ImpliedVol: 0, // You might need to calculate this or get it from the API
+        Rho:        quote.Greeks.Rho,
+        ImpliedVol: 0, // Solved from the quote by the pricing engine
+    }
+}
+
+// convertToOptionChain converts a Schwab chain response to our internal
+// model, solving implied volatilities the API does not provide with the
+// pricing engine, if set, so they are consistent with other brokers
+func convertToOptionChain(resp *OptionsChainResponse, engine *analytics.Engine) models.OptionChain {
+    chain := models.OptionChain{
+        Symbol:     resp.Symbol,
+        Underlying: resp.UnderlyingPrice,
+        Updated:    resp.Timestamp,
+    }
+    for _, quote := range resp.Contracts {
+        option := convertToOptionData(quote)
+        if option.Type == "call" {
+            chain.Calls = append(chain.Calls, option)
+        } else {
+            chain.Puts = append(chain.Puts, option)
+        }
+    }
+    if engine != nil {
+        engine.Enrich(&chain)
     }
+    return chain
 }
@@ -0,0 +1,63 @@
+package schwab
+
+import (
+    "context"
+    "encoding/json"
+    "math"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+)
+
+// flatMarket prices with a flat rate and no dividends
+type flatMarket struct{}
+
+func (flatMarket) RiskFreeRate(float64) float64 { return 0.05 }
+func (flatMarket) CashDividends(string, time.Time) (float64, []analytics.CashDividend) {
+    return 0, nil
+}
+
+func TestOptionChainSolvesImpliedVols(t *testing.T) {
+    expiration := time.Now().AddDate(0, 3, 0).Truncate(24 * time.Hour)
+    engine := analytics.NewEngine(analytics.Config{Model: analytics.ModelEuropean}, flatMarket{})
+
+    // Quote the call at its Black-Scholes value for a 25% vol
+    years, err := analytics.YearsToExpiration(expiration.Format("2006-01-02"), time.Now().Truncate(time.Minute))
+    if err != nil {
+        t.Fatal(err)
+    }
+    price := analytics.BSMPrice(analytics.Params{Spot: 100, Strike: 100, T: years, Rate: 0.05, Vol: 0.25, Call: true})
+
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(OptionsChainResponse{
+            Symbol:          "XYZ",
+            UnderlyingPrice: 100,
+            Timestamp:       time.Now(),
+            Contracts: []OptionQuote{
+                {ContractID: ".XYZC100", Strike: 100, Expiration: expiration, Type: "call", Bid: price - 0.05, Ask: price + 0.05},
+                {ContractID: ".XYZP100", Strike: 100, Expiration: expiration, Type: "put", Bid: 4, Ask: 4.2},
+            },
+        })
+    }))
+    defer server.Close()
+
+    client := NewClient(server.URL, "", Credentials{})
+    client.SetPricingEngine(engine)
+    chain, err := client.OptionChain(context.Background(), "XYZ")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(chain.Calls) != 1 || len(chain.Puts) != 1 {
+        t.Fatalf("got %d calls and %d puts, want 1 of each", len(chain.Calls), len(chain.Puts))
+    }
+    call := chain.Calls[0]
+    if math.Abs(call.ImpliedVol-0.25) > 1e-3 {
+        t.Errorf("call implied vol = %v, want 0.25", call.ImpliedVol)
+    }
+    if call.Delta == 0 || chain.Puts[0].ImpliedVol == 0 {
+        t.Errorf("Greeks and IVs not filled in: call %+v, put %+v", call, chain.Puts[0])
+    }
+}
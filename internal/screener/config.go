@@ -0,0 +1,37 @@
+package screener
+
+import (
+    "fmt"
+    "os"
+    "time"
+)
+
+// Config holds the saved screen settings
+type Config struct {
+    Interval time.Duration // how often saved screens are rerun
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        Interval: getDurationOrDefault("SCREEN_INTERVAL", 5*time.Second),
+    }
+
+    if config.Interval <= 0 {
+        return nil, fmt.Errorf("invalid screen interval: %v", config.Interval)
+    }
+
+    return config, nil
+}
+
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    duration, err := time.ParseDuration(str)
+    if err != nil {
+        return defaultValue
+    }
+    return duration
+}
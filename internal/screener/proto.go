@@ -0,0 +1,44 @@
+package screener
+
+import (
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Message carries the matches of a saved screen, named by Symbol, to
+// stream clients
+type Message struct {
+    Type   string `json:"type"` // "screen"
+    Symbol string `json:"symbol"`
+    Screen Update `json:"screen"`
+}
+
+// NewMessage wraps a screen update for streaming
+func NewMessage(u Update) Message {
+    return Message{Type: "screen", Symbol: u.Name, Screen: u}
+}
+
+// ServerMessage returns the message in its protobuf form
+func (m Message) ServerMessage() *optionsv1.ServerMessage {
+    return &optionsv1.ServerMessage{Message: &optionsv1.ServerMessage_Screen{Screen: m.Screen.Proto()}}
+}
+
+// Proto converts the matches of a saved screen to their protobuf form
+func (u Update) Proto() *optionsv1.ScreenUpdate {
+    matches := make([]*optionsv1.ScreenMatch, len(u.Matches))
+    for i, m := range u.Matches {
+        matches[i] = &optionsv1.ScreenMatch{
+            Underlying:      m.Underlying,
+            UnderlyingPrice: m.UnderlyingPrice,
+            Option:          m.Option.Proto(),
+            Values:          m.Values,
+        }
+    }
+    return &optionsv1.ScreenUpdate{
+        Name:        u.Name,
+        LastUpdated: timestamppb.New(u.Updated),
+        Matches:     matches,
+        Added:       u.Added,
+        Removed:     u.Removed,
+    }
+}
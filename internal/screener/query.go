@@ -0,0 +1,171 @@
+package screener
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "strings"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+const (
+    defaultLimit = 100
+    maxLimit     = 1000
+)
+
+// Query selects and ranks contracts across the stored chains. Filters are
+// keyed by field name and keep contracts whose value lies in the range;
+// a contract without a value for a filtered field never matches. Sort
+// ranks by the listed fields in turn, and contracts without a value sort
+// last. For example:
+//
+//    {
+//      "type": "put",
+//      "filters": {"dte": {"min": 20, "max": 45}, "absDelta": {"max": 0.3}},
+//      "sort": [{"field": "premiumYield", "desc": true}],
+//      "limit": 20
+//    }
+type Query struct {
+    Symbols []string         `json:"symbols,omitempty"` // underlyings to scan; every stored chain if empty
+    Type    string           `json:"type,omitempty"`    // "call", "put" or empty for both
+    Filters map[string]Range `json:"filters,omitempty"`
+    Sort    []SortKey        `json:"sort,omitempty"`
+    Limit   int              `json:"limit,omitempty"` // 0 selects the default of 100
+}
+
+// Range bounds a field, inclusively; either end may be left open
+type Range struct {
+    Min *float64 `json:"min,omitempty"`
+    Max *float64 `json:"max,omitempty"`
+}
+
+// contains reports whether a value lies in the range
+func (r Range) contains(v float64) bool {
+    return (r.Min == nil || v >= *r.Min) && (r.Max == nil || v <= *r.Max)
+}
+
+// SortKey ranks contracts by a field, ascending unless Desc is set
+type SortKey struct {
+    Field string `json:"field"`
+    Desc  bool   `json:"desc,omitempty"`
+}
+
+// contract is a candidate with the context its fields are computed from
+type contract struct {
+    underlying   float64
+    option       *models.OptionData
+    dte          float64
+    ivRank       float64
+    ivPercentile float64
+    hasIVRank    bool
+}
+
+// mid returns the midpoint of the quote
+func (c contract) mid() float64 {
+    return (c.option.Bid + c.option.Ask) / 2
+}
+
+// fieldFunc computes a value of a contract, reporting false if it has none
+type fieldFunc func(c contract) (float64, bool)
+
+// fields are the names a query can filter and sort on
+var fields = map[string]fieldFunc{
+    "dte":    func(c contract) (float64, bool) { return c.dte, true },
+    "strike": func(c contract) (float64, bool) { return c.option.Strike, true },
+    "bid":    func(c contract) (float64, bool) { return c.option.Bid, true },
+    "ask":    func(c contract) (float64, bool) { return c.option.Ask, true },
+    "mid": func(c contract) (float64, bool) {
+        return c.mid(), c.option.Ask > 0
+    },
+    "last": func(c contract) (float64, bool) { return c.option.LastPrice, c.option.LastPrice > 0 },
+    // Bid/ask spread as a percentage of the mid
+    "spreadPct": func(c contract) (float64, bool) {
+        if c.option.Ask <= 0 || c.option.Ask < c.option.Bid {
+            return 0, false
+        }
+        return (c.option.Ask - c.option.Bid) / c.mid() * 100, true
+    },
+    "delta":    func(c contract) (float64, bool) { return c.option.Delta, true },
+    "absDelta": func(c contract) (float64, bool) { return math.Abs(c.option.Delta), true },
+    "gamma":    func(c contract) (float64, bool) { return c.option.Gamma, true },
+    "theta":    func(c contract) (float64, bool) { return c.option.Theta, true },
+    "vega":     func(c contract) (float64, bool) { return c.option.Vega, true },
+    "iv":       func(c contract) (float64, bool) { return c.option.ImpliedVol, c.option.ImpliedVol > 0 },
+    "fittedIv": func(c contract) (float64, bool) { return c.option.FittedIV, c.option.FittedIV > 0 },
+    // Underlying's ATM 30-day IV against its history, 0 to 1
+    "ivRank":       func(c contract) (float64, bool) { return c.ivRank, c.hasIVRank },
+    "ivPercentile": func(c contract) (float64, bool) { return c.ivPercentile, c.hasIVRank },
+    "openInterest": func(c contract) (float64, bool) { return float64(c.option.OpenInt), true },
+    "volume":       func(c contract) (float64, bool) { return float64(c.option.Volume), true },
+    // Mid as an annualised fraction of the capital a seller ties up: the
+    // strike for a cash-secured put, the underlying for a covered call
+    "premiumYield": func(c contract) (float64, bool) {
+        collateral := c.option.Strike
+        if c.option.Type == "call" {
+            collateral = c.underlying
+        }
+        if c.option.Ask <= 0 || collateral <= 0 || c.dte <= 0 {
+            return 0, false
+        }
+        return c.mid() / collateral * 365 / c.dte, true
+    },
+    // Strike over the underlying price
+    "moneyness": func(c contract) (float64, bool) {
+        return c.option.Strike / c.underlying, c.underlying > 0
+    },
+    "pitm":      func(c contract) (float64, bool) { return c.option.PITM, c.option.PITM > 0 },
+    "probTouch": func(c contract) (float64, bool) { return c.option.PTouch, c.option.PTouch > 0 },
+    "pop":       func(c contract) (float64, bool) { return c.option.POP, c.option.POP > 0 },
+}
+
+// Validate checks the query and normalises its symbols, type and limit
+func (q *Query) Validate() error {
+    for i, symbol := range q.Symbols {
+        q.Symbols[i] = strings.ToUpper(strings.TrimSpace(symbol))
+    }
+    q.Type = strings.ToLower(q.Type)
+    if q.Type != "" && q.Type != "call" && q.Type != "put" {
+        return fmt.Errorf("invalid type %q: must be call or put", q.Type)
+    }
+    for name, r := range q.Filters {
+        if _, ok := fields[name]; !ok {
+            return fmt.Errorf("unknown filter field %q", name)
+        }
+        if r.Min == nil && r.Max == nil {
+            return fmt.Errorf("filter %q needs a min or max", name)
+        }
+        if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+            return fmt.Errorf("filter %q has min above max", name)
+        }
+    }
+    for _, key := range q.Sort {
+        if _, ok := fields[key.Field]; !ok {
+            return fmt.Errorf("unknown sort field %q", key.Field)
+        }
+    }
+    switch {
+    case q.Limit < 0 || q.Limit > maxLimit:
+        return fmt.Errorf("limit must be between 1 and %d", maxLimit)
+    case q.Limit == 0:
+        q.Limit = defaultLimit
+    }
+    return nil
+}
+
+// columns returns the fields a query filters or sorts on, in name order
+func (q Query) columns() []string {
+    seen := make(map[string]bool)
+    for name := range q.Filters {
+        seen[name] = true
+    }
+    for _, key := range q.Sort {
+        seen[key.Field] = true
+    }
+    names := make([]string, 0, len(seen))
+    for name := range seen {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
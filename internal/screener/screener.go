@@ -0,0 +1,158 @@
+package screener
+
+import (
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// IVRanker places an underlying's implied vol within its history
+type IVRanker interface {
+    IVRank(symbol string) (rank, percentile float64, ok bool)
+}
+
+// Match is a contract that passed a query, with the values of the fields
+// the query filters or sorts on
+type Match struct {
+    Underlying      string             `json:"underlying"`
+    UnderlyingPrice float64            `json:"underlyingPrice"`
+    Option          models.OptionData  `json:"option"`
+    Values          map[string]float64 `json:"values"`
+}
+
+// Result holds the ranked matches of a query
+type Result struct {
+    Updated time.Time `json:"lastUpdated"`
+    Scanned int       `json:"scanned"` // unexpired contracts considered
+    Matched int       `json:"matched"` // matches before the limit
+    Matches []Match   `json:"matches"`
+}
+
+// Screener runs queries over the aggregated chains
+type Screener struct {
+    chains  *store.ChainStore
+    ivRanks IVRanker
+    now     func() time.Time
+}
+
+// NewScreener creates a screener over the chain store. ivRanks may be nil,
+// in which case no contract has an IV rank.
+func NewScreener(chains *store.ChainStore, ivRanks IVRanker) *Screener {
+    return &Screener{
+        chains:  chains,
+        ivRanks: ivRanks,
+        now:     time.Now,
+    }
+}
+
+// Screen validates a query and runs it
+func (s *Screener) Screen(q Query) (Result, error) {
+    if err := q.Validate(); err != nil {
+        return Result{}, err
+    }
+    now := s.now()
+    symbols := q.Symbols
+    if len(symbols) == 0 {
+        symbols = s.chains.Symbols()
+        sort.Strings(symbols)
+    }
+    columns := q.columns()
+
+    result := Result{Updated: now, Matches: []Match{}}
+    for _, symbol := range symbols {
+        chain, ok := s.chains.Get(symbol)
+        if !ok {
+            continue
+        }
+        base := contract{underlying: chain.Underlying}
+        if s.ivRanks != nil {
+            base.ivRank, base.ivPercentile, base.hasIVRank = s.ivRanks.IVRank(symbol)
+        }
+
+        var options []models.OptionData
+        if q.Type != "put" {
+            options = append(options, chain.Calls...)
+        }
+        if q.Type != "call" {
+            options = append(options, chain.Puts...)
+        }
+        for i := range options {
+            t, err := analytics.YearsToExpiration(options[i].Expiration, now)
+            if err != nil || t <= 0 {
+                continue
+            }
+            result.Scanned++
+            c := base
+            c.option = &options[i]
+            c.dte = t * 365
+            if !q.matches(c) {
+                continue
+            }
+            match := Match{
+                Underlying:      symbol,
+                UnderlyingPrice: chain.Underlying,
+                Option:          options[i],
+                Values:          make(map[string]float64, len(columns)),
+            }
+            for _, name := range columns {
+                if v, ok := fields[name](c); ok {
+                    match.Values[name] = v
+                }
+            }
+            result.Matches = append(result.Matches, match)
+        }
+    }
+
+    q.rank(result.Matches)
+    result.Matched = len(result.Matches)
+    if len(result.Matches) > q.Limit {
+        result.Matches = result.Matches[:q.Limit]
+    }
+    return result, nil
+}
+
+// matches reports whether a contract passes every filter
+func (q Query) matches(c contract) bool {
+    for name, r := range q.Filters {
+        v, ok := fields[name](c)
+        if !ok || !r.contains(v) {
+            return false
+        }
+    }
+    return true
+}
+
+// rank sorts matches by the sort keys, falling back to underlying,
+// expiration, strike and type
+func (q Query) rank(matches []Match) {
+    sort.SliceStable(matches, func(i, j int) bool {
+        a, b := matches[i], matches[j]
+        for _, key := range q.Sort {
+            va, oka := a.Values[key.Field]
+            vb, okb := b.Values[key.Field]
+            switch {
+            case oka != okb:
+                return oka
+            case !oka || va == vb:
+                continue
+            case key.Desc:
+                return va > vb
+            default:
+                return va < vb
+            }
+        }
+        if a.Underlying != b.Underlying {
+            return a.Underlying < b.Underlying
+        }
+        if a.Option.Expiration != b.Option.Expiration {
+            return a.Option.Expiration < b.Option.Expiration
+        }
+        if a.Option.Strike != b.Option.Strike {
+            return a.Option.Strike < b.Option.Strike
+        }
+        return a.Option.Type < b.Option.Type
+    })
+}
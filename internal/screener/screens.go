@@ -0,0 +1,181 @@
+package screener
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Screen is a named query kept by the server
+type Screen struct {
+    Name  string `json:"name"`
+    Query Query  `json:"query"`
+}
+
+// Update carries the matches of a saved screen whenever they change, with
+// the contracts that entered and left it since the previous update
+type Update struct {
+    Name    string    `json:"name"`
+    Updated time.Time `json:"lastUpdated"`
+    Matches []Match   `json:"matches"`
+    Added   []string  `json:"added,omitempty"`
+    Removed []string  `json:"removed,omitempty"`
+}
+
+// saved is a screen and the contracts it last matched
+type saved struct {
+    query   Query
+    matched map[string]bool
+}
+
+// Screens reruns saved screens at a fixed cadence and publishes the ones
+// whose matches changed
+type Screens struct {
+    screener *Screener
+    interval time.Duration
+    publish  func(Update)
+
+    mu      sync.Mutex
+    screens map[string]*saved
+}
+
+// NewScreens creates the saved screens over a screener. Screen names are
+// upper-cased, like the symbols clients subscribe to.
+func NewScreens(screener *Screener, interval time.Duration, publish func(Update)) *Screens {
+    return &Screens{
+        screener: screener,
+        interval: interval,
+        publish:  publish,
+        screens:  make(map[string]*saved),
+    }
+}
+
+// Save validates a screen, replacing any of the same name, and publishes
+// its first matches
+func (s *Screens) Save(screen Screen) (Screen, error) {
+    screen.Name = strings.ToUpper(strings.TrimSpace(screen.Name))
+    if screen.Name == "" {
+        return Screen{}, fmt.Errorf("screen name is required")
+    }
+    if err := screen.Query.Validate(); err != nil {
+        return Screen{}, err
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    sv := &saved{query: screen.Query}
+    s.screens[screen.Name] = sv
+    if err := s.run(screen.Name, sv); err != nil {
+        return Screen{}, err
+    }
+    return screen, nil
+}
+
+// Delete removes a saved screen, publishing an empty update to its
+// subscribers. It reports false if there was no such screen.
+func (s *Screens) Delete(name string) bool {
+    name = strings.ToUpper(name)
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    sv, ok := s.screens[name]
+    if !ok {
+        return false
+    }
+    delete(s.screens, name)
+    s.publish(Update{
+        Name:    name,
+        Updated: s.screener.now(),
+        Matches: []Match{},
+        Removed: sortedKeys(sv.matched),
+    })
+    return true
+}
+
+// List returns the saved screens by name
+func (s *Screens) List() []Screen {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    screens := make([]Screen, 0, len(s.screens))
+    for name, sv := range s.screens {
+        screens = append(screens, Screen{Name: name, Query: sv.query})
+    }
+    sort.Slice(screens, func(i, j int) bool { return screens[i].Name < screens[j].Name })
+    return screens
+}
+
+// Run reruns the saved screens until the context is cancelled
+func (s *Screens) Run(ctx context.Context) {
+    ticker := time.NewTicker(s.interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            s.mu.Lock()
+            for name, sv := range s.screens {
+                if err := s.run(name, sv); err != nil {
+                    log.Printf("Error running screen %s: %v", name, err)
+                }
+            }
+            s.mu.Unlock()
+        }
+    }
+}
+
+// run screens a saved query and publishes the result if its matches
+// changed. The caller must hold the lock.
+func (s *Screens) run(name string, sv *saved) error {
+    result, err := s.screener.Screen(sv.query)
+    if err != nil {
+        return err
+    }
+
+    matched := make(map[string]bool, len(result.Matches))
+    var added []string
+    for _, m := range result.Matches {
+        matched[m.Option.Symbol] = true
+        if !sv.matched[m.Option.Symbol] {
+            added = append(added, m.Option.Symbol)
+        }
+    }
+    var removed []string
+    for symbol := range sv.matched {
+        if !matched[symbol] {
+            removed = append(removed, symbol)
+        }
+    }
+    first := sv.matched == nil
+    sv.matched = matched
+    if !first && len(added) == 0 && len(removed) == 0 {
+        return nil
+    }
+
+    sort.Strings(added)
+    sort.Strings(removed)
+    s.publish(Update{
+        Name:    name,
+        Updated: result.Updated,
+        Matches: result.Matches,
+        Added:   added,
+        Removed: removed,
+    })
+    return nil
+}
+
+// sortedKeys returns the members of a set in order
+func sortedKeys(set map[string]bool) []string {
+    keys := make([]string, 0, len(set))
+    for key := range set {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys
+}
@@ -0,0 +1,50 @@
+package smile
+
+import "math"
+
+// checkPoints is the number of log-moneyness points arbitrage is checked at
+const checkPoints = 201
+
+// Check reports whether a fitted smile is free of static arbitrage
+type Check struct {
+    ButterflyFree bool    `json:"butterflyFree"`
+    MinDensity    float64 `json:"minDensity"` // minimum of Gatheral's g(k); negative means butterfly arbitrage
+    CalendarFree  bool    `json:"calendarFree"` // total variance at or above the previous expiration's
+}
+
+// density is Gatheral's g(k), proportional to the risk-neutral density
+// implied by a total variance smile. Derivatives are taken numerically.
+func density(w func(float64) float64, k float64) float64 {
+    const h = 1e-4
+    w0 := w(k)
+    if w0 <= 0 {
+        return math.Inf(-1)
+    }
+    up, down := w(k+h), w(k-h)
+    d1 := (up - down) / (2 * h)
+    d2 := (up - 2*w0 + down) / (h * h)
+    a := 1 - k*d1/(2*w0)
+    return a*a - d1*d1/4*(1/w0+0.25) + d2/2
+}
+
+// butterflyCheck returns the minimum of g(k) over [lo, hi]
+func butterflyCheck(w func(float64) float64, lo, hi float64) float64 {
+    minimum := math.Inf(1)
+    for i := 0; i < checkPoints; i++ {
+        k := lo + (hi-lo)*float64(i)/(checkPoints-1)
+        minimum = math.Min(minimum, density(w, k))
+    }
+    return minimum
+}
+
+// calendarFree reports whether total variance never decreases from the
+// earlier smile to the later one over [lo, hi]
+func calendarFree(earlier, later func(float64) float64, lo, hi float64) bool {
+    for i := 0; i < checkPoints; i++ {
+        k := lo + (hi-lo)*float64(i)/(checkPoints-1)
+        if later(k) < earlier(k)-1e-12 {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,48 @@
+package smile
+
+import (
+    "fmt"
+    "os"
+    "time"
+)
+
+// Config holds the smile fitting settings
+type Config struct {
+    Model    Model         // model whose fitted vols are reported on contracts
+    Interval time.Duration // how often the stored chains are refitted
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    model, err := ParseModel(getEnvOrDefault("SMILE_MODEL", string(ModelSVI)))
+    if err != nil {
+        return nil, err
+    }
+    config := &Config{
+        Model:    model,
+        Interval: getDurationOrDefault("SMILE_INTERVAL", 5*time.Second),
+    }
+    if config.Interval <= 0 {
+        return nil, fmt.Errorf("invalid smile interval: %v", config.Interval)
+    }
+    return config, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
+
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := time.ParseDuration(str)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
@@ -0,0 +1,287 @@
+package smile
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// Model selects the smile parameterisation used for fitted vols
+type Model string
+
+const (
+    ModelSVI  Model = "svi"  // raw SVI per expiration
+    ModelSSVI Model = "ssvi" // surface SVI across expirations
+)
+
+// ParseModel parses a smile model name
+func ParseModel(name string) (Model, error) {
+    switch Model(name) {
+    case ModelSVI, ModelSSVI:
+        return Model(name), nil
+    }
+    return "", fmt.Errorf("unknown smile model: %q", name)
+}
+
+// minSpreadVol floors the bid/ask spread in vol used to weight quotes, so
+// that locked quotes do not dominate a fit
+const minSpreadVol = 0.005
+
+// Quote is the market and fitted implied volatility of one strike
+type Quote struct {
+    Strike   float64 `json:"strike"`
+    K        float64 `json:"logMoneyness"`
+    MarketIV float64 `json:"marketIv,omitempty"`
+    BidIV    float64 `json:"bidIv,omitempty"`
+    AskIV    float64 `json:"askIv,omitempty"`
+    SVIIV    float64 `json:"sviIv,omitempty"`
+    SSVIIV   float64 `json:"ssviIv,omitempty"`
+}
+
+// Slice is the fitted smile of one expiration
+type Slice struct {
+    Expiration string  `json:"expiration"`
+    Years      float64 `json:"yearsToExpiration"`
+    Forward    float64 `json:"forward"`
+    Theta      float64 `json:"atmTotalVariance"`
+
+    SVI       *RawSVI `json:"svi,omitempty"`
+    SVIError  float64 `json:"sviRmse,omitempty"` // weighted RMSE in total variance
+    SVICheck  *Check  `json:"sviCheck,omitempty"`
+    SSVICheck *Check  `json:"ssviCheck,omitempty"`
+
+    Quotes []Quote `json:"quotes"`
+
+    points []Point
+}
+
+// Fit holds the fitted smiles of an underlying
+type Fit struct {
+    Symbol     string    `json:"symbol"`
+    Underlying float64   `json:"underlyingPrice"`
+    Updated    time.Time `json:"lastUpdated"`
+    SSVI       *SSVI     `json:"ssvi,omitempty"`
+    SSVIError  float64   `json:"ssviRmse,omitempty"`
+    Slices     []Slice   `json:"slices"`
+}
+
+// Slice returns the fitted smile of an expiration
+func (f Fit) Slice(expiration string) (Slice, bool) {
+    for _, slice := range f.Slices {
+        if slice.Expiration == expiration {
+            return slice, true
+        }
+    }
+    return Slice{}, false
+}
+
+// FittedVol returns the fitted implied volatility of a strike under a
+// model, falling back to the other model when the slice has no fit for it
+func (s Slice) FittedVol(strike float64, model Model) float64 {
+    for _, quote := range s.Quotes {
+        if quote.Strike != strike {
+            continue
+        }
+        preferred, fallback := quote.SVIIV, quote.SSVIIV
+        if model == ModelSSVI {
+            preferred, fallback = fallback, preferred
+        }
+        if preferred > 0 {
+            return preferred
+        }
+        return fallback
+    }
+    return 0
+}
+
+// FitChain fits raw SVI to every expiration of a chain and SSVI across
+// them. Quotes are the out-of-the-money mid implied volatilities against
+// log-moneyness to each expiration's forward, weighted by the inverse of
+// their bid/ask spread in vol so wide wing quotes count for less.
+func FitChain(chain models.OptionChain, forwards map[string]float64, now time.Time) Fit {
+    fit := Fit{
+        Symbol:     chain.Symbol,
+        Underlying: chain.Underlying,
+        Updated:    chain.Updated,
+    }
+
+    slices := make(map[string]*Slice)
+    add := func(options []models.OptionData, call bool) {
+        for _, option := range options {
+            forward := forwards[option.Expiration]
+            if forward <= 0 || option.Strike <= 0 {
+                continue
+            }
+            slice, ok := slices[option.Expiration]
+            if !ok {
+                t, err := analytics.YearsToExpiration(option.Expiration, now)
+                if err != nil || t <= 0 {
+                    continue
+                }
+                slice = &Slice{Expiration: option.Expiration, Years: t, Forward: forward}
+                slices[option.Expiration] = slice
+            }
+
+            // Each strike is quoted once, by its out-of-the-money side
+            if call != (option.Strike >= forward) {
+                continue
+            }
+            quote := Quote{
+                Strike: option.Strike,
+                K:      math.Log(option.Strike / forward),
+                BidIV:  option.BidIV,
+                AskIV:  option.AskIV,
+            }
+            quote.MarketIV = option.MidIV
+            if quote.MarketIV <= 0 {
+                quote.MarketIV = option.ImpliedVol
+            }
+            slice.Quotes = append(slice.Quotes, quote)
+
+            // Quotes that break no-arbitrage bounds stay out of the fit
+            if quote.MarketIV > 0 && option.Flags == "" {
+                spread := minSpreadVol
+                if option.BidIV > 0 && option.AskIV > option.BidIV {
+                    spread = math.Max(option.AskIV-option.BidIV, minSpreadVol)
+                }
+                // Weight total variance errors so the fit is in vol terms:
+                // dw = 2 * vol * T * dvol
+                scale := 2 * quote.MarketIV * slice.Years
+                slice.points = append(slice.points, Point{
+                    K:      quote.K,
+                    W:      quote.MarketIV * quote.MarketIV * slice.Years,
+                    Weight: 1 / (spread * scale * scale),
+                })
+            }
+        }
+    }
+    add(chain.Calls, true)
+    add(chain.Puts, false)
+
+    for _, slice := range slices {
+        sort.Slice(slice.Quotes, func(i, j int) bool { return slice.Quotes[i].Strike < slice.Quotes[j].Strike })
+        fit.Slices = append(fit.Slices, *slice)
+    }
+    sort.Slice(fit.Slices, func(i, j int) bool { return fit.Slices[i].Years < fit.Slices[j].Years })
+
+    fitSVI(fit.Slices)
+    fit.SSVI, fit.SSVIError = fitSSVI(fit.Slices)
+    return fit
+}
+
+// fitSVI fits raw SVI to each slice and checks it for arbitrage
+func fitSVI(slices []Slice) {
+    var prev *RawSVI
+    for i := range slices {
+        slice := &slices[i]
+        s, rmse, err := FitRawSVI(slice.points)
+        if err != nil {
+            continue
+        }
+        slice.SVI = &s
+        slice.SVIError = rmse
+        for j := range slice.Quotes {
+            slice.Quotes[j].SVIIV = impliedVol(s.TotalVariance(slice.Quotes[j].K), slice.Years)
+        }
+
+        lo, hi := checkRange(slice.points)
+        check := Check{MinDensity: butterflyCheck(s.TotalVariance, lo, hi), CalendarFree: true}
+        check.ButterflyFree = check.MinDensity >= 0
+        if prev != nil {
+            check.CalendarFree = calendarFree(prev.TotalVariance, s.TotalVariance, lo, hi)
+        }
+        slice.SVICheck = &check
+        prev = slice.SVI
+    }
+}
+
+// fitSSVI fits SSVI across the slices, taking each slice's at-the-money
+// total variance from its SVI fit where there is one
+func fitSSVI(slices []Slice) (*SSVI, float64) {
+    var data []SSVISlice
+    var fitted []*Slice
+    theta := 0.0
+    for i := range slices {
+        slice := &slices[i]
+        atm := atmVariance(*slice)
+        if atm <= 0 {
+            continue
+        }
+        // Calendar arbitrage requires theta to increase with expiration
+        theta = math.Max(theta, atm)
+        slice.Theta = theta
+        data = append(data, SSVISlice{Theta: theta, Points: slice.points})
+        fitted = append(fitted, slice)
+    }
+
+    s, rmse, err := FitSSVI(data)
+    if err != nil {
+        return nil, 0
+    }
+
+    var prevTheta float64
+    for _, slice := range fitted {
+        w := func(k float64) float64 { return s.TotalVariance(k, slice.Theta) }
+        for j := range slice.Quotes {
+            slice.Quotes[j].SSVIIV = impliedVol(w(slice.Quotes[j].K), slice.Years)
+        }
+
+        lo, hi := checkRange(slice.points)
+        check := Check{MinDensity: butterflyCheck(w, lo, hi), CalendarFree: true}
+        check.ButterflyFree = check.MinDensity >= 0
+        if prevTheta > 0 {
+            theta := prevTheta
+            check.CalendarFree = calendarFree(func(k float64) float64 { return s.TotalVariance(k, theta) }, w, lo, hi)
+        }
+        slice.SSVICheck = &check
+        prevTheta = slice.Theta
+    }
+    return &s, rmse
+}
+
+// atmVariance returns the at-the-money total variance of a slice: from
+// its SVI fit, or interpolated between the quotes either side of the
+// forward
+func atmVariance(slice Slice) float64 {
+    if slice.SVI != nil {
+        return slice.SVI.TotalVariance(0)
+    }
+    points := append([]Point(nil), slice.points...)
+    if len(points) == 0 {
+        return 0
+    }
+    sort.Slice(points, func(i, j int) bool { return points[i].K < points[j].K })
+    if points[0].K >= 0 {
+        return points[0].W
+    }
+    for i := 1; i < len(points); i++ {
+        if points[i].K >= 0 {
+            lo, hi := points[i-1], points[i]
+            return lo.W + (hi.W-lo.W)*(0-lo.K)/(hi.K-lo.K)
+        }
+    }
+    return points[len(points)-1].W
+}
+
+// checkRange is the log-moneyness range arbitrage is checked over: the
+// quoted strikes plus a margin into the wings
+func checkRange(points []Point) (float64, float64) {
+    lo, hi := -0.5, 0.5
+    for _, p := range points {
+        lo = math.Min(lo, p.K-0.25)
+        hi = math.Max(hi, p.K+0.25)
+    }
+    return lo, hi
+}
+
+// impliedVol converts total variance back to an annualised vol
+func impliedVol(w, t float64) float64 {
+    if w <= 0 || t <= 0 {
+        return 0
+    }
+    return math.Sqrt(w / t)
+}
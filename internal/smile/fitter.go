@@ -0,0 +1,115 @@
+package smile
+
+import (
+    "context"
+    "math"
+    "sync"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/market"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// fittedVolStep is the precision fitted vols are reported to on contracts,
+// so refits that barely move the smile leave them, and stream deltas,
+// unchanged
+const fittedVolStep = 1e-4
+
+// Fitter refits the smiles of the stored chains at a fixed cadence and
+// keeps the latest fit of each underlying. Fitting is too slow to run on
+// every chain update, so updates are stamped with the latest fit instead.
+type Fitter struct {
+    chains   *store.ChainStore
+    market   *market.Params
+    model    Model
+    interval time.Duration
+    now      func() time.Time
+
+    mu     sync.RWMutex
+    fits   map[string]Fit
+    fitted map[string]time.Time // update time of the chain each fit is from
+}
+
+// NewFitter creates a fitter over the chain store that takes forwards from
+// the market parameters and reports fitted vols from the configured model
+func NewFitter(chains *store.ChainStore, market *market.Params, config Config) *Fitter {
+    return &Fitter{
+        chains:   chains,
+        market:   market,
+        model:    config.Model,
+        interval: config.Interval,
+        now:      time.Now,
+        fits:     make(map[string]Fit),
+        fitted:   make(map[string]time.Time),
+    }
+}
+
+// Apply sets the fitted vol of every contract from the underlying's latest
+// fit, a fair-value reference for its quoted vols. Contracts of
+// expirations not fitted yet have none.
+func (f *Fitter) Apply(chain *models.OptionChain) {
+    fit, _ := f.Fit(chain.Symbol)
+    slices := make(map[string]Slice, len(fit.Slices))
+    for _, slice := range fit.Slices {
+        slices[slice.Expiration] = slice
+    }
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for i := range options {
+            options[i].FittedIV = 0
+            if slice, ok := slices[options[i].Expiration]; ok {
+                vol := slice.FittedVol(options[i].Strike, f.model)
+                options[i].FittedIV = math.Round(vol/fittedVolStep) * fittedVolStep
+            }
+        }
+    }
+}
+
+// Refit fits the smiles of a stored chain unless it has not changed since
+// its last fit. It reports false if no chain is stored for the symbol.
+func (f *Fitter) Refit(symbol string) bool {
+    chain, ok := f.chains.Get(symbol)
+    if !ok {
+        return false
+    }
+    f.mu.RLock()
+    fitted, ok := f.fitted[symbol]
+    f.mu.RUnlock()
+    if ok && fitted.Equal(chain.Updated) {
+        return true
+    }
+
+    now := f.now()
+    fit := FitChain(chain, f.market.Forwards(chain, now), now)
+
+    f.mu.Lock()
+    f.fits[symbol] = fit
+    f.fitted[symbol] = chain.Updated
+    f.mu.Unlock()
+    return true
+}
+
+// Run refits the stored chains until the context is cancelled
+func (f *Fitter) Run(ctx context.Context) {
+    ticker := time.NewTicker(f.interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            for _, symbol := range f.chains.Symbols() {
+                f.Refit(symbol)
+            }
+        }
+    }
+}
+
+// Fit returns the latest fit of an underlying
+func (f *Fitter) Fit(symbol string) (Fit, bool) {
+    f.mu.RLock()
+    defer f.mu.RUnlock()
+    fit, ok := f.fits[symbol]
+    return fit, ok
+}
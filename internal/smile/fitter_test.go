@@ -0,0 +1,74 @@
+package smile
+
+import (
+    "math"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/market"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+func TestFitterRefit(t *testing.T) {
+    now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+    known := RawSVI{A: 0.01, B: 0.1, Rho: -0.4, M: 0.02, Sigma: 0.15}
+
+    // With no rates, dividends or two-sided pairs the forward is the spot,
+    // so mid vols read straight off the known smile
+    const spot, expiration = 100.0, "2027-04-16"
+    years, err := analytics.YearsToExpiration(expiration, now)
+    if err != nil {
+        t.Fatal(err)
+    }
+    chain := models.OptionChain{Symbol: "SPY", Underlying: spot, Updated: now}
+    for strike := 70.0; strike <= 130; strike += 5 {
+        vol := math.Sqrt(known.TotalVariance(math.Log(strike/spot)) / years)
+        option := models.OptionData{Strike: strike, Expiration: expiration, MidIV: vol}
+        if strike >= spot {
+            option.Type = "call"
+            chain.Calls = append(chain.Calls, option)
+        } else {
+            option.Type = "put"
+            chain.Puts = append(chain.Puts, option)
+        }
+    }
+
+    chains := store.NewChainStore()
+    chains.Put(chain)
+    params, err := market.NewParams(market.Config{})
+    if err != nil {
+        t.Fatal(err)
+    }
+    f := NewFitter(chains, params, Config{Model: ModelSVI, Interval: time.Minute})
+    fits := 0
+    f.now = func() time.Time {
+        fits++
+        return now
+    }
+
+    if f.Refit("QQQ") {
+        t.Error("refit a symbol with no stored chain")
+    }
+    if !f.Refit("SPY") || !f.Refit("SPY") {
+        t.Fatal("no fit for a stored chain")
+    }
+    if fits != 1 {
+        t.Errorf("fitted %d times, want an unchanged chain fitted once", fits)
+    }
+
+    f.Apply(&chain)
+    for _, option := range append(chain.Calls, chain.Puts...) {
+        if math.Abs(option.FittedIV-option.MidIV) > fittedVolStep {
+            t.Errorf("fitted vol at %v = %.4f, want %.4f", option.Strike, option.FittedIV, option.MidIV)
+        }
+    }
+
+    chain.Updated = now.Add(time.Second)
+    chains.Put(chain)
+    f.Refit("SPY")
+    if fits != 2 {
+        t.Errorf("fitted %d times, want an updated chain refitted", fits)
+    }
+}
@@ -0,0 +1,79 @@
+package smile
+
+import (
+    "math"
+    "sort"
+)
+
+// nelderMead minimises f from start with the Nelder-Mead simplex method.
+// The initial simplex steps each coordinate by the matching entry of step.
+// It returns the best point found and its value.
+func nelderMead(f func([]float64) float64, start, step []float64, maxIter int, tol float64) ([]float64, float64) {
+    n := len(start)
+    type vertex struct {
+        x []float64
+        f float64
+    }
+    simplex := make([]vertex, n+1)
+    simplex[0] = vertex{append([]float64(nil), start...), f(start)}
+    for i := 0; i < n; i++ {
+        x := append([]float64(nil), start...)
+        x[i] += step[i]
+        simplex[i+1] = vertex{x, f(x)}
+    }
+
+    // point returns centroid + scale * (x - centroid)
+    point := func(centroid, x []float64, scale float64) []float64 {
+        p := make([]float64, n)
+        for i := range p {
+            p[i] = centroid[i] + scale*(x[i]-centroid[i])
+        }
+        return p
+    }
+
+    for iter := 0; iter < maxIter; iter++ {
+        sort.Slice(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+        best, worst := simplex[0], simplex[n]
+        if math.Abs(worst.f-best.f) <= tol*(math.Abs(best.f)+tol) {
+            break
+        }
+
+        centroid := make([]float64, n)
+        for _, v := range simplex[:n] {
+            for i := range centroid {
+                centroid[i] += v.x[i] / float64(n)
+            }
+        }
+
+        reflected := point(centroid, worst.x, -1)
+        fr := f(reflected)
+        switch {
+        case fr < best.f:
+            expanded := point(centroid, worst.x, -2)
+            if fe := f(expanded); fe < fr {
+                simplex[n] = vertex{expanded, fe}
+            } else {
+                simplex[n] = vertex{reflected, fr}
+            }
+        case fr < simplex[n-1].f:
+            simplex[n] = vertex{reflected, fr}
+        default:
+            contracted := point(centroid, worst.x, 0.5)
+            if fr < worst.f {
+                contracted = point(centroid, worst.x, -0.5)
+            }
+            if fc := f(contracted); fc < math.Min(fr, worst.f) {
+                simplex[n] = vertex{contracted, fc}
+                continue
+            }
+            // Shrink towards the best vertex
+            for i := 1; i <= n; i++ {
+                x := point(best.x, simplex[i].x, 0.5)
+                simplex[i] = vertex{x, f(x)}
+            }
+        }
+    }
+
+    sort.Slice(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+    return simplex[0].x, simplex[0].f
+}
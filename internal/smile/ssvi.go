@@ -0,0 +1,87 @@
+package smile
+
+import (
+    "errors"
+    "math"
+)
+
+// SSVI is the surface SVI parameterisation of Gatheral and Jacquier with a
+// power-law curvature: each expiration is described by its at-the-money
+// total variance theta, and
+// w(k, theta) = theta/2 * (1 + rho*phi*k + sqrt((phi*k + rho)^2 + 1 - rho^2))
+// with phi(theta) = eta / (theta^gamma * (1 + theta)^(1 - gamma)).
+type SSVI struct {
+    Rho   float64 `json:"rho"`
+    Eta   float64 `json:"eta"`
+    Gamma float64 `json:"gamma"`
+}
+
+// SSVISlice is the data of one expiration for an SSVI fit
+type SSVISlice struct {
+    Theta  float64 // at-the-money total variance
+    Points []Point
+}
+
+// TotalVariance returns w(k, theta)
+func (s SSVI) TotalVariance(k, theta float64) float64 {
+    if theta <= 0 {
+        return 0
+    }
+    phi := s.Eta / (math.Pow(theta, s.Gamma) * math.Pow(1+theta, 1-s.Gamma))
+    x := phi*k + s.Rho
+    return theta / 2 * (1 + s.Rho*phi*k + math.Sqrt(x*x+1-s.Rho*s.Rho))
+}
+
+// FitSSVI calibrates rho, eta and gamma to every slice at once. The search
+// is restricted to gamma in (0, 1/2] and eta * (1 + |rho|) <= 2, under
+// which the surface is free of static arbitrage as long as theta increases
+// with expiration. It returns the parameters and the weighted RMSE in
+// total variance.
+func FitSSVI(slices []SSVISlice) (SSVI, float64, error) {
+    var points int
+    for _, slice := range slices {
+        points += len(slice.Points)
+    }
+    if points < 3 {
+        return SSVI{}, 0, ErrTooFewPoints
+    }
+
+    // Unconstrained coordinates: rho = tanh(x0), eta = exp(x1),
+    // gamma = 1/2 * logistic(x2)
+    params := func(x []float64) SSVI {
+        return SSVI{
+            Rho:   math.Tanh(x[0]),
+            Eta:   math.Exp(x[1]),
+            Gamma: 0.5 / (1 + math.Exp(-x[2])),
+        }
+    }
+    objective := func(x []float64) float64 {
+        s := params(x)
+        if s.Eta*(1+math.Abs(s.Rho)) > 2 {
+            return math.Inf(1)
+        }
+        var sse float64
+        for _, slice := range slices {
+            sse += weightedSSE(slice.Points, func(k float64) float64 {
+                return s.TotalVariance(k, slice.Theta)
+            })
+        }
+        return sse
+    }
+
+    x, value := nelderMead(objective, []float64{math.Atanh(-0.3), math.Log(0.5), 0}, []float64{0.3, 0.3, 1}, 1000, 1e-12)
+    if math.IsInf(value, 1) || math.IsNaN(value) {
+        return SSVI{}, 0, errors.New("SSVI calibration failed")
+    }
+    s := params(x)
+
+    var sse, weights float64
+    for _, slice := range slices {
+        for _, p := range slice.Points {
+            diff := s.TotalVariance(p.K, slice.Theta) - p.W
+            sse += p.Weight * diff * diff
+            weights += p.Weight
+        }
+    }
+    return s, math.Sqrt(sse / weights), nil
+}
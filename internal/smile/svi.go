@@ -0,0 +1,180 @@
+package smile
+
+import (
+    "errors"
+    "math"
+)
+
+// minSVIPoints is the number of quotes needed to fit the five raw SVI
+// parameters
+const minSVIPoints = 5
+
+// maxWingSlope is Lee's bound on the slope of total variance in the wings
+const maxWingSlope = 2.0
+
+// ErrTooFewPoints is returned when a slice has too few quotes to fit
+var ErrTooFewPoints = errors.New("too few quotes to fit")
+
+// Point is one market quote of a smile
+type Point struct {
+    K      float64 // log-moneyness ln(strike / forward)
+    W      float64 // total implied variance vol^2 * T
+    Weight float64
+}
+
+// RawSVI is Gatheral's raw SVI parameterisation of total implied variance:
+// w(k) = a + b * (rho * (k - m) + sqrt((k - m)^2 + sigma^2))
+type RawSVI struct {
+    A     float64 `json:"a"`
+    B     float64 `json:"b"`
+    Rho   float64 `json:"rho"`
+    M     float64 `json:"m"`
+    Sigma float64 `json:"sigma"`
+}
+
+// TotalVariance returns w(k)
+func (s RawSVI) TotalVariance(k float64) float64 {
+    x := k - s.M
+    return s.A + s.B*(s.Rho*x+math.Sqrt(x*x+s.Sigma*s.Sigma))
+}
+
+// FitRawSVI calibrates raw SVI to weighted quotes with the quasi-explicit
+// method of Zeliade: for fixed m and sigma the remaining parameters solve
+// a linear least-squares problem, which is projected onto the no-arbitrage
+// domain (non-negative variance, wing slopes within Lee's bound), and m and
+// sigma are found with Nelder-Mead. It returns the parameters and the
+// weighted RMSE in total variance.
+func FitRawSVI(points []Point) (RawSVI, float64, error) {
+    if len(points) < minSVIPoints {
+        return RawSVI{}, 0, ErrTooFewPoints
+    }
+
+    minK, maxK := points[0].K, points[0].K
+    atm := points[0]
+    maxW := 0.0
+    for _, p := range points {
+        minK = math.Min(minK, p.K)
+        maxK = math.Max(maxK, p.K)
+        maxW = math.Max(maxW, p.W)
+        if math.Abs(p.K) < math.Abs(atm.K) {
+            atm = p
+        }
+    }
+
+    objective := func(x []float64) float64 {
+        m, sigma := x[0], math.Exp(x[1])
+        if m < minK-1 || m > maxK+1 || sigma < 1e-4 || sigma > 10 {
+            return math.Inf(1)
+        }
+        s := solveSVI(points, m, sigma, maxW)
+        return weightedSSE(points, s.TotalVariance)
+    }
+
+    var best []float64
+    bestValue := math.Inf(1)
+    for _, sigma := range []float64{0.05, 0.2, 0.5} {
+        x, value := nelderMead(objective, []float64{atm.K, math.Log(sigma)}, []float64{0.1, 0.5}, 500, 1e-12)
+        if value < bestValue {
+            best, bestValue = x, value
+        }
+    }
+    if best == nil || math.IsInf(bestValue, 1) {
+        return RawSVI{}, 0, errors.New("SVI calibration failed")
+    }
+
+    s := solveSVI(points, best[0], math.Exp(best[1]), maxW)
+    return s, rmse(points, s.TotalVariance), nil
+}
+
+// solveSVI finds a, b and rho for fixed m and sigma. In y = (k - m) / sigma
+// the smile is linear in (a, d, c) with d = rho * b * sigma and
+// c = b * sigma: w = a + d*y + c*sqrt(y^2 + 1).
+func solveSVI(points []Point, m, sigma, maxW float64) RawSVI {
+    // Normal equations of the weighted least-squares problem
+    var ata [3][3]float64
+    var atb [3]float64
+    for _, p := range points {
+        y := (p.K - m) / sigma
+        row := [3]float64{1, y, math.Sqrt(y*y + 1)}
+        for i := 0; i < 3; i++ {
+            for j := 0; j < 3; j++ {
+                ata[i][j] += p.Weight * row[i] * row[j]
+            }
+            atb[i] += p.Weight * row[i] * p.W
+        }
+    }
+    x, ok := solve3(ata, atb)
+    a, d, c := x[0], x[1], x[2]
+
+    // Project onto c >= 0, |d| <= c, c + |d| <= maxWingSlope * sigma,
+    // 0 <= a <= max w, re-solving a for the projected slopes
+    limit := maxWingSlope * sigma
+    feasible := ok && c >= 0 && math.Abs(d) <= c && c+math.Abs(d) <= limit && a >= 0 && a <= maxW
+    if !feasible {
+        c = math.Max(0, math.Min(c, limit))
+        if !ok {
+            c, d = 0, 0
+        }
+        d = math.Max(-c, math.Min(d, c))
+        d = math.Max(-(limit - c), math.Min(d, limit-c))
+        var num, den float64
+        for _, p := range points {
+            y := (p.K - m) / sigma
+            num += p.Weight * (p.W - d*y - c*math.Sqrt(y*y+1))
+            den += p.Weight
+        }
+        a = math.Max(0, math.Min(num/den, maxW))
+    }
+
+    s := RawSVI{A: a, M: m, Sigma: sigma}
+    if c > 0 {
+        s.B = c / sigma
+        s.Rho = d / c
+    }
+    return s
+}
+
+// solve3 solves a 3x3 linear system with Cramer's rule, reporting false if
+// it is singular
+func solve3(a [3][3]float64, b [3]float64) ([3]float64, bool) {
+    det := func(m [3][3]float64) float64 {
+        return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+            m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+            m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+    }
+    d := det(a)
+    var x [3]float64
+    if math.Abs(d) < 1e-18 {
+        return x, false
+    }
+    for i := 0; i < 3; i++ {
+        m := a
+        for j := 0; j < 3; j++ {
+            m[j][i] = b[j]
+        }
+        x[i] = det(m) / d
+    }
+    return x, true
+}
+
+// weightedSSE is the weighted sum of squared total variance errors
+func weightedSSE(points []Point, w func(float64) float64) float64 {
+    var sse float64
+    for _, p := range points {
+        diff := w(p.K) - p.W
+        sse += p.Weight * diff * diff
+    }
+    return sse
+}
+
+// rmse is the weighted root mean squared total variance error
+func rmse(points []Point, w func(float64) float64) float64 {
+    var weights float64
+    for _, p := range points {
+        weights += p.Weight
+    }
+    if weights == 0 {
+        return 0
+    }
+    return math.Sqrt(weightedSSE(points, w) / weights)
+}
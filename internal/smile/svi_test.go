@@ -0,0 +1,101 @@
+package smile
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+// sviSlice samples a smile at evenly spaced log-moneyness with equal weights
+func sviSlice(w func(float64) float64, lo, hi float64, n int) []Point {
+    points := make([]Point, n)
+    for i := range points {
+        k := lo + (hi-lo)*float64(i)/float64(n-1)
+        points[i] = Point{K: k, W: w(k), Weight: 1}
+    }
+    return points
+}
+
+func TestFitRawSVIRecoversKnownSlice(t *testing.T) {
+    tests := []struct {
+        name string
+        want RawSVI
+    }{
+        {"equity skew", RawSVI{A: 0.04, B: 0.4, Rho: -0.4, M: 0.05, Sigma: 0.2}},
+        {"symmetric smile", RawSVI{A: 0.02, B: 0.2, Rho: 0, M: 0, Sigma: 0.3}},
+        {"upside skew", RawSVI{A: 0.01, B: 0.15, Rho: 0.5, M: -0.1, Sigma: 0.1}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, rmse, err := FitRawSVI(sviSlice(tt.want.TotalVariance, -0.6, 0.4, 21))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if rmse > 1e-6 {
+                t.Errorf("RMSE = %g, want an exact fit", rmse)
+            }
+            for _, c := range []struct {
+                name      string
+                got, want float64
+            }{
+                {"a", got.A, tt.want.A},
+                {"b", got.B, tt.want.B},
+                {"rho", got.Rho, tt.want.Rho},
+                {"m", got.M, tt.want.M},
+                {"sigma", got.Sigma, tt.want.Sigma},
+            } {
+                if math.Abs(c.got-c.want) > 1e-3 {
+                    t.Errorf("%s = %.5f, want %.5f", c.name, c.got, c.want)
+                }
+            }
+        })
+    }
+}
+
+func TestFitRawSVITooFewPoints(t *testing.T) {
+    s := RawSVI{A: 0.04, B: 0.4, Rho: -0.4, M: 0.05, Sigma: 0.2}
+    if _, _, err := FitRawSVI(sviSlice(s.TotalVariance, -0.2, 0.2, minSVIPoints-1)); !errors.Is(err, ErrTooFewPoints) {
+        t.Errorf("err = %v, want ErrTooFewPoints", err)
+    }
+}
+
+func TestFitSSVIRecoversKnownSurface(t *testing.T) {
+    want := SSVI{Rho: -0.6, Eta: 1.2, Gamma: 0.4}
+    var slices []SSVISlice
+    for _, theta := range []float64{0.005, 0.02, 0.06} {
+        w := func(k float64) float64 { return want.TotalVariance(k, theta) }
+        slices = append(slices, SSVISlice{Theta: theta, Points: sviSlice(w, -0.5, 0.3, 11)})
+    }
+
+    got, rmse, err := FitSSVI(slices)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if rmse > 1e-6 {
+        t.Errorf("RMSE = %g, want an exact fit", rmse)
+    }
+    if math.Abs(got.Rho-want.Rho) > 1e-3 || math.Abs(got.Eta-want.Eta) > 1e-3 || math.Abs(got.Gamma-want.Gamma) > 1e-3 {
+        t.Errorf("SSVI = %+v, want %+v", got, want)
+    }
+}
+
+func TestButterflyCheck(t *testing.T) {
+    tests := []struct {
+        name string
+        s    RawSVI
+        free bool
+    }{
+        {"arbitrage-free skew", RawSVI{A: 0.04, B: 0.4, Rho: -0.4, M: 0.05, Sigma: 0.2}, true},
+        // Gatheral and Jacquier, Arbitrage-free SVI volatility surfaces
+        // (2014), example 3.1: g(k) is negative around k = 1
+        {"Gatheral-Jacquier counterexample", RawSVI{A: -0.0410, B: 0.1331, Rho: 0.3060, M: 0.3586, Sigma: 0.4153}, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            minimum := butterflyCheck(tt.s.TotalVariance, -1.5, 1.5)
+            if free := minimum >= 0; free != tt.free {
+                t.Errorf("min g(k) = %.4f, want butterfly-free %v", minimum, tt.free)
+            }
+        })
+    }
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+    "sort"
+    "sync"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// ChainStore keeps the latest aggregated option chain for each underlying.
+// It is shared by the HTTP, WebSocket and gRPC front ends.
+type ChainStore struct {
+    mu     sync.RWMutex
+    chains map[string]models.OptionChain
+}
+
+// NewChainStore creates an empty chain store
+func NewChainStore() *ChainStore {
+    return &ChainStore{
+        chains: make(map[string]models.OptionChain),
+    }
+}
+
+// Put replaces the chain stored for the chain's symbol
+func (s *ChainStore) Put(chain models.OptionChain) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.chains[chain.Symbol] = chain
+}
+
+// Get returns the latest chain for a symbol. The returned chain shares its
+// contract slices with the store and must not be modified.
+func (s *ChainStore) Get(symbol string) (models.OptionChain, bool) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    chain, ok := s.chains[symbol]
+    return chain, ok
+}
+
+// Symbols returns the stored symbols in sorted order
+func (s *ChainStore) Symbols() []string {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    symbols := make([]string, 0, len(s.chains))
+    for symbol := range s.chains {
+        symbols = append(symbols, symbol)
+    }
+    sort.Strings(symbols)
+    return symbols
+}
@@ -0,0 +1,288 @@
+package strategy
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// Valuer prices a contract of an underlying at a given spot and time, and
+// forecasts the underlying's price at an expiration
+type Valuer interface {
+    Value(symbol string, option models.OptionData, spot float64, at time.Time) float64
+    Distribution(symbol string, spot, vol float64, expiration string, now time.Time) analytics.Lognormal
+}
+
+// Request asks for the analysis of a position
+type Request struct {
+    Position
+    Days   []float64 `json:"days,omitempty"`   // days from now of the T+n curves, default today only
+    Range  float64   `json:"range,omitempty"`  // curve prices either side of spot as a fraction, default 0.2
+    Points int       `json:"points,omitempty"` // prices per curve, default 101
+}
+
+// Point is the profit or loss of a position at one underlying price
+type Point struct {
+    Price float64 `json:"price"`
+    PL    float64 `json:"pl"`
+}
+
+// Curve is the profit or loss of a position across underlying prices at
+// one time
+type Curve struct {
+    Days       float64 `json:"days"`       // from now
+    Expiration bool    `json:"expiration"` // taken at the first leg's expiration
+    Points     []Point `json:"points"`
+}
+
+// Analysis describes the cost, risk and payoff of a position. Profit and
+// loss are in dollars against the mid price.
+type Analysis struct {
+    Underlying      string     `json:"underlying"`
+    UnderlyingPrice float64    `json:"underlyingPrice"`
+    Updated         time.Time  `json:"lastUpdated"`
+    Legs            []LegQuote `json:"legs"`
+    Price           Price      `json:"price"`
+    Greeks          Greeks     `json:"greeks"`
+
+    // At the first expiration among the legs
+    Expiration      string    `json:"expiration,omitempty"`
+    MaxProfit       float64   `json:"maxProfit"` // zero when unlimited
+    MaxLoss         float64   `json:"maxLoss"`   // negative; zero when unlimited or nothing can be lost
+    UnlimitedProfit bool      `json:"unlimitedProfit"`
+    UnlimitedLoss   bool      `json:"unlimitedLoss"`
+    Breakevens      []float64 `json:"breakevens"`
+    POP             float64   `json:"pop"` // probability of a profit against the mid; zero without option vols
+
+    Curves []Curve `json:"curves"`
+}
+
+// Analyzer analyzes positions against the aggregated chains
+type Analyzer struct {
+    chains *store.ChainStore
+    valuer Valuer
+    now    func() time.Time
+}
+
+// NewAnalyzer creates an analyzer that values legs with the given valuer,
+// normally the pricing engine
+func NewAnalyzer(chains *store.ChainStore, valuer Valuer) *Analyzer {
+    return &Analyzer{
+        chains: chains,
+        valuer: valuer,
+        now:    time.Now,
+    }
+}
+
+// BuildRequest asks for a templated position to be picked from the chain
+// and analyzed
+type BuildRequest struct {
+    Selection
+    Days   []float64 `json:"days,omitempty"`
+    Range  float64   `json:"range,omitempty"`
+    Points int       `json:"points,omitempty"`
+}
+
+// Build picks the legs of a templated position from its underlying's chain
+// and analyzes the result
+func (a *Analyzer) Build(req BuildRequest) (Analysis, error) {
+    symbol := strings.ToUpper(req.Underlying)
+    chain, ok := a.chains.Get(symbol)
+    if !ok {
+        return Analysis{}, fmt.Errorf("%w %s", ErrNoChain, symbol)
+    }
+    position, err := Select(chain, req.Selection, a.now())
+    if err != nil {
+        return Analysis{}, err
+    }
+    return a.Analyze(Request{Position: position, Days: req.Days, Range: req.Range, Points: req.Points})
+}
+
+// Analyze resolves a position against its underlying's chain and computes
+// its price, Greeks, risk and payoff curves
+func (a *Analyzer) Analyze(req Request) (Analysis, error) {
+    req.Underlying = strings.ToUpper(req.Underlying)
+    if err := req.Validate(); err != nil {
+        return Analysis{}, err
+    }
+    if req.Range == 0 {
+        req.Range = 0.2
+    }
+    if req.Points == 0 {
+        req.Points = 101
+    }
+    if req.Range < 0 || req.Range >= 1 {
+        return Analysis{}, fmt.Errorf("invalid range: %v", req.Range)
+    }
+    if req.Points < 2 || req.Points > 1000 {
+        return Analysis{}, fmt.Errorf("invalid points: %d", req.Points)
+    }
+    if len(req.Days) == 0 {
+        req.Days = []float64{0}
+    }
+
+    chain, ok := a.chains.Get(req.Underlying)
+    if !ok {
+        return Analysis{}, fmt.Errorf("%w %s", ErrNoChain, req.Underlying)
+    }
+    if chain.Underlying <= 0 {
+        return Analysis{}, fmt.Errorf("no underlying price for %s", req.Underlying)
+    }
+    legs, err := req.Resolve(chain)
+    if err != nil {
+        return Analysis{}, err
+    }
+
+    now := a.now()
+    spot := chain.Underlying
+    analysis := Analysis{
+        Underlying:      req.Underlying,
+        UnderlyingPrice: spot,
+        Updated:         chain.Updated,
+        Legs:            legs,
+        Price:           PriceOf(legs),
+        Greeks:          GreeksOf(legs),
+    }
+
+    // Profit or loss at a price and time, against the mid
+    pl := func(price float64, at time.Time) float64 {
+        value := -analysis.Price.Mid
+        for _, leg := range legs {
+            if leg.Option == nil {
+                value += leg.Size() * price
+            } else {
+                value += leg.Size() * a.valuer.Value(req.Underlying, *leg.Option, price, at)
+            }
+        }
+        return value
+    }
+
+    expiry := now
+    var strikes []float64
+    for _, leg := range legs {
+        if leg.Option == nil {
+            continue
+        }
+        strikes = append(strikes, leg.Option.Strike)
+        if analysis.Expiration == "" || leg.Option.Expiration < analysis.Expiration {
+            analysis.Expiration = leg.Option.Expiration
+        }
+    }
+    if analysis.Expiration != "" {
+        if expiry, err = analytics.ExpirationTime(analysis.Expiration); err != nil {
+            return Analysis{}, fmt.Errorf("parsing expiration: %w", err)
+        }
+    }
+    atExpiry := func(price float64) float64 { return pl(price, expiry) }
+
+    // Curves across the requested range, through every strike inside it
+    lo, hi := spot*(1-req.Range), spot*(1+req.Range)
+    grid := make([]float64, 0, req.Points+len(strikes))
+    for i := 0; i < req.Points; i++ {
+        grid = append(grid, lo+(hi-lo)*float64(i)/float64(req.Points-1))
+    }
+    for _, strike := range strikes {
+        if strike > lo && strike < hi {
+            grid = append(grid, strike)
+        }
+    }
+    grid = sortedUnique(grid)
+
+    for _, days := range req.Days {
+        at := now.Add(time.Duration(days * 24 * float64(time.Hour)))
+        if days < 0 || !at.Before(expiry) {
+            continue
+        }
+        analysis.Curves = append(analysis.Curves, curve(grid, days, false, func(price float64) float64 { return pl(price, at) }))
+    }
+    analysis.Curves = append(analysis.Curves, curve(grid, expiry.Sub(now).Hours()/24, true, atExpiry))
+
+    analysis.MaxProfit, analysis.MaxLoss, analysis.UnlimitedProfit, analysis.UnlimitedLoss, analysis.Breakevens = risk(atExpiry, spot, strikes, grid)
+    analysis.POP, _ = ProbabilityOfProfit(a.valuer, req.Underlying, legs, spot, analysis.Price.Mid, now)
+    return analysis, nil
+}
+
+// curve evaluates a profit and loss function over a grid of prices
+func curve(grid []float64, days float64, expiration bool, pl func(float64) float64) Curve {
+    c := Curve{Days: days, Expiration: expiration, Points: make([]Point, len(grid))}
+    for i, price := range grid {
+        c.Points[i] = Point{Price: price, PL: pl(price)}
+    }
+    return c
+}
+
+// risk finds the extremes and breakevens of a profit and loss function at
+// expiration. Prices from near zero to twice the highest strike are
+// searched, together with the curve grid; the slope beyond that decides
+// whether profit or loss is unlimited.
+func risk(pl func(float64) float64, spot float64, strikes, grid []float64) (maxProfit, maxLoss float64, unlimitedProfit, unlimitedLoss bool, breakevens []float64) {
+    top := spot
+    for _, strike := range strikes {
+        top = math.Max(top, strike)
+    }
+    far := 2 * top
+    prices := append([]float64{0.01, spot, far}, strikes...)
+    prices = sortedUnique(append(prices, grid...))
+
+    values := make([]float64, len(prices))
+    maxProfit, maxLoss = math.Inf(-1), math.Inf(1)
+    for i, price := range prices {
+        values[i] = pl(price)
+        maxProfit = math.Max(maxProfit, values[i])
+        maxLoss = math.Min(maxLoss, values[i])
+    }
+    maxLoss = math.Min(maxLoss, 0)
+    maxProfit = math.Max(maxProfit, 0)
+
+    slope := (pl(2*far) - values[len(values)-1]) / far
+    unlimitedProfit = slope > 1e-6
+    unlimitedLoss = slope < -1e-6
+    if unlimitedProfit {
+        maxProfit = 0
+    }
+    if unlimitedLoss {
+        maxLoss = 0
+    }
+
+    for i := 1; i < len(prices); i++ {
+        if (values[i-1] < 0) == (values[i] < 0) {
+            continue
+        }
+        a, b := prices[i-1], prices[i]
+        for j := 0; j < 50; j++ {
+            mid := (a + b) / 2
+            if (pl(mid) < 0) == (values[i-1] < 0) {
+                a = mid
+            } else {
+                b = mid
+            }
+        }
+        breakevens = append(breakevens, (a+b)/2)
+    }
+    if unlimitedProfit || unlimitedLoss {
+        // Beyond the far price the position keeps its sign once the slope
+        // has taken over, so no breakeven is missed above it
+        if last := values[len(values)-1]; (last < 0) != (slope < 0) && last != 0 {
+            breakevens = append(breakevens, far-last/slope)
+        }
+    }
+    return
+}
+
+// sortedUnique sorts prices and drops duplicates
+func sortedUnique(prices []float64) []float64 {
+    sort.Float64s(prices)
+    out := prices[:0]
+    for i, price := range prices {
+        if i == 0 || price != prices[i-1] {
+            out = append(out, price)
+        }
+    }
+    return out
+}
@@ -0,0 +1,133 @@
+package strategy
+
+import (
+    "fmt"
+    "math"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+var (
+    testNow   = time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+    testFront = "2026-11-30" // 45 days out
+    testBack  = "2027-01-15"
+)
+
+// testContract is the symbol of a fixture contract
+func testContract(expiration, optionType string, strike float64) string {
+    date, _ := time.Parse("2006-01-02", expiration)
+    return fmt.Sprintf(".SPY%s%s%g", date.Format("060102"), optionType[:1], strike)
+}
+
+// testChain lists strikes 80 to 120 on a front and a back expiration with
+// round mids a dime wide and rounded deltas, the same on both
+func testChain() models.OptionChain {
+    strikes := []float64{80, 85, 90, 95, 100, 105, 110, 115, 120}
+    callMids := []float64{20.5, 16.0, 11.5, 8.0, 5.0, 3.0, 1.5, 0.7, 0.3}
+    putMids := []float64{0.3, 0.6, 1.2, 2.5, 4.5, 7.5, 11.5, 16.0, 20.5}
+    callDeltas := []float64{0.95, 0.90, 0.82, 0.68, 0.52, 0.36, 0.22, 0.12, 0.06}
+
+    chain := models.OptionChain{Symbol: "SPY", Underlying: 100, Updated: testNow}
+    option := func(expiration, optionType string, strike, mid, delta float64) models.OptionData {
+        return models.OptionData{
+            Symbol:     testContract(expiration, optionType, strike),
+            Strike:     strike,
+            Expiration: expiration,
+            Type:       optionType,
+            Bid:        mid - 0.05,
+            Ask:        mid + 0.05,
+            Delta:      delta,
+            ImpliedVol: 0.25,
+        }
+    }
+    for _, expiration := range []string{testFront, testBack} {
+        for i, strike := range strikes {
+            chain.Calls = append(chain.Calls, option(expiration, "call", strike, callMids[i], callDeltas[i]))
+            chain.Puts = append(chain.Puts, option(expiration, "put", strike, putMids[i], callDeltas[i]-1))
+        }
+    }
+    return chain
+}
+
+func TestAnalyzeRisk(t *testing.T) {
+    leg := func(optionType string, strike float64, quantity int) models.Leg {
+        return models.Leg{Contract: testContract(testFront, optionType, strike), Quantity: quantity}
+    }
+
+    tests := []struct {
+        name            string
+        legs            []models.Leg
+        maxProfit       float64
+        maxLoss         float64
+        unlimitedProfit bool
+        unlimitedLoss   bool
+        breakevens      []float64
+    }{
+        {
+            // 3.50 debit on a 10-wide spread
+            name:       "call debit vertical",
+            legs:       []models.Leg{leg("call", 100, 1), leg("call", 110, -1)},
+            maxProfit:  650,
+            maxLoss:    -350,
+            breakevens: []float64{103.5},
+        },
+        {
+            // 1.40 credit on 5-wide wings
+            name: "iron condor",
+            legs: []models.Leg{
+                leg("put", 85, 1), leg("put", 90, -1),
+                leg("call", 110, -1), leg("call", 115, 1),
+            },
+            maxProfit:  140,
+            maxLoss:    -360,
+            breakevens: []float64{88.6, 111.4},
+        },
+        {
+            name:          "naked short call",
+            legs:          []models.Leg{leg("call", 110, -1)},
+            maxProfit:     150,
+            unlimitedLoss: true,
+            breakevens:    []float64{111.5},
+        },
+        {
+            name:            "long straddle",
+            legs:            []models.Leg{leg("call", 100, 1), leg("put", 100, 1)},
+            maxLoss:         -950,
+            unlimitedProfit: true,
+            breakevens:      []float64{90.5, 109.5},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            chains := store.NewChainStore()
+            chains.Put(testChain())
+            a := NewAnalyzer(chains, intrinsicValuer{})
+            a.now = func() time.Time { return testNow }
+
+            analysis, err := a.Analyze(Request{Position: Position{Underlying: "spy", Legs: tt.legs}})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if math.Abs(analysis.MaxProfit-tt.maxProfit) > 1e-6 || math.Abs(analysis.MaxLoss-tt.maxLoss) > 1e-6 {
+                t.Errorf("max profit, loss = %.2f, %.2f, want %.2f, %.2f", analysis.MaxProfit, analysis.MaxLoss, tt.maxProfit, tt.maxLoss)
+            }
+            if analysis.UnlimitedProfit != tt.unlimitedProfit || analysis.UnlimitedLoss != tt.unlimitedLoss {
+                t.Errorf("unlimited profit, loss = %v, %v, want %v, %v", analysis.UnlimitedProfit, analysis.UnlimitedLoss, tt.unlimitedProfit, tt.unlimitedLoss)
+            }
+            if len(analysis.Breakevens) != len(tt.breakevens) {
+                t.Fatalf("breakevens = %v, want %v", analysis.Breakevens, tt.breakevens)
+            }
+            for i, want := range tt.breakevens {
+                if math.Abs(analysis.Breakevens[i]-want) > 1e-6 {
+                    t.Errorf("breakevens = %v, want %v", analysis.Breakevens, tt.breakevens)
+                }
+            }
+            if analysis.Expiration != testFront {
+                t.Errorf("expiration = %s, want %s", analysis.Expiration, testFront)
+            }
+        })
+    }
+}
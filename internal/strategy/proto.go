@@ -0,0 +1,73 @@
+package strategy
+
+import (
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SpreadMessage carries the live quote of a spread on its underlying to
+// stream clients
+type SpreadMessage struct {
+    Type   string      `json:"type"` // "spread"
+    Symbol string      `json:"symbol"`
+    Spread SpreadQuote `json:"spread"`
+}
+
+// NewSpreadMessage wraps a spread quote for streaming
+func NewSpreadMessage(q SpreadQuote) SpreadMessage {
+    return SpreadMessage{Type: "spread", Symbol: q.Underlying, Spread: q}
+}
+
+// ServerMessage returns the message in its protobuf form
+func (m SpreadMessage) ServerMessage() *optionsv1.ServerMessage {
+    return &optionsv1.ServerMessage{Message: &optionsv1.ServerMessage_Spread{Spread: m.Spread.Proto()}}
+}
+
+// Proto converts the spread quote to its protobuf form
+func (q SpreadQuote) Proto() *optionsv1.SpreadQuote {
+    return &optionsv1.SpreadQuote{
+        Id:              q.ID,
+        Underlying:      q.Underlying,
+        UnderlyingPrice: q.UnderlyingPrice,
+        LastUpdated:     timestamppb.New(q.Updated),
+        Legs:            ProtoLegs(q.Legs),
+        Price:           q.Price.Proto(),
+        Greeks:          q.Greeks.Proto(),
+        Entry:           q.Entry,
+        Pl:              q.PL,
+        Pop:             q.POP,
+    }
+}
+
+// ProtoLegs converts resolved legs to their protobuf form
+func ProtoLegs(legs []LegQuote) []*optionsv1.LegQuote {
+    out := make([]*optionsv1.LegQuote, len(legs))
+    for i, leg := range legs {
+        out[i] = &optionsv1.LegQuote{
+            Leg: &optionsv1.Leg{Contract: leg.Contract, Quantity: int64(leg.Quantity)},
+            Bid: leg.Bid,
+            Ask: leg.Ask,
+            Mid: leg.Mid,
+        }
+        if leg.Option != nil {
+            out[i].Option = leg.Option.Proto()
+        }
+    }
+    return out
+}
+
+// Proto converts the price to its protobuf form
+func (p Price) Proto() *optionsv1.SpreadPrice {
+    return &optionsv1.SpreadPrice{Bid: p.Bid, Mid: p.Mid, Ask: p.Ask}
+}
+
+// Proto converts the Greeks to their protobuf form
+func (g Greeks) Proto() *optionsv1.PositionGreeks {
+    return &optionsv1.PositionGreeks{
+        Delta: g.Delta,
+        Gamma: g.Gamma,
+        Theta: g.Theta,
+        Vega:  g.Vega,
+        Rho:   g.Rho,
+    }
+}
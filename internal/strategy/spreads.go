@@ -0,0 +1,238 @@
+package strategy
+
+import (
+    "fmt"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// Spread is a position streamed as one instrument
+type Spread struct {
+    Position
+    Entry *float64 `json:"entry,omitempty"` // opening price in dollars, positive for a debit; default the first mid
+}
+
+// ID identifies a spread by its underlying, legs and entry, independent of
+// leg order
+func (s Spread) ID() string {
+    legs := make([]string, len(s.Legs))
+    for i, leg := range s.Legs {
+        legs[i] = leg.Contract + "x" + strconv.Itoa(leg.Quantity)
+    }
+    sort.Strings(legs)
+    id := strings.ToUpper(s.Underlying) + ":" + strings.Join(legs, ",")
+    if s.Entry != nil {
+        id += "@" + strconv.FormatFloat(*s.Entry, 'f', -1, 64)
+    }
+    return id
+}
+
+// SpreadQuote is the live market of a spread
+type SpreadQuote struct {
+    ID              string     `json:"id"`
+    Underlying      string     `json:"underlying"`
+    UnderlyingPrice float64    `json:"underlyingPrice"`
+    Updated         time.Time  `json:"lastUpdated"`
+    Legs            []LegQuote `json:"legs"`
+    Price           Price      `json:"price"`
+    Greeks          Greeks     `json:"greeks"`
+    Entry           float64    `json:"entry"`
+    PL              float64    `json:"pl"`  // mid against the entry, in dollars
+    POP             float64    `json:"pop"` // probability of a profit against the entry at the first expiration
+}
+
+// liveSpread is a spread with at least one subscriber
+type liveSpread struct {
+    spread    Spread
+    entry     float64
+    quoted    bool
+    requested []string // legs requested from the feed for the spread
+}
+
+// Spreads streams quotes of the spreads clients subscribe to as their
+// underlying's chain updates. Legs missing from the chain are requested
+// from the feed until the spread is removed.
+type Spreads struct {
+    chains      *store.ChainStore
+    valuer      Valuer
+    publish     func(SpreadQuote)
+    subscribe   func(contracts []string)
+    unsubscribe func(contracts []string)
+
+    mu      sync.Mutex
+    spreads map[string]*liveSpread
+}
+
+// NewSpreads creates a spread registry that values legs for the
+// probability of profit with the given valuer. Quotes go to publish,
+// contracts missing from the chain to subscribe, and those contracts to
+// unsubscribe once their spread is removed.
+func NewSpreads(chains *store.ChainStore, valuer Valuer, publish func(SpreadQuote), subscribe, unsubscribe func(contracts []string)) *Spreads {
+    return &Spreads{
+        chains:      chains,
+        valuer:      valuer,
+        publish:     publish,
+        subscribe:   subscribe,
+        unsubscribe: unsubscribe,
+        spreads:     make(map[string]*liveSpread),
+    }
+}
+
+// ID validates a spread requested on an underlying and returns the ID its
+// quotes are published under
+func (s *Spreads) ID(underlying string, legs []models.Leg, entry *float64) (string, error) {
+    spread, err := newSpread(underlying, legs, entry)
+    if err != nil {
+        return "", err
+    }
+    return spread.ID(), nil
+}
+
+// newSpread builds and validates a spread of an underlying's contracts
+func newSpread(underlying string, legs []models.Leg, entry *float64) (Spread, error) {
+    spread := Spread{
+        Position: Position{Underlying: strings.ToUpper(underlying), Legs: legs},
+        Entry:    entry,
+    }
+    if err := spread.Validate(); err != nil {
+        return Spread{}, err
+    }
+    for _, leg := range spread.Legs {
+        if leg.Contract == "" {
+            continue
+        }
+        if root := contractRoot(leg.Contract); root != spread.Underlying {
+            return Spread{}, fmt.Errorf("contract %q is not on %s", leg.Contract, spread.Underlying)
+        }
+    }
+    return spread, nil
+}
+
+// Add starts streaming a spread requested on an underlying and returns its
+// ID. The current quote is published straight away when every leg is in
+// the chain.
+func (s *Spreads) Add(underlying string, legs []models.Leg, entry *float64) (string, error) {
+    spread, err := newSpread(underlying, legs, entry)
+    if err != nil {
+        return "", err
+    }
+    id := spread.ID()
+    chain, _ := s.chains.Get(spread.Underlying)
+    missing := missingContracts(chain, spread.Position)
+
+    s.mu.Lock()
+    live, ok := s.spreads[id]
+    if !ok {
+        live = &liveSpread{spread: spread, requested: missing}
+        if spread.Entry != nil {
+            live.entry, live.quoted = *spread.Entry, true
+        }
+        s.spreads[id] = live
+    }
+    s.mu.Unlock()
+
+    if len(missing) > 0 {
+        // Legs are requested once per spread, however many add it
+        if !ok {
+            s.subscribe(missing)
+        }
+        return id, nil
+    }
+    s.quote(id, live, chain)
+    return id, nil
+}
+
+// Remove stops streaming a spread and releases the legs requested for it
+func (s *Spreads) Remove(id string) {
+    s.mu.Lock()
+    live, ok := s.spreads[id]
+    delete(s.spreads, id)
+    s.mu.Unlock()
+
+    if ok && len(live.requested) > 0 {
+        s.unsubscribe(live.requested)
+    }
+}
+
+// Update publishes the quotes of the spreads on a chain's underlying
+func (s *Spreads) Update(chain models.OptionChain) {
+    s.mu.Lock()
+    live := make(map[string]*liveSpread)
+    for id, spread := range s.spreads {
+        if spread.spread.Underlying == chain.Symbol {
+            live[id] = spread
+        }
+    }
+    s.mu.Unlock()
+
+    for id, spread := range live {
+        s.quote(id, spread, chain)
+    }
+}
+
+// quote publishes a spread's quote if all its legs are in the chain
+func (s *Spreads) quote(id string, live *liveSpread, chain models.OptionChain) {
+    legs, err := live.spread.Resolve(chain)
+    if err != nil {
+        return
+    }
+    price := PriceOf(legs)
+
+    s.mu.Lock()
+    if !live.quoted {
+        live.entry, live.quoted = price.Mid, true
+    }
+    entry := live.entry
+    s.mu.Unlock()
+
+    pop, _ := ProbabilityOfProfit(s.valuer, chain.Symbol, legs, chain.Underlying, entry, time.Now())
+    s.publish(SpreadQuote{
+        ID:              id,
+        Underlying:      chain.Symbol,
+        UnderlyingPrice: chain.Underlying,
+        Updated:         chain.Updated,
+        Legs:            legs,
+        Price:           price,
+        Greeks:          GreeksOf(legs),
+        Entry:           entry,
+        PL:              price.Mid - entry,
+        POP:             pop,
+    })
+}
+
+// missingContracts lists the option legs of a position absent from a chain
+func missingContracts(chain models.OptionChain, position Position) []string {
+    listed := make(map[string]bool, len(chain.Calls)+len(chain.Puts))
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for _, option := range options {
+            listed[option.Symbol] = true
+        }
+    }
+    var missing []string
+    for _, leg := range position.Legs {
+        if leg.Contract != "" && !listed[leg.Contract] {
+            missing = append(missing, leg.Contract)
+        }
+    }
+    return missing
+}
+
+// contractPattern matches streamer option symbols such as ".SPY240119C450"
+var contractPattern = regexp.MustCompile(`^\.([A-Z0-9/]+?)(\d{6})([CP])(\d+(?:\.\d+)?)$`)
+
+// contractRoot returns the underlying of a streamer option symbol, or ""
+// if it is not one
+func contractRoot(symbol string) string {
+    m := contractPattern.FindStringSubmatch(symbol)
+    if m == nil {
+        return ""
+    }
+    return m[1]
+}
@@ -0,0 +1,196 @@
+package strategy
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// multiplier is the number of shares one contract delivers
+const multiplier = 100
+
+// ErrNoChain is returned when no chain is stored for a position's
+// underlying
+var ErrNoChain = errors.New("no chain for underlying")
+
+// Position is a set of legs on one underlying
+type Position struct {
+    Underlying string       `json:"underlying"`
+    Legs       []models.Leg `json:"legs"`
+}
+
+// LegQuote is a leg resolved against the chain, with its market per share
+type LegQuote struct {
+    models.Leg
+    Option *models.OptionData `json:"option,omitempty"` // nil for stock
+    Bid    float64            `json:"bid"`
+    Ask    float64            `json:"ask"`
+    Mid    float64            `json:"mid"`
+}
+
+// Price is the market of a whole position in dollars, positive for a debit
+// and negative for a credit. Buying the position pays Ask (long legs at
+// their asks, short legs at their bids); selling it receives Bid.
+type Price struct {
+    Bid float64 `json:"bid"`
+    Mid float64 `json:"mid"`
+    Ask float64 `json:"ask"`
+}
+
+// Greeks are the sensitivities of a whole position: delta and gamma in
+// shares, theta, vega and rho in dollars per day, vol point and rate point
+type Greeks struct {
+    Delta float64 `json:"delta"`
+    Gamma float64 `json:"gamma"`
+    Theta float64 `json:"theta"`
+    Vega  float64 `json:"vega"`
+    Rho   float64 `json:"rho"`
+}
+
+// Validate checks that a position has legs and no empty quantities
+func (p Position) Validate() error {
+    if p.Underlying == "" {
+        return fmt.Errorf("missing underlying")
+    }
+    if len(p.Legs) == 0 {
+        return fmt.Errorf("position has no legs")
+    }
+    for _, leg := range p.Legs {
+        if leg.Quantity == 0 {
+            return fmt.Errorf("leg %q has zero quantity", leg.Contract)
+        }
+    }
+    return nil
+}
+
+// Resolve looks up the contracts of a position's legs in its chain. Stock
+// legs are quoted at the underlying price.
+func (p Position) Resolve(chain models.OptionChain) ([]LegQuote, error) {
+    contracts := make(map[string]*models.OptionData, len(chain.Calls)+len(chain.Puts))
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for i := range options {
+            contracts[options[i].Symbol] = &options[i]
+        }
+    }
+
+    quotes := make([]LegQuote, 0, len(p.Legs))
+    for _, leg := range p.Legs {
+        quote := LegQuote{Leg: leg}
+        if leg.Contract == "" {
+            quote.Bid, quote.Ask, quote.Mid = chain.Underlying, chain.Underlying, chain.Underlying
+        } else {
+            option, ok := contracts[leg.Contract]
+            if !ok {
+                return nil, fmt.Errorf("unknown contract %q for %s", leg.Contract, p.Underlying)
+            }
+            copied := *option
+            quote.Option = &copied
+            quote.Bid, quote.Ask, quote.Mid = option.Bid, option.Ask, mid(*option)
+        }
+        quotes = append(quotes, quote)
+    }
+    return quotes, nil
+}
+
+// Size is the number of shares a leg's quantity stands for
+func (q LegQuote) Size() float64 {
+    if q.Option == nil {
+        return float64(q.Quantity)
+    }
+    return float64(q.Quantity) * multiplier
+}
+
+// PriceOf returns the market of a resolved position
+func PriceOf(legs []LegQuote) Price {
+    var price Price
+    for _, leg := range legs {
+        size := leg.Size()
+        price.Mid += size * leg.Mid
+        if leg.Quantity > 0 {
+            price.Ask += size * leg.Ask
+            price.Bid += size * leg.Bid
+        } else {
+            price.Ask += size * leg.Bid
+            price.Bid += size * leg.Ask
+        }
+    }
+    return price
+}
+
+// GreeksOf returns the aggregate Greeks of a resolved position
+func GreeksOf(legs []LegQuote) Greeks {
+    var g Greeks
+    for _, leg := range legs {
+        size := leg.Size()
+        if leg.Option == nil {
+            g.Delta += size
+            continue
+        }
+        g.Delta += size * leg.Option.Delta
+        g.Gamma += size * leg.Option.Gamma
+        g.Theta += size * leg.Option.Theta
+        g.Vega += size * leg.Option.Vega
+        g.Rho += size * leg.Option.Rho
+    }
+    return g
+}
+
+// ProbabilityOfProfit is the probability that a resolved position opened
+// at entry, in dollars, shows a profit at its first expiration, with legs
+// expiring later valued by the valuer. The price is taken as lognormal at
+// the option legs' implied vols, weighted by their vega in the position.
+// It reports false for positions without options or vols.
+func ProbabilityOfProfit(valuer Valuer, symbol string, legs []LegQuote, spot, entry float64, now time.Time) (float64, bool) {
+    var expiration string
+    var vegaVols, vegas, vols float64
+    var n int
+    for _, leg := range legs {
+        if leg.Option == nil {
+            continue
+        }
+        if expiration == "" || leg.Option.Expiration < expiration {
+            expiration = leg.Option.Expiration
+        }
+        vol := leg.Option.ImpliedVol
+        if vol <= 0 {
+            vol = leg.Option.MidIV
+        }
+        if vol > 0 {
+            vega := math.Abs(leg.Size() * leg.Option.Vega)
+            vegaVols += vega * vol
+            vegas += vega
+            vols += vol
+            n++
+        }
+    }
+    if n == 0 {
+        return 0, false
+    }
+    // Legs without a vega count equally
+    vol := vols / float64(n)
+    if vegas > 0 {
+        vol = vegaVols / vegas
+    }
+    expiry, err := analytics.ExpirationTime(expiration)
+    if err != nil {
+        return 0, false
+    }
+
+    payoff := func(price float64) float64 {
+        value := -entry
+        for _, leg := range legs {
+            if leg.Option == nil {
+                value += leg.Size() * price
+            } else {
+                value += leg.Size() * valuer.Value(symbol, *leg.Option, price, expiry)
+            }
+        }
+        return value
+    }
+    d := valuer.Distribution(symbol, spot, vol, expiration, now)
+    return d.ProfitProbability(payoff), true
+}
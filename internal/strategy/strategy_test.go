@@ -0,0 +1,91 @@
+package strategy
+
+import (
+    "math"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// intrinsicValuer values contracts at their intrinsic value and forecasts
+// the underlying with no drift
+type intrinsicValuer struct{}
+
+func (intrinsicValuer) Value(symbol string, option models.OptionData, spot float64, at time.Time) float64 {
+    if option.Type == "call" {
+        return math.Max(spot-option.Strike, 0)
+    }
+    return math.Max(option.Strike-spot, 0)
+}
+
+func (intrinsicValuer) Distribution(symbol string, spot, vol float64, expiration string, now time.Time) analytics.Lognormal {
+    years, _ := analytics.YearsToExpiration(expiration, now)
+    return analytics.Lognormal{Spot: spot, T: years, Vol: vol}
+}
+
+func testLeg(optionType string, strike float64, quantity int) LegQuote {
+    return LegQuote{
+        Leg: models.Leg{Quantity: quantity},
+        Option: &models.OptionData{
+            Type:       optionType,
+            Strike:     strike,
+            Expiration: "2026-12-18",
+            ImpliedVol: 0.25,
+            Vega:       0.1,
+        },
+    }
+}
+
+func TestProbabilityOfProfit(t *testing.T) {
+    now := time.Date(2026, 9, 18, 14, 0, 0, 0, time.UTC)
+    d := intrinsicValuer{}.Distribution("SPY", 100, 0.25, "2026-12-18", now)
+
+    tests := []struct {
+        name  string
+        legs  []LegQuote
+        entry float64
+        want  float64
+    }{
+        {
+            name:  "long call vertical breaks even above the long strike",
+            legs:  []LegQuote{testLeg("call", 100, 1), testLeg("call", 110, -1)},
+            entry: 400,
+            want:  d.Above(104),
+        },
+        {
+            name:  "short put keeps the credit above the breakeven",
+            legs:  []LegQuote{testLeg("put", 95, -1)},
+            entry: -200,
+            want:  d.Above(93),
+        },
+        {
+            name: "iron condor profits between its breakevens",
+            legs: []LegQuote{
+                testLeg("put", 85, 1), testLeg("put", 90, -1),
+                testLeg("call", 110, -1), testLeg("call", 115, 1),
+            },
+            entry: -150,
+            want:  d.Above(88.5) - d.Above(111.5),
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, ok := ProbabilityOfProfit(intrinsicValuer{}, "SPY", tt.legs, 100, tt.entry, now)
+            if !ok {
+                t.Fatal("no probability for option legs")
+            }
+            if math.Abs(got-tt.want) > 0.005 {
+                t.Errorf("POP = %.4f, want %.4f", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestProbabilityOfProfitStockOnly(t *testing.T) {
+    legs := []LegQuote{{Leg: models.Leg{Quantity: 100}}}
+    if _, ok := ProbabilityOfProfit(intrinsicValuer{}, "SPY", legs, 100, 10000, time.Now()); ok {
+        t.Error("probability for a position without option legs")
+    }
+}
@@ -0,0 +1,406 @@
+package strategy
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// Template is a named multi-leg strategy
+type Template string
+
+const (
+    Vertical   Template = "vertical"    // short anchor, long wing further out
+    Strangle   Template = "strangle"    // short call and put at the anchors
+    Straddle   Template = "straddle"    // short call and put at the money
+    IronCondor Template = "iron_condor" // short strangle with long wings
+    Condor     Template = "condor"      // iron condor strikes in one option type, long wings
+    Butterfly  Template = "butterfly"   // long wings around two short at the money
+    Calendar   Template = "calendar"    // short front, long back at the same strike
+    Diagonal   Template = "diagonal"    // short front at the anchor, long back a width in the money
+    JadeLizard Template = "jade_lizard" // short put, short call spread
+    Ratio      Template = "ratio"       // long one near the money, short several a width out
+)
+
+// templates holds the direction each template is built in, which a
+// selection can reverse, and its default anchor delta
+var templates = map[Template]struct {
+    direction string
+    delta     float64
+}{
+    Vertical:   {"credit", 0.30},
+    Strangle:   {"credit", 0.16},
+    Straddle:   {"credit", 0.50},
+    IronCondor: {"credit", 0.16},
+    Condor:     {"debit", 0.16},
+    Butterfly:  {"debit", 0.50},
+    Calendar:   {"debit", 0.50},
+    Diagonal:   {"debit", 0.30},
+    JadeLizard: {"credit", 0.30},
+    Ratio:      {"credit", 0.50},
+}
+
+// ParseTemplate parses a template name
+func ParseTemplate(name string) (Template, error) {
+    template := Template(strings.ToLower(name))
+    if _, ok := templates[template]; !ok {
+        return "", fmt.Errorf("unknown strategy template: %q", name)
+    }
+    return template, nil
+}
+
+// Selection describes a templated position to pick from the chain. Anchor
+// strikes are chosen by premium if set, else by offset from spot if set,
+// else by delta; wings sit the nearest listed strike at least Width beyond
+// their anchor.
+type Selection struct {
+    Underlying string   `json:"underlying"`
+    Template   Template `json:"template"`
+    Side       string   `json:"side,omitempty"`      // "call" (default) or "put", for one-type templates
+    Direction  string   `json:"direction,omitempty"` // "credit" or "debit", default the template's own
+
+    DTE     float64 `json:"dte,omitempty"`     // target days to the front expiration, default 45
+    BackDTE float64 `json:"backDte,omitempty"` // target days to the back expiration, default DTE + 30
+
+    Delta   float64 `json:"delta,omitempty"`   // target absolute delta of the anchors
+    Offset  float64 `json:"offset,omitempty"`  // anchor distance from spot in strike points
+    Premium float64 `json:"premium,omitempty"` // target anchor mid price per share
+    Width   float64 `json:"width,omitempty"`   // wing distance in strike points, default the next strike
+
+    Quantity int `json:"quantity,omitempty"` // default 1
+    Ratio    int `json:"ratio,omitempty"`    // short contracts per long in ratio spreads, default 2
+}
+
+// Select picks the legs of a templated position from a chain
+func Select(chain models.OptionChain, sel Selection, now time.Time) (Position, error) {
+    template, err := ParseTemplate(string(sel.Template))
+    if err != nil {
+        return Position{}, err
+    }
+    defaults := templates[template]
+    if sel.Side == "" {
+        sel.Side = "call"
+    }
+    if sel.Direction == "" {
+        sel.Direction = defaults.direction
+    }
+    if sel.DTE == 0 {
+        sel.DTE = 45
+    }
+    if sel.BackDTE == 0 {
+        sel.BackDTE = sel.DTE + 30
+    }
+    if sel.Delta == 0 {
+        sel.Delta = defaults.delta
+    }
+    if sel.Quantity == 0 {
+        sel.Quantity = 1
+    }
+    if sel.Ratio == 0 {
+        sel.Ratio = 2
+    }
+    switch {
+    case sel.Side != "call" && sel.Side != "put":
+        return Position{}, fmt.Errorf("invalid side: %q", sel.Side)
+    case sel.Direction != "credit" && sel.Direction != "debit":
+        return Position{}, fmt.Errorf("invalid direction: %q", sel.Direction)
+    case sel.DTE < 0 || sel.BackDTE <= sel.DTE:
+        return Position{}, fmt.Errorf("invalid days to expiration: %v and %v", sel.DTE, sel.BackDTE)
+    case sel.Delta < 0 || sel.Delta >= 1 || sel.Offset < 0 || sel.Premium < 0 || sel.Width < 0:
+        return Position{}, fmt.Errorf("invalid selection constraints")
+    case sel.Quantity < 0 || sel.Ratio < 1:
+        return Position{}, fmt.Errorf("invalid quantity or ratio")
+    }
+
+    p := newPicker(chain, sel, now)
+    front, err := p.expiration(sel.DTE, "")
+    if err != nil {
+        return Position{}, err
+    }
+
+    q := sel.Quantity
+    var legs []models.Leg
+    add := func(option models.OptionData, quantity int) {
+        legs = append(legs, models.Leg{Contract: option.Symbol, Quantity: quantity})
+    }
+    // out is +1 for calls and -1 for puts: the direction away from spot
+    out := func(side string) float64 {
+        if side == "put" {
+            return -1
+        }
+        return 1
+    }
+
+    switch template {
+    case Vertical:
+        short, err := p.anchor(front, sel.Side)
+        if err != nil {
+            return Position{}, err
+        }
+        long, err := p.wing(front, sel.Side, short.Strike, out(sel.Side))
+        if err != nil {
+            return Position{}, err
+        }
+        add(short, -q)
+        add(long, q)
+    case Strangle, IronCondor, JadeLizard:
+        put, err := p.anchor(front, "put")
+        if err != nil {
+            return Position{}, err
+        }
+        call, err := p.anchor(front, "call")
+        if err != nil {
+            return Position{}, err
+        }
+        add(put, -q)
+        add(call, -q)
+        if template != Strangle {
+            callWing, err := p.wing(front, "call", call.Strike, 1)
+            if err != nil {
+                return Position{}, err
+            }
+            add(callWing, q)
+        }
+        if template == IronCondor {
+            putWing, err := p.wing(front, "put", put.Strike, -1)
+            if err != nil {
+                return Position{}, err
+            }
+            add(putWing, q)
+        }
+    case Straddle:
+        call, err := p.anchor(front, "call")
+        if err != nil {
+            return Position{}, err
+        }
+        put, err := p.at(front, "put", call.Strike)
+        if err != nil {
+            return Position{}, err
+        }
+        add(call, -q)
+        add(put, -q)
+    case Condor:
+        lowAnchor, err := p.anchor(front, "put")
+        if err != nil {
+            return Position{}, err
+        }
+        highAnchor, err := p.anchor(front, "call")
+        if err != nil {
+            return Position{}, err
+        }
+        low, err := p.at(front, sel.Side, lowAnchor.Strike)
+        if err != nil {
+            return Position{}, err
+        }
+        high, err := p.at(front, sel.Side, highAnchor.Strike)
+        if err != nil {
+            return Position{}, err
+        }
+        lowWing, err := p.wing(front, sel.Side, low.Strike, -1)
+        if err != nil {
+            return Position{}, err
+        }
+        highWing, err := p.wing(front, sel.Side, high.Strike, 1)
+        if err != nil {
+            return Position{}, err
+        }
+        add(lowWing, q)
+        add(low, -q)
+        add(high, -q)
+        add(highWing, q)
+    case Butterfly:
+        body, err := p.anchor(front, sel.Side)
+        if err != nil {
+            return Position{}, err
+        }
+        lower, err := p.wing(front, sel.Side, body.Strike, -1)
+        if err != nil {
+            return Position{}, err
+        }
+        upper, err := p.wing(front, sel.Side, body.Strike, 1)
+        if err != nil {
+            return Position{}, err
+        }
+        add(lower, q)
+        add(body, -2*q)
+        add(upper, q)
+    case Calendar, Diagonal:
+        back, err := p.expiration(sel.BackDTE, front)
+        if err != nil {
+            return Position{}, err
+        }
+        short, err := p.anchor(front, sel.Side)
+        if err != nil {
+            return Position{}, err
+        }
+        var long models.OptionData
+        if template == Calendar {
+            long, err = p.at(back, sel.Side, short.Strike)
+        } else {
+            long, err = p.wing(back, sel.Side, short.Strike, -out(sel.Side))
+        }
+        if err != nil {
+            return Position{}, err
+        }
+        add(short, -q)
+        add(long, q)
+    case Ratio:
+        long, err := p.anchor(front, sel.Side)
+        if err != nil {
+            return Position{}, err
+        }
+        short, err := p.wing(front, sel.Side, long.Strike, out(sel.Side))
+        if err != nil {
+            return Position{}, err
+        }
+        add(long, q)
+        add(short, -sel.Ratio*q)
+    }
+
+    if sel.Direction != defaults.direction {
+        for i := range legs {
+            legs[i].Quantity = -legs[i].Quantity
+        }
+    }
+    return Position{Underlying: chain.Symbol, Legs: legs}, nil
+}
+
+// picker selects contracts from a chain under a selection's constraints
+type picker struct {
+    sel       Selection
+    spot      float64
+    now       time.Time
+    contracts map[string]map[string][]models.OptionData // by expiration and type, by strike
+}
+
+func newPicker(chain models.OptionChain, sel Selection, now time.Time) picker {
+    p := picker{
+        sel:       sel,
+        spot:      chain.Underlying,
+        now:       now,
+        contracts: make(map[string]map[string][]models.OptionData),
+    }
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for _, option := range options {
+            if p.contracts[option.Expiration] == nil {
+                p.contracts[option.Expiration] = make(map[string][]models.OptionData)
+            }
+            p.contracts[option.Expiration][option.Type] = append(p.contracts[option.Expiration][option.Type], option)
+        }
+    }
+    for _, byType := range p.contracts {
+        for _, options := range byType {
+            sort.Slice(options, func(i, j int) bool { return options[i].Strike < options[j].Strike })
+        }
+    }
+    return p
+}
+
+// expiration returns the listed expiration nearest a number of days out,
+// after the given one if set
+func (p picker) expiration(days float64, after string) (string, error) {
+    best, distance := "", math.Inf(1)
+    for expiration := range p.contracts {
+        if expiration <= after {
+            continue
+        }
+        t, err := analytics.YearsToExpiration(expiration, p.now)
+        if err != nil || t <= 0 {
+            continue
+        }
+        if d := math.Abs(t*365 - days); d < distance || (d == distance && expiration < best) {
+            best, distance = expiration, d
+        }
+    }
+    if best == "" {
+        return "", fmt.Errorf("no expiration near %v days", days)
+    }
+    return best, nil
+}
+
+// anchor picks the contract of an expiration and type that best meets the
+// premium, offset or delta constraint
+func (p picker) anchor(expiration, optionType string) (models.OptionData, error) {
+    options := p.contracts[expiration][optionType]
+    sign := 1.0
+    if optionType == "put" {
+        sign = -1
+    }
+
+    var score func(models.OptionData) (float64, bool)
+    switch {
+    case p.sel.Premium > 0:
+        score = func(o models.OptionData) (float64, bool) {
+            otm := sign*(o.Strike-p.spot) >= 0
+            return math.Abs(mid(o) - p.sel.Premium), otm && mid(o) > 0
+        }
+    case p.sel.Offset > 0:
+        target := p.spot + sign*p.sel.Offset
+        score = func(o models.OptionData) (float64, bool) {
+            return math.Abs(o.Strike - target), true
+        }
+    default:
+        score = func(o models.OptionData) (float64, bool) {
+            return math.Abs(math.Abs(o.Delta) - p.sel.Delta), o.Delta != 0
+        }
+    }
+
+    best, found, distance := models.OptionData{}, false, math.Inf(1)
+    for _, option := range options {
+        if d, ok := score(option); ok && d < distance {
+            best, found, distance = option, true, d
+        }
+    }
+    if !found {
+        return best, fmt.Errorf("no %s on %s meets the constraints", optionType, expiration)
+    }
+    return best, nil
+}
+
+// wing picks the contract at least Width beyond a strike in a direction
+// (+1 up, -1 down), or the next listed strike without a width
+func (p picker) wing(expiration, optionType string, strike, direction float64) (models.OptionData, error) {
+    options := p.contracts[expiration][optionType]
+    if direction < 0 {
+        for i := len(options) - 1; i >= 0; i-- {
+            if options[i].Strike < strike && strike-options[i].Strike >= p.sel.Width-1e-9 {
+                return options[i], nil
+            }
+        }
+    } else {
+        for _, option := range options {
+            if option.Strike > strike && option.Strike-strike >= p.sel.Width-1e-9 {
+                return option, nil
+            }
+        }
+    }
+    return models.OptionData{}, fmt.Errorf("no %s wing %v from %v on %s", optionType, p.sel.Width, strike, expiration)
+}
+
+// at picks the contract of an expiration and type nearest a strike
+func (p picker) at(expiration, optionType string, strike float64) (models.OptionData, error) {
+    best, found, distance := models.OptionData{}, false, math.Inf(1)
+    for _, option := range p.contracts[expiration][optionType] {
+        if d := math.Abs(option.Strike - strike); d < distance {
+            best, found, distance = option, true, d
+        }
+    }
+    if !found {
+        return best, fmt.Errorf("no %s near %v on %s", optionType, strike, expiration)
+    }
+    return best, nil
+}
+
+// mid is a contract's mid price, or its last price without a two-sided
+// quote
+func mid(option models.OptionData) float64 {
+    if option.Bid > 0 && option.Ask > 0 {
+        return (option.Bid + option.Ask) / 2
+    }
+    return option.LastPrice
+}
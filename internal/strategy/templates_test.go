@@ -0,0 +1,128 @@
+package strategy
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+func TestSelect(t *testing.T) {
+    front := func(optionType string, strike float64, quantity int) models.Leg {
+        return models.Leg{Contract: testContract(testFront, optionType, strike), Quantity: quantity}
+    }
+    back := func(optionType string, strike float64, quantity int) models.Leg {
+        return models.Leg{Contract: testContract(testBack, optionType, strike), Quantity: quantity}
+    }
+
+    // Call deltas run .52 at 100, .36 at 105, .22 at 110 and .12 at 115;
+    // put deltas -.32 at 95, -.18 at 90 and -.10 at 85
+    tests := []struct {
+        name string
+        sel  Selection
+        want []models.Leg
+    }{
+        {
+            name: "call vertical at 30 delta",
+            sel:  Selection{Template: Vertical},
+            want: []models.Leg{front("call", 105, -1), front("call", 110, 1)},
+        },
+        {
+            name: "put vertical bought as a debit",
+            sel:  Selection{Template: Vertical, Side: "put", Direction: "debit"},
+            want: []models.Leg{front("put", 95, 1), front("put", 90, -1)},
+        },
+        {
+            name: "vertical with a width",
+            sel:  Selection{Template: Vertical, Width: 10},
+            want: []models.Leg{front("call", 105, -1), front("call", 115, 1)},
+        },
+        {
+            name: "strangle at 16 delta",
+            sel:  Selection{Template: Strangle},
+            want: []models.Leg{front("put", 90, -1), front("call", 115, -1)},
+        },
+        {
+            name: "straddle",
+            sel:  Selection{Template: Straddle, Quantity: 2},
+            want: []models.Leg{front("call", 100, -2), front("put", 100, -2)},
+        },
+        {
+            name: "iron condor",
+            sel:  Selection{Template: IronCondor},
+            want: []models.Leg{front("put", 90, -1), front("call", 115, -1), front("call", 120, 1), front("put", 85, 1)},
+        },
+        {
+            name: "call condor",
+            sel:  Selection{Template: Condor},
+            want: []models.Leg{front("call", 85, 1), front("call", 90, -1), front("call", 115, -1), front("call", 120, 1)},
+        },
+        {
+            name: "butterfly",
+            sel:  Selection{Template: Butterfly},
+            want: []models.Leg{front("call", 95, 1), front("call", 100, -2), front("call", 105, 1)},
+        },
+        {
+            name: "calendar",
+            sel:  Selection{Template: Calendar},
+            want: []models.Leg{front("call", 100, -1), back("call", 100, 1)},
+        },
+        {
+            name: "diagonal",
+            sel:  Selection{Template: Diagonal},
+            want: []models.Leg{front("call", 105, -1), back("call", 100, 1)},
+        },
+        {
+            name: "jade lizard",
+            sel:  Selection{Template: JadeLizard},
+            want: []models.Leg{front("put", 95, -1), front("call", 105, -1), front("call", 110, 1)},
+        },
+        {
+            name: "ratio",
+            sel:  Selection{Template: Ratio, Ratio: 3},
+            want: []models.Leg{front("call", 100, 1), front("call", 105, -3)},
+        },
+        {
+            name: "anchor by offset",
+            sel:  Selection{Template: Strangle, Offset: 8},
+            want: []models.Leg{front("put", 90, -1), front("call", 110, -1)},
+        },
+        {
+            name: "anchor by premium",
+            sel:  Selection{Template: Strangle, Premium: 1},
+            want: []models.Leg{front("put", 90, -1), front("call", 115, -1)},
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            tt.sel.Underlying = "SPY"
+            position, err := Select(testChain(), tt.sel, testNow)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if position.Underlying != "SPY" || !reflect.DeepEqual(position.Legs, tt.want) {
+                t.Errorf("legs = %v, want %v", position.Legs, tt.want)
+            }
+        })
+    }
+}
+
+func TestSelectRejects(t *testing.T) {
+    tests := []struct {
+        name string
+        sel  Selection
+    }{
+        {"unknown template", Selection{Template: "collar"}},
+        {"invalid side", Selection{Template: Vertical, Side: "both"}},
+        {"back before front", Selection{Template: Calendar, DTE: 60, BackDTE: 30}},
+        {"delta out of range", Selection{Template: Strangle, Delta: 1.5}},
+        {"no wing at the width", Selection{Template: IronCondor, Width: 10}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := Select(testChain(), tt.sel, testNow); err == nil {
+                t.Error("selected a position")
+            }
+        })
+    }
+}
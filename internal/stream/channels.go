@@ -0,0 +1,197 @@
+package stream
+
+import (
+    "fmt"
+    "log"
+    "strings"
+
+    "github.com/gorilla/websocket"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+)
+
+// Channels a client can subscribe to. The chain channel streams a snapshot
+// followed by deltas; the other channels republish a whole message for a
+// symbol whenever its producer has a new one. Spread channel messages are
+// keyed by spread ID and screen channel messages by screen name rather
+// than symbol; the portfolio is published under its benchmark.
+const (
+    ChannelChain     = "chain"
+    ChannelSurface   = "surface"
+    ChannelSpread    = "spread"
+    ChannelScreen    = "screen"
+    ChannelUnusual   = "unusual"
+    ChannelPortfolio = "portfolio"
+)
+
+// feedChannels are the channels served by Publish
+var feedChannels = map[string]bool{
+    ChannelSurface:   true,
+    ChannelSpread:    true,
+    ChannelScreen:    true,
+    ChannelUnusual:   true,
+    ChannelPortfolio: true,
+}
+
+// FeedMessage is a message published on a feed channel. Producers define
+// their own messages: JSON and MessagePack clients receive them as they
+// are, and protobuf clients receive their ServerMessage form.
+type FeedMessage interface {
+    ServerMessage() *optionsv1.ServerMessage
+}
+
+// SpreadSource streams the spreads clients subscribe to on the spread
+// channel, publishing their quotes under their IDs until they are removed.
+// ID validates a requested spread without streaming it.
+type SpreadSource interface {
+    ID(underlying string, legs []models.Leg, entry *float64) (string, error)
+    Add(underlying string, legs []models.Leg, entry *float64) (string, error)
+    Remove(id string)
+}
+
+// SetSpreadSource sets the source of the spread channel
+func (m *Manager) SetSpreadSource(source SpreadSource) {
+    m.spreads = source
+}
+
+// feedKey identifies a channel of a symbol
+func feedKey(channel, symbol string) string {
+    return channel + ":" + symbol
+}
+
+// SubscribeFeed sends the client the latest message of a channel for the
+// symbol, if any, and every message published on it from then on
+func (m *Manager) SubscribeFeed(conn *websocket.Conn, channel, symbol string) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    c, ok := m.clients[conn]
+    if !ok {
+        return
+    }
+    key := feedKey(channel, symbol)
+    c.feeds[key] = struct{}{}
+    if msg, ok := m.feeds[key]; ok {
+        if err := c.write(msg); err != nil {
+            log.Printf("WebSocket write error: %v", err)
+            m.dropClient(c)
+        }
+    }
+}
+
+// UnsubscribeFeed stops sending a channel of a symbol to the client
+func (m *Manager) UnsubscribeFeed(conn *websocket.Conn, channel, symbol string) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    if c, ok := m.clients[conn]; ok {
+        key := feedKey(channel, symbol)
+        delete(c.feeds, key)
+        m.release(key)
+    }
+}
+
+// release forgets the latest message of a feed once no client is
+// subscribed to it, and stops streaming a spread nobody watches. Callers
+// must hold clientsMux.
+func (m *Manager) release(key string) {
+    if m.subscribed(key) {
+        return
+    }
+    delete(m.feeds, key)
+    if channel, id, _ := strings.Cut(key, ":"); channel == ChannelSpread && m.spreads != nil {
+        m.spreads.Remove(id)
+    }
+}
+
+// subscribed reports whether any client is subscribed to a feed. Callers
+// must hold clientsMux.
+func (m *Manager) subscribed(key string) bool {
+    for _, c := range m.clients {
+        if _, ok := c.feeds[key]; ok {
+            return true
+        }
+    }
+    return false
+}
+
+// FeedSubscribed reports whether any client is subscribed to a channel of
+// the symbol
+func (m *Manager) FeedSubscribed(channel, symbol string) bool {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    return m.subscribed(feedKey(channel, symbol))
+}
+
+// Publish sends a message to every client subscribed to a channel of the
+// symbol and keeps it for clients that subscribe later. Spread quotes are
+// only kept while the spread has a subscriber, as clients choose their keys.
+func (m *Manager) Publish(channel, symbol string, msg FeedMessage) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    key := feedKey(channel, symbol)
+    m.feeds[key] = msg
+    for _, c := range m.clients {
+        if _, ok := c.feeds[key]; !ok {
+            continue
+        }
+        if err := c.write(msg); err != nil {
+            log.Printf("WebSocket write error: %v", err)
+            m.dropClient(c)
+        }
+    }
+    if channel == ChannelSpread {
+        m.release(key)
+    }
+}
+
+// handleFeedMessage processes a subscribe or unsubscribe request for a
+// channel other than the chain
+func (m *Manager) handleFeedMessage(conn *websocket.Conn, msg ClientMessage, symbol string) {
+    if !feedChannels[msg.Channel] {
+        m.sendError(conn, fmt.Sprintf("unknown channel: %q", msg.Channel))
+        return
+    }
+    if msg.Channel == ChannelSpread {
+        m.handleSpreadMessage(conn, msg, symbol)
+        return
+    }
+    switch msg.Type {
+    case MessageSubscribe, MessageResync:
+        m.SubscribeFeed(conn, msg.Channel, symbol)
+    case MessageUnsubscribe:
+        m.UnsubscribeFeed(conn, msg.Channel, symbol)
+    default:
+        m.sendError(conn, fmt.Sprintf("unknown message type: %q", msg.Type))
+    }
+}
+
+// handleSpreadMessage subscribes a client to a spread, which the spread
+// source starts streaming, or unsubscribes it
+func (m *Manager) handleSpreadMessage(conn *websocket.Conn, msg ClientMessage, symbol string) {
+    if m.spreads == nil {
+        m.sendError(conn, "spreads are not available")
+        return
+    }
+    id, err := m.spreads.ID(symbol, msg.Legs, msg.Entry)
+    if err != nil {
+        m.sendError(conn, fmt.Sprintf("invalid spread: %v", err))
+        return
+    }
+
+    switch msg.Type {
+    case MessageSubscribe, MessageResync:
+        // Subscribe first so the source never sees the spread unwatched
+        m.SubscribeFeed(conn, ChannelSpread, id)
+        if _, err := m.spreads.Add(symbol, msg.Legs, msg.Entry); err != nil {
+            m.UnsubscribeFeed(conn, ChannelSpread, id)
+            m.sendError(conn, fmt.Sprintf("invalid spread: %v", err))
+        }
+    case MessageUnsubscribe:
+        m.UnsubscribeFeed(conn, ChannelSpread, id)
+    default:
+        m.sendError(conn, fmt.Sprintf("unknown message type: %q", msg.Type))
+    }
+}
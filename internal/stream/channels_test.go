@@ -0,0 +1,96 @@
+package stream
+
+import (
+    "testing"
+
+    "github.com/gorilla/websocket"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "google.golang.org/protobuf/proto"
+)
+
+// spreadRecorder records the spreads removed from a spread source
+type spreadRecorder struct {
+    removed []string
+}
+
+func (s *spreadRecorder) ID(underlying string, legs []models.Leg, entry *float64) (string, error) {
+    return underlying, nil
+}
+
+func (s *spreadRecorder) Add(underlying string, legs []models.Leg, entry *float64) (string, error) {
+    return underlying, nil
+}
+
+func (s *spreadRecorder) Remove(id string) {
+    s.removed = append(s.removed, id)
+}
+
+// testFeed is a feed message carrying its type
+type testFeed struct {
+    Type string `json:"type"`
+}
+
+func (f testFeed) ServerMessage() *optionsv1.ServerMessage {
+    return &optionsv1.ServerMessage{Message: &optionsv1.ServerMessage_Error{Error: &optionsv1.Error{Message: f.Type}}}
+}
+
+func TestEncodeFeedMessage(t *testing.T) {
+    feed := testFeed{Type: "test"}
+
+    data, err := jsonCodec{}.Encode(feed)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if string(data) != `{"type":"test"}` {
+        t.Errorf("JSON = %s, want the producer's message", data)
+    }
+
+    data, err = protobufCodec{}.Encode(feed)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var msg optionsv1.ServerMessage
+    if err := proto.Unmarshal(data, &msg); err != nil {
+        t.Fatal(err)
+    }
+    if msg.GetError().GetMessage() != "test" {
+        t.Errorf("protobuf = %v, want the producer's server message", &msg)
+    }
+}
+
+func TestReleaseFeedWithLastSubscriber(t *testing.T) {
+    m := NewManager()
+    spreads := &spreadRecorder{}
+    m.SetSpreadSource(spreads)
+    first, second := &websocket.Conn{}, &websocket.Conn{}
+    for _, conn := range []*websocket.Conn{first, second} {
+        m.clients[conn] = &client{conn: conn, feeds: make(map[string]struct{})}
+    }
+    id := "SPY:.SPY260116C500x1"
+    key := feedKey(ChannelSpread, id)
+    m.clients[first].feeds[key] = struct{}{}
+    m.clients[second].feeds[key] = struct{}{}
+    m.feeds[key] = testFeed{}
+
+    m.UnsubscribeFeed(first, ChannelSpread, id)
+    if _, ok := m.feeds[key]; !ok {
+        t.Fatal("feed released while a client is subscribed")
+    }
+    if len(spreads.removed) != 0 {
+        t.Fatalf("spread removed while a client is subscribed: %v", spreads.removed)
+    }
+
+    m.RemoveClient(second)
+    if _, ok := m.feeds[key]; ok {
+        t.Error("feed kept after its last subscriber left")
+    }
+    if len(spreads.removed) != 1 || spreads.removed[0] != id {
+        t.Errorf("removed spreads = %v, want [%s]", spreads.removed, id)
+    }
+
+    m.Publish(ChannelSpread, id, testFeed{})
+    if _, ok := m.feeds[key]; ok {
+        t.Error("spread quote kept without a subscriber")
+    }
+}
@@ -0,0 +1,80 @@
+package stream
+
+import (
+    "bytes"
+    "encoding/json"
+
+    "github.com/gorilla/websocket"
+    "github.com/vmihailenco/msgpack/v5"
+)
+
+// Subprotocols a WebSocket client can request through Sec-WebSocket-Protocol
+// to choose the encoding of its messages. Clients that request none get JSON.
+const (
+    SubprotocolJSON     = "json"
+    SubprotocolMsgpack  = "msgpack"
+    SubprotocolProtobuf = "protobuf"
+)
+
+// Codec encodes server messages and decodes client messages for one wire
+// format
+type Codec interface {
+    // Encode serialises a SnapshotMessage, DeltaMessage or ErrorMessage
+    Encode(v interface{}) ([]byte, error)
+    // Decode parses a client request
+    Decode(data []byte, msg *ClientMessage) error
+    // MessageType is the WebSocket frame type used for encoded messages
+    MessageType() int
+}
+
+// CodecFor returns the codec for a negotiated subprotocol, defaulting to JSON
+func CodecFor(subprotocol string) Codec {
+    switch subprotocol {
+    case SubprotocolMsgpack:
+        return msgpackCodec{}
+    case SubprotocolProtobuf:
+        return protobufCodec{}
+    default:
+        return jsonCodec{}
+    }
+}
+
+// jsonCodec encodes messages as JSON text frames
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(v interface{}) ([]byte, error) {
+    return json.Marshal(v)
+}
+
+func (jsonCodec) Decode(data []byte, msg *ClientMessage) error {
+    return json.Unmarshal(data, msg)
+}
+
+func (jsonCodec) MessageType() int {
+    return websocket.TextMessage
+}
+
+// msgpackCodec encodes messages as MessagePack binary frames. Maps use the
+// same keys as the JSON encoding.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Encode(v interface{}) ([]byte, error) {
+    var buf bytes.Buffer
+    enc := msgpack.NewEncoder(&buf)
+    enc.SetCustomStructTag("json")
+    enc.UseCompactInts(true)
+    if err := enc.Encode(v); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Decode(data []byte, msg *ClientMessage) error {
+    dec := msgpack.NewDecoder(bytes.NewReader(data))
+    dec.SetCustomStructTag("json")
+    return dec.Decode(msg)
+}
+
+func (msgpackCodec) MessageType() int {
+    return websocket.BinaryMessage
+}
@@ -0,0 +1,128 @@
+package stream
+
+import (
+    "fmt"
+    "math/rand"
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// Chain size and churn of the encoding benchmarks, which compare the size
+// and cost of the wire formats:
+//
+//    go test ./internal/stream -run '^$' -bench Encode
+const (
+    benchExpirations = 12
+    benchStrikes     = 80
+    benchChanged     = 0.1 // fraction of contracts changed in the delta
+)
+
+var benchSubprotocols = []string{SubprotocolJSON, SubprotocolMsgpack, SubprotocolProtobuf}
+
+func BenchmarkEncodeSnapshot(b *testing.B) {
+    chain := sampleChain(rand.New(rand.NewSource(1)), "SPY", benchExpirations, benchStrikes)
+    benchmarkEncode(b, SnapshotMessage{
+        Type:   MessageSnapshot,
+        Symbol: chain.Symbol,
+        Seq:    1,
+        Chain:  chain,
+    })
+}
+
+func BenchmarkEncodeDelta(b *testing.B) {
+    r := rand.New(rand.NewSource(1))
+    chain := sampleChain(r, "SPY", benchExpirations, benchStrikes)
+    benchmarkEncode(b, sampleDelta(r, chain, benchChanged))
+}
+
+// benchmarkEncode encodes a message with each codec, reporting the size of
+// the encoded message alongside the cost of encoding it
+func benchmarkEncode(b *testing.B, msg interface{}) {
+    for _, name := range benchSubprotocols {
+        codec := CodecFor(name)
+        b.Run(name, func(b *testing.B) {
+            data, err := codec.Encode(msg)
+            if err != nil {
+                b.Fatalf("encoding with %s: %v", name, err)
+            }
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                if _, err := codec.Encode(msg); err != nil {
+                    b.Fatal(err)
+                }
+            }
+            b.ReportMetric(float64(len(data)), "bytes/msg")
+        })
+    }
+}
+
+// sampleChain builds a chain with calls and puts at every strike of every
+// expiration
+func sampleChain(r *rand.Rand, symbol string, expirations, strikes int) models.OptionChain {
+    now := time.Now()
+    chain := models.OptionChain{
+        Symbol:     symbol,
+        Underlying: 450,
+        Updated:    now,
+    }
+    for e := 0; e < expirations; e++ {
+        expiration := now.AddDate(0, 0, 7*(e+1))
+        for s := 0; s < strikes; s++ {
+            strike := chain.Underlying + float64(s-strikes/2)
+            chain.Calls = append(chain.Calls, sampleOption(r, symbol, expiration, "call", strike))
+            chain.Puts = append(chain.Puts, sampleOption(r, symbol, expiration, "put", strike))
+        }
+    }
+    return chain
+}
+
+func sampleOption(r *rand.Rand, root string, expiration time.Time, optionType string, strike float64) models.OptionData {
+    side := "P"
+    if optionType == "call" {
+        side = "C"
+    }
+    bid := r.Float64() * 20
+    return models.OptionData{
+        Symbol:     fmt.Sprintf(".%s%s%s%g", root, expiration.Format("060102"), side, strike),
+        Strike:     strike,
+        Expiration: expiration.Format("2006-01-02"),
+        Type:       optionType,
+        Bid:        bid,
+        Ask:        bid + 0.05,
+        LastPrice:  bid + 0.02,
+        Volume:     r.Intn(10000),
+        OpenInt:    r.Intn(50000),
+        Delta:      r.Float64(),
+        Gamma:      r.Float64() * 0.1,
+        Theta:      -r.Float64(),
+        Vega:       r.Float64() * 0.5,
+        ImpliedVol: 0.1 + r.Float64()*0.3,
+    }
+}
+
+// sampleDelta changes the quote of a fraction of the contracts, as a burst
+// of Quote events would
+func sampleDelta(r *rand.Rand, chain models.OptionChain, fraction float64) DeltaMessage {
+    delta := DeltaMessage{
+        Type:    MessageDelta,
+        Symbol:  chain.Symbol,
+        Seq:     2,
+        Updated: time.Now(),
+    }
+    for _, option := range append(chain.Calls, chain.Puts...) {
+        if r.Float64() >= fraction {
+            continue
+        }
+        delta.Changes = append(delta.Changes, ContractDelta{
+            Symbol: option.Symbol,
+            Fields: map[string]interface{}{
+                "bid": option.Bid + 0.01,
+                "ask": option.Ask + 0.01,
+            },
+        })
+    }
+    return delta
+}
@@ -0,0 +1,170 @@
+package stream
+
+import (
+    "context"
+    "reflect"
+    "sort"
+    "sync"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// Conflator sits between the market data feed and the Manager. The feed
+// pushes a chain on every event; the Conflator keeps only the latest chain
+// per symbol and publishes it as fast as the consumer can take it, so
+// bursts collapse into a single update and a slow WebSocket client never
+// blocks the DXLink read loop.
+type Conflator struct {
+    publish func(models.OptionChain)
+
+    mu      sync.Mutex
+    pending map[string]models.OptionChain
+    order   []string
+    notify  chan struct{}
+}
+
+// NewConflator creates a conflation stage in front of publish, typically a
+// Manager's BroadcastOptionChain
+func NewConflator(publish func(models.OptionChain)) *Conflator {
+    return &Conflator{
+        publish: publish,
+        pending: make(map[string]models.OptionChain),
+        notify:  make(chan struct{}, 1),
+    }
+}
+
+// Push queues the latest chain for a symbol, replacing any chain for the
+// same symbol that has not been published yet
+func (c *Conflator) Push(chain models.OptionChain) {
+    c.mu.Lock()
+    if _, ok := c.pending[chain.Symbol]; !ok {
+        c.order = append(c.order, chain.Symbol)
+    }
+    c.pending[chain.Symbol] = chain
+    c.mu.Unlock()
+
+    select {
+    case c.notify <- struct{}{}:
+    default:
+    }
+}
+
+// Run publishes queued chains until the context is cancelled
+func (c *Conflator) Run(ctx context.Context) {
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-c.notify:
+            c.mu.Lock()
+            pending, order := c.pending, c.order
+            c.pending = make(map[string]models.OptionChain)
+            c.order = nil
+            c.mu.Unlock()
+
+            for _, symbol := range order {
+                c.publish(pending[symbol])
+            }
+        }
+    }
+}
+
+// pendingDelta accumulates the changes a throttled subscription has not
+// been sent yet. Only the names of changed fields are kept; their values
+// are read from the latest chain when the subscription is flushed, so
+// repeated updates to a contract coalesce into one.
+type pendingDelta struct {
+    underlying  bool
+    chainFields map[string]struct{}
+    fields      map[string]map[string]struct{}
+    removed     map[string]struct{}
+}
+
+func newPendingDelta() *pendingDelta {
+    return &pendingDelta{
+        chainFields: make(map[string]struct{}),
+        fields:      make(map[string]map[string]struct{}),
+        removed:     make(map[string]struct{}),
+    }
+}
+
+// empty reports whether there is nothing to flush
+func (p *pendingDelta) empty() bool {
+    return !p.underlying && len(p.chainFields) == 0 && len(p.fields) == 0 && len(p.removed) == 0
+}
+
+// merge folds a delta into the pending changes
+func (p *pendingDelta) merge(delta chainDelta) {
+    if delta.underlying != nil {
+        p.underlying = true
+    }
+    for name := range delta.fields {
+        p.chainFields[name] = struct{}{}
+    }
+    for _, change := range delta.changes {
+        delete(p.removed, change.Symbol)
+        names, ok := p.fields[change.Symbol]
+        if !ok {
+            names = make(map[string]struct{}, len(change.Fields))
+            p.fields[change.Symbol] = names
+        }
+        for name := range change.Fields {
+            names[name] = struct{}{}
+        }
+    }
+    for _, symbol := range delta.removed {
+        delete(p.fields, symbol)
+        p.removed[symbol] = struct{}{}
+    }
+}
+
+// build turns the pending changes into a delta using the latest values
+// of the topic, and resets the pending state
+func (p *pendingDelta) build(t *topic) chainDelta {
+    var delta chainDelta
+
+    if p.underlying {
+        underlying := t.chain.Underlying
+        delta.underlying = &underlying
+    }
+    if len(p.chainFields) > 0 {
+        delta.fields = pickFields(chainFields, reflect.ValueOf(t.chain), p.chainFields)
+    }
+
+    for _, options := range [][]models.OptionData{t.chain.Calls, t.chain.Puts} {
+        for _, option := range options {
+            names, ok := p.fields[option.Symbol]
+            if !ok {
+                continue
+            }
+            delta.changes = append(delta.changes, ContractDelta{
+                Symbol: option.Symbol,
+                Fields: selectFields(option, names),
+            })
+        }
+    }
+
+    for symbol := range p.removed {
+        delta.removed = append(delta.removed, symbol)
+    }
+    sort.Strings(delta.removed)
+
+    *p = *newPendingDelta()
+    return delta
+}
+
+// selectFields returns the named fields of a contract
+func selectFields(option models.OptionData, names map[string]struct{}) map[string]interface{} {
+    return pickFields(optionFields, reflect.ValueOf(option), names)
+}
+
+// pickFields returns the named fields of a struct value
+func pickFields(list []optionField, value reflect.Value, names map[string]struct{}) map[string]interface{} {
+    fields := make(map[string]interface{}, len(names))
+    for _, field := range list {
+        if _, ok := names[field.name]; ok {
+            fields[field.name] = value.Field(field.index).Interface()
+        }
+    }
+    return fields
+}
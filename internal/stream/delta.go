@@ -0,0 +1,182 @@
+package stream
+
+import (
+    "reflect"
+    "sort"
+    "strings"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// optionField maps a struct field to its JSON name
+type optionField struct {
+    index      int
+    name       string
+    comparable bool
+}
+
+// structFields lists the fields of a struct type by JSON name, leaving out
+// the named ones
+func structFields(t reflect.Type, skip ...string) []optionField {
+    var fields []optionField
+    for i := 0; i < t.NumField(); i++ {
+        name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+        if name == "" || name == "-" || contains(skip, name) {
+            continue
+        }
+        fields = append(fields, optionField{
+            index:      i,
+            name:       name,
+            comparable: t.Field(i).Type.Comparable() && t.Field(i).Type.Kind() != reflect.Ptr,
+        })
+    }
+    return fields
+}
+
+func contains(names []string, name string) bool {
+    for _, n := range names {
+        if n == name {
+            return true
+        }
+    }
+    return false
+}
+
+// optionFields lists the OptionData fields that are compared when building
+// deltas. The contract symbol is the delta key and is not repeated.
+var optionFields = structFields(reflect.TypeOf(models.OptionData{}), "symbol")
+
+// chainFields lists the chain-level analytics of OptionChain that are
+// compared when building deltas. The symbol, price, timestamp and
+// contracts have their own place in the delta.
+var chainFields = structFields(reflect.TypeOf(models.OptionChain{}),
+    "symbol", "underlyingPrice", "lastUpdated", "calls", "puts")
+
+// chainDelta is the difference between two versions of a chain
+type chainDelta struct {
+    underlying *float64
+    fields     map[string]interface{} // changed chain-level fields
+    changes    []ContractDelta
+    removed    []string
+}
+
+// empty reports whether the delta carries no changes
+func (d chainDelta) empty() bool {
+    return d.underlying == nil && len(d.fields) == 0 && len(d.changes) == 0 && len(d.removed) == 0
+}
+
+// message builds the delta message for the given sequence number
+func (d chainDelta) message(chain models.OptionChain, seq uint64) DeltaMessage {
+    return DeltaMessage{
+        Type:       MessageDelta,
+        Symbol:     chain.Symbol,
+        Seq:        seq,
+        Updated:    chain.Updated,
+        Underlying: d.underlying,
+        Chain:      d.fields,
+        Changes:    d.changes,
+        Removed:    d.removed,
+    }
+}
+
+// IndexChain maps every contract of the chain by its symbol
+func IndexChain(chain models.OptionChain) map[string]models.OptionData {
+    index := make(map[string]models.OptionData, len(chain.Calls)+len(chain.Puts))
+    for _, option := range chain.Calls {
+        index[option.Symbol] = option
+    }
+    for _, option := range chain.Puts {
+        index[option.Symbol] = option
+    }
+    return index
+}
+
+// diffChains computes the changes needed to turn prev into next. prevIndex
+// and nextIndex are the contract indexes of the two chains.
+func diffChains(prev, next models.OptionChain, prevIndex, nextIndex map[string]models.OptionData) chainDelta {
+    var delta chainDelta
+
+    if prev.Underlying != next.Underlying {
+        underlying := next.Underlying
+        delta.underlying = &underlying
+    }
+    delta.fields = diffFields(chainFields, reflect.ValueOf(prev), reflect.ValueOf(next), false)
+
+    for _, options := range [][]models.OptionData{next.Calls, next.Puts} {
+        for _, option := range options {
+            old, existed := prevIndex[option.Symbol]
+            fields := diffContract(old, option, !existed)
+            if len(fields) > 0 {
+                delta.changes = append(delta.changes, ContractDelta{
+                    Symbol: option.Symbol,
+                    Fields: fields,
+                })
+            }
+        }
+    }
+
+    for symbol := range prevIndex {
+        if _, ok := nextIndex[symbol]; !ok {
+            delta.removed = append(delta.removed, symbol)
+        }
+    }
+    sort.Strings(delta.removed)
+
+    return delta
+}
+
+// diffContract returns the fields of next that differ from prev, or every
+// field when all is set
+func diffContract(prev, next models.OptionData, all bool) map[string]interface{} {
+    return diffFields(optionFields, reflect.ValueOf(prev), reflect.ValueOf(next), all)
+}
+
+// diffFields returns the listed fields of next that differ from prev, or
+// every listed field when all is set
+func diffFields(list []optionField, prevValue, nextValue reflect.Value, all bool) map[string]interface{} {
+    var fields map[string]interface{}
+    for _, field := range list {
+        value := nextValue.Field(field.index).Interface()
+        if !all && equalField(field, value, prevValue.Field(field.index).Interface()) {
+            continue
+        }
+        if fields == nil {
+            fields = make(map[string]interface{})
+        }
+        fields[field.name] = value
+    }
+    return fields
+}
+
+// ApplyDelta updates an index of contracts by symbol with the changes of a
+// delta message, the way a client rebuilds a chain from a snapshot
+func ApplyDelta(contracts map[string]models.OptionData, msg DeltaMessage) {
+    for _, change := range msg.Changes {
+        option := contracts[change.Symbol]
+        option.Symbol = change.Symbol
+        value := reflect.ValueOf(&option).Elem()
+        for _, field := range optionFields {
+            v, ok := change.Fields[field.name]
+            if !ok {
+                continue
+            }
+            target := value.Field(field.index)
+            fv := reflect.ValueOf(v)
+            if fv.IsValid() && fv.Type().ConvertibleTo(target.Type()) {
+                target.Set(fv.Convert(target.Type()))
+            }
+        }
+        contracts[change.Symbol] = option
+    }
+    for _, symbol := range msg.Removed {
+        delete(contracts, symbol)
+    }
+}
+
+// equalField compares two values of the same struct field
+func equalField(field optionField, a, b interface{}) bool {
+    if field.comparable {
+        return a == b
+    }
+    return reflect.DeepEqual(a, b)
+}
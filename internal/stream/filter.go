@@ -0,0 +1,69 @@
+package stream
+
+import (
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// ContractFilter selects the contracts of a chain a stream should carry.
+// Zero values match everything.
+type ContractFilter struct {
+    Type        string          // "call" or "put"
+    Expirations map[string]bool // expiration dates (2006-01-02)
+    MinStrike   float64
+    MaxStrike   float64
+}
+
+// Match reports whether a contract passes the filter
+func (f ContractFilter) Match(option models.OptionData) bool {
+    if f.Type != "" && option.Type != f.Type {
+        return false
+    }
+    if len(f.Expirations) > 0 && !f.Expirations[option.Expiration] {
+        return false
+    }
+    if f.MinStrike > 0 && option.Strike < f.MinStrike {
+        return false
+    }
+    if f.MaxStrike > 0 && option.Strike > f.MaxStrike {
+        return false
+    }
+    return true
+}
+
+// FilterChain returns a copy of the chain holding only matching contracts
+func (f ContractFilter) FilterChain(chain models.OptionChain) models.OptionChain {
+    filtered := chain
+    filtered.Calls = f.filterOptions(chain.Calls)
+    filtered.Puts = f.filterOptions(chain.Puts)
+    return filtered
+}
+
+func (f ContractFilter) filterOptions(options []models.OptionData) []models.OptionData {
+    var out []models.OptionData
+    for _, option := range options {
+        if f.Match(option) {
+            out = append(out, option)
+        }
+    }
+    return out
+}
+
+// filterDelta returns a copy of the delta holding only changes to matching
+// contracts. contracts resolves a symbol to the last known state of the
+// contract, since changes carry only the fields that moved.
+func (f ContractFilter) filterDelta(msg DeltaMessage, contracts map[string]models.OptionData) DeltaMessage {
+    filtered := msg
+    filtered.Changes = nil
+    filtered.Removed = nil
+    for _, change := range msg.Changes {
+        if f.Match(contracts[change.Symbol]) {
+            filtered.Changes = append(filtered.Changes, change)
+        }
+    }
+    for _, symbol := range msg.Removed {
+        if f.Match(contracts[symbol]) {
+            filtered.Removed = append(filtered.Removed, symbol)
+        }
+    }
+    return filtered
+}
@@ -0,0 +1,145 @@
+package stream
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+
+    "github.com/gorilla/websocket"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/reflect/protoreflect"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protobufCodec encodes messages as options.v1.ServerMessage binary frames
+// and decodes options.v1.ClientMessage requests
+type protobufCodec struct{}
+
+func (protobufCodec) Encode(v interface{}) ([]byte, error) {
+    var msg optionsv1.ServerMessage
+    switch v := v.(type) {
+    case FeedMessage:
+        return proto.Marshal(v.ServerMessage())
+    case SnapshotMessage:
+        msg.Message = &optionsv1.ServerMessage_Snapshot{Snapshot: &optionsv1.Snapshot{
+            Symbol: v.Symbol,
+            Seq:    v.Seq,
+            Chain:  v.Chain.Proto(),
+        }}
+    case DeltaMessage:
+        delta, err := ProtoDelta(v)
+        if err != nil {
+            return nil, err
+        }
+        msg.Message = &optionsv1.ServerMessage_Delta{Delta: delta}
+    case ErrorMessage:
+        msg.Message = &optionsv1.ServerMessage_Error{Error: &optionsv1.Error{
+            Message: v.Message,
+        }}
+    default:
+        return nil, fmt.Errorf("unsupported message type %T", v)
+    }
+    return proto.Marshal(&msg)
+}
+
+func (protobufCodec) Decode(data []byte, msg *ClientMessage) error {
+    var req optionsv1.ClientMessage
+    if err := proto.Unmarshal(data, &req); err != nil {
+        return err
+    }
+    *msg = ClientMessage{
+        Type:    req.Type,
+        Symbol:  req.Symbol,
+        Rate:    req.Rate,
+        Channel: req.Channel,
+        Entry:   req.Entry,
+    }
+    for _, leg := range req.Legs {
+        msg.Legs = append(msg.Legs, models.Leg{Contract: leg.Contract, Quantity: int(leg.Quantity)})
+    }
+    return nil
+}
+
+func (protobufCodec) MessageType() int {
+    return websocket.BinaryMessage
+}
+
+// ProtoDelta converts a delta message, setting each changed contract field
+// on the protobuf message by its JSON name
+func ProtoDelta(msg DeltaMessage) (*optionsv1.Delta, error) {
+    delta := &optionsv1.Delta{
+        Symbol:          msg.Symbol,
+        Seq:             msg.Seq,
+        LastUpdated:     timestamppb.New(msg.Updated),
+        UnderlyingPrice: msg.Underlying,
+        Removed:         msg.Removed,
+    }
+
+    if len(msg.Chain) > 0 {
+        // Set the changed fields on a chain and convert it whole
+        var chain models.OptionChain
+        value := reflect.ValueOf(&chain).Elem()
+        for _, field := range chainFields {
+            v, ok := msg.Chain[field.name]
+            if !ok {
+                continue
+            }
+            if fv := reflect.ValueOf(v); fv.IsValid() && fv.Type().AssignableTo(value.Field(field.index).Type()) {
+                value.Field(field.index).Set(fv)
+            }
+            delta.ChainFields = append(delta.ChainFields, field.name)
+        }
+        delta.Chain = chain.Proto()
+    }
+
+    for _, change := range msg.Changes {
+        values := &optionsv1.OptionData{}
+        refl := values.ProtoReflect()
+        fields := make([]string, 0, len(change.Fields))
+        for name, value := range change.Fields {
+            fd := refl.Descriptor().Fields().ByJSONName(name)
+            if fd == nil {
+                return nil, fmt.Errorf("no protobuf field for %q", name)
+            }
+            pv, err := protoValue(fd, value)
+            if err != nil {
+                return nil, err
+            }
+            refl.Set(fd, pv)
+            fields = append(fields, name)
+        }
+        sort.Strings(fields)
+        delta.Changes = append(delta.Changes, &optionsv1.ContractDelta{
+            Symbol: change.Symbol,
+            Values: values,
+            Fields: fields,
+        })
+    }
+
+    return delta, nil
+}
+
+// protoValue converts a model field value to a protobuf field value
+func protoValue(fd protoreflect.FieldDescriptor, value interface{}) (protoreflect.Value, error) {
+    switch v := value.(type) {
+    case float64:
+        if fd.Kind() == protoreflect.DoubleKind {
+            return protoreflect.ValueOfFloat64(v), nil
+        }
+    case int:
+        if fd.Kind() == protoreflect.Int64Kind {
+            return protoreflect.ValueOfInt64(int64(v)), nil
+        }
+    case string:
+        if fd.Kind() == protoreflect.StringKind {
+            return protoreflect.ValueOfString(v), nil
+        }
+    case bool:
+        if fd.Kind() == protoreflect.BoolKind {
+            return protoreflect.ValueOfBool(v), nil
+        }
+    }
+    return protoreflect.Value{}, fmt.Errorf("cannot convert %T to protobuf field %s", value, fd.Name())
+}
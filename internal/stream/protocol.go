@@ -0,0 +1,78 @@
+package stream
+
+import (
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// Message types exchanged with WebSocket clients
+const (
+    // Client to server
+    MessageSubscribe   = "subscribe"
+    MessageUnsubscribe = "unsubscribe"
+    MessageResync      = "resync"
+
+    // Server to client; feed channel messages are typed by their producers
+    MessageSnapshot = "snapshot"
+    MessageDelta    = "delta"
+    MessageError    = "error"
+)
+
+// ClientMessage is a request sent by a WebSocket client. A client sends
+// "subscribe" to start receiving a symbol, and "resync" when it detects a
+// gap in the delta sequence numbers. Rate is the maximum number of updates
+// per second the client wants; 0 asks for every update and leaving it out
+// selects the server default. Channel picks what to stream for the symbol
+// and defaults to the chain. On the spread channel, Symbol is the
+// underlying and Legs and Entry define the spread.
+type ClientMessage struct {
+    Type    string       `json:"type"`
+    Symbol  string       `json:"symbol"`
+    Rate    *float64     `json:"rate,omitempty"`
+    Channel string       `json:"channel,omitempty"`
+    Legs    []models.Leg `json:"legs,omitempty"`
+    Entry   *float64     `json:"entry,omitempty"`
+}
+
+// SnapshotMessage carries the full option chain for a symbol. Deltas that
+// follow it continue from its sequence number.
+type SnapshotMessage struct {
+    Type   string             `json:"type"`
+    Symbol string             `json:"symbol"`
+    Seq    uint64             `json:"seq"`
+    Chain  models.OptionChain `json:"chain"`
+}
+
+// DeltaMessage carries the changes to a chain since the previous sequence
+// number. Chain holds the changed chain-level analytics, keyed by their
+// OptionChain JSON names. Clients that see a sequence gap should request a
+// resync.
+type DeltaMessage struct {
+    Type       string                 `json:"type"`
+    Symbol     string                 `json:"symbol"`
+    Seq        uint64                 `json:"seq"`
+    Updated    time.Time              `json:"lastUpdated"`
+    Underlying *float64               `json:"underlyingPrice,omitempty"`
+    Chain      map[string]interface{} `json:"chain,omitempty"`
+    Changes    []ContractDelta        `json:"changes,omitempty"`
+    Removed    []string               `json:"removed,omitempty"`
+}
+
+// empty reports whether the message carries no changes
+func (d DeltaMessage) empty() bool {
+    return d.Underlying == nil && len(d.Chain) == 0 && len(d.Changes) == 0 && len(d.Removed) == 0
+}
+
+// ContractDelta holds the changed fields of a single contract, keyed by
+// their OptionData JSON names. New contracts carry every field.
+type ContractDelta struct {
+    Symbol string                 `json:"symbol"`
+    Fields map[string]interface{} `json:"fields"`
+}
+
+// ErrorMessage reports a rejected client request
+type ErrorMessage struct {
+    Type    string `json:"type"`
+    Message string `json:"message"`
+}
@@ -0,0 +1,255 @@
+package stream
+
+import (
+    "encoding/json"
+    "fmt"
+    "log"
+    "net/http"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+const (
+    // replayBufferSize is the number of deltas kept per symbol for
+    // Last-Event-ID resumption
+    replayBufferSize = 256
+
+    // listenerBufferSize is the number of events a listener can fall behind
+    // before it is dropped
+    listenerBufferSize = 256
+
+    // heartbeatInterval is how often an idle event stream sends a comment
+    // to keep proxies from closing it
+    heartbeatInterval = 15 * time.Second
+)
+
+// Listener receives the updates of one symbol outside of a WebSocket
+// connection, such as a Server-Sent Events stream. Events carries
+// SnapshotMessage and DeltaMessage values whose Seq is the symbol's
+// publish sequence; it is closed if the listener falls too far behind.
+type Listener struct {
+    Symbol string
+    Events <-chan interface{}
+
+    filter ContractFilter
+    events chan interface{}
+}
+
+// Listen registers a listener for a symbol. When lastSeq is set and the
+// deltas after it are still buffered they are replayed; otherwise the
+// listener starts with a snapshot.
+func (m *Manager) Listen(symbol string, filter ContractFilter, lastSeq *uint64) *Listener {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    events := make(chan interface{}, listenerBufferSize+replayBufferSize)
+    l := &Listener{
+        Symbol: symbol,
+        Events: events,
+        filter: filter,
+        events: events,
+    }
+    m.listeners[l] = struct{}{}
+
+    t, ok := m.topics[symbol]
+    if !ok {
+        t = &topic{}
+    }
+
+    if lastSeq != nil {
+        if replay, ok := t.replay(*lastSeq); ok {
+            for _, msg := range replay {
+                if filtered := filter.filterDelta(msg, t.contracts); !filtered.empty() {
+                    l.send(filtered)
+                }
+            }
+            return l
+        }
+    }
+
+    chain := t.chain
+    chain.Symbol = symbol
+    l.send(SnapshotMessage{
+        Type:   MessageSnapshot,
+        Symbol: symbol,
+        Seq:    t.seq,
+        Chain:  filter.FilterChain(chain),
+    })
+    return l
+}
+
+// Unlisten removes a listener
+func (m *Manager) Unlisten(l *Listener) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+    m.removeListener(l)
+}
+
+// notifyListeners sends a published delta to the symbol's listeners.
+// Callers must hold clientsMux.
+func (m *Manager) notifyListeners(t *topic, msg DeltaMessage) {
+    for l := range m.listeners {
+        if l.Symbol != msg.Symbol {
+            continue
+        }
+        filtered := l.filter.filterDelta(msg, t.contracts)
+        if filtered.empty() {
+            continue
+        }
+        if !l.send(filtered) {
+            log.Printf("Dropping slow %s listener", l.Symbol)
+            m.removeListener(l)
+        }
+    }
+}
+
+// removeListener unregisters a listener and closes its channel. Callers
+// must hold clientsMux.
+func (m *Manager) removeListener(l *Listener) {
+    if _, ok := m.listeners[l]; ok {
+        delete(m.listeners, l)
+        close(l.events)
+    }
+}
+
+// send queues an event without blocking and reports whether it fit
+func (l *Listener) send(event interface{}) bool {
+    select {
+    case l.events <- event:
+        return true
+    default:
+        return false
+    }
+}
+
+// replay returns the buffered deltas published after seq, or false if some
+// of them are no longer buffered
+func (t *topic) replay(seq uint64) ([]DeltaMessage, bool) {
+    if seq > t.seq {
+        return nil, false
+    }
+    if seq == t.seq {
+        return nil, true
+    }
+    if len(t.history) == 0 || t.history[0].Seq > seq+1 {
+        return nil, false
+    }
+    start := int(seq + 1 - t.history[0].Seq)
+    return t.history[start:], true
+}
+
+// record appends a published delta to the replay buffer
+func (t *topic) record(msg DeltaMessage) {
+    t.history = append(t.history, msg)
+    if len(t.history) > replayBufferSize {
+        t.history = append([]DeltaMessage(nil), t.history[len(t.history)-replayBufferSize:]...)
+    }
+}
+
+// ServeEvents streams a symbol's chain updates to an HTTP client as
+// Server-Sent Events until the request is cancelled. Each event's id is the
+// publish sequence, so a reconnecting client resumes via Last-Event-ID.
+func (m *Manager) ServeEvents(w http.ResponseWriter, r *http.Request, symbol string, filter ContractFilter, lastSeq *uint64) {
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+        return
+    }
+
+    // The stream outlives the server's write timeout
+    if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+        log.Printf("SSE write deadline error: %v", err)
+    }
+
+    listener := m.Listen(symbol, filter, lastSeq)
+    defer m.Unlisten(listener)
+
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Header().Set("Connection", "keep-alive")
+    w.Header().Set("X-Accel-Buffering", "no")
+    w.WriteHeader(http.StatusOK)
+    flusher.Flush()
+
+    heartbeat := time.NewTicker(heartbeatInterval)
+    defer heartbeat.Stop()
+
+    for {
+        select {
+        case <-r.Context().Done():
+            return
+        case <-heartbeat.C:
+            if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+                return
+            }
+            flusher.Flush()
+        case event, ok := <-listener.Events:
+            if !ok {
+                // Dropped for falling behind; the client reconnects and
+                // resumes from its last event id
+                return
+            }
+            if err := writeEvent(w, event); err != nil {
+                log.Printf("SSE write error: %v", err)
+                return
+            }
+            flusher.Flush()
+        }
+    }
+}
+
+// writeEvent writes a snapshot or delta as a single Server-Sent Event
+func writeEvent(w http.ResponseWriter, event interface{}) error {
+    var name string
+    var seq uint64
+    switch msg := event.(type) {
+    case SnapshotMessage:
+        name, seq = msg.Type, msg.Seq
+    case DeltaMessage:
+        name, seq = msg.Type, msg.Seq
+    default:
+        return fmt.Errorf("unsupported event type %T", event)
+    }
+
+    data, err := json.Marshal(event)
+    if err != nil {
+        return err
+    }
+    _, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", seq, name, data)
+    return err
+}
+
+// rememberContracts records the last known state of every contract in the
+// chain, so that filters can still place contracts after they are removed
+func (t *topic) rememberContracts(chain models.OptionChain) {
+    if t.contracts == nil {
+        t.contracts = make(map[string]models.OptionData)
+    }
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for _, option := range options {
+            t.contracts[option.Symbol] = option
+            delete(t.removed, option.Symbol)
+        }
+    }
+}
+
+// forgetContracts drops removed contracts once no buffered delta refers to
+// them. A contract is last referred to by the delta that removes it, so it
+// is forgotten when that delta leaves the replay buffer; expired contracts
+// leave the chain and go the same way.
+func (t *topic) forgetContracts(msg DeltaMessage) {
+    if t.removed == nil {
+        t.removed = make(map[string]uint64)
+    }
+    for _, symbol := range msg.Removed {
+        t.removed[symbol] = msg.Seq
+    }
+    oldest := t.history[0].Seq
+    for symbol, seq := range t.removed {
+        if seq < oldest {
+            delete(t.removed, symbol)
+            delete(t.contracts, symbol)
+        }
+    }
+}
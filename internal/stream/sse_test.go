@@ -0,0 +1,33 @@
+package stream
+
+import (
+    "testing"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+func TestForgetRemovedContracts(t *testing.T) {
+    m := NewManager()
+    listed := models.OptionData{Symbol: ".SPY260116C500", Strike: 500, Type: "call", Expiration: "2026-01-16"}
+    removed := models.OptionData{Symbol: ".SPY260116C505", Strike: 505, Type: "call", Expiration: "2026-01-16"}
+
+    m.BroadcastOptionChain(models.OptionChain{Symbol: "SPY", Underlying: 500, Calls: []models.OptionData{listed, removed}})
+    m.BroadcastOptionChain(models.OptionChain{Symbol: "SPY", Underlying: 501, Calls: []models.OptionData{listed}})
+    topic := m.topics["SPY"]
+    if _, ok := topic.contracts[removed.Symbol]; !ok {
+        t.Fatal("removed contract forgotten while its removal is buffered")
+    }
+
+    for i := 0; i < replayBufferSize; i++ {
+        m.BroadcastOptionChain(models.OptionChain{Symbol: "SPY", Underlying: 502 + float64(i), Calls: []models.OptionData{listed}})
+    }
+    if _, ok := topic.contracts[removed.Symbol]; ok {
+        t.Error("removed contract kept after its removal left the replay buffer")
+    }
+    if _, ok := topic.contracts[listed.Symbol]; !ok {
+        t.Error("listed contract forgotten")
+    }
+    if len(topic.removed) != 0 {
+        t.Errorf("removed = %v, want empty", topic.removed)
+    }
+}
@@ -1,9 +1,11 @@
 package stream
 
 import (
+    "fmt"
     "log"
     "math/rand"
     "net/http"
+    "strings"
     "sync"
     "time"
 
@@ -16,6 +18,7 @@ var (
     Upgrader = websocket.Upgrader{
         ReadBufferSize:  1024,
         WriteBufferSize: 1024,
+        Subprotocols:    []string{SubprotocolJSON, SubprotocolMsgpack, SubprotocolProtobuf},
         CheckOrigin: func(r *http.Request) bool {
             return true // For development; add proper origin checking in production
         },
@@ -26,45 +29,361 @@ var (
     clientsMux sync.Mutex
 )
 
-// Manager handles WebSocket client connections and broadcasting
+// Manager handles WebSocket client connections and broadcasting. Each
+// subscribed client receives a snapshot of the chain followed by
+// sequence-numbered deltas, at the update rate it asked for.
 type Manager struct {
-    clients    map[*websocket.Conn]bool
+    clients    map[*websocket.Conn]*client
     clientsMux sync.Mutex
+
+    // Latest chain published for each symbol
+    topics map[string]*topic
+
+    // Non-WebSocket consumers such as Server-Sent Events streams
+    listeners map[*Listener]struct{}
+
+    // Latest message of each feed channel, by feedKey
+    feeds map[string]FeedMessage
+
+    // Source of the spread channel, if spreads are served
+    spreads SpreadSource
+
+    // Update rate in Hz for clients that do not request one (0 = unthrottled)
+    defaultRate float64
+}
+
+// client is a connected WebSocket client and its subscriptions
+type client struct {
+    conn  *websocket.Conn
+    codec Codec
+    mu    sync.Mutex // serialises writes to conn
+    subs  map[string]*subscription
+    feeds map[string]struct{} // subscribed feed channels, by feedKey
+}
+
+// subscription tracks the delta sequence of a subscribed symbol. Throttled
+// subscriptions collect changes in pending and flush them on their own
+// ticker; unthrottled ones are sent every delta as it is published.
+type subscription struct {
+    seq      uint64
+    interval time.Duration
+    pending  *pendingDelta
+    done     chan struct{}
+}
+
+// topic holds the latest chain published for a symbol, along with the
+// publish sequence and recent deltas used by listeners
+type topic struct {
+    chain models.OptionChain
+    index map[string]models.OptionData
+
+    seq       uint64
+    history   []DeltaMessage
+    contracts map[string]models.OptionData // last known state, including removed contracts
+    removed   map[string]uint64            // sequence each removed contract was removed at
 }
 
 // NewManager creates a new WebSocket manager
 func NewManager() *Manager {
     return &Manager{
-        clients: make(map[*websocket.Conn]bool),
+        clients:   make(map[*websocket.Conn]*client),
+        topics:    make(map[string]*topic),
+        listeners: make(map[*Listener]struct{}),
+        feeds:     make(map[string]FeedMessage),
     }
 }
 
-// AddClient registers a new WebSocket client
+// AddClient registers a new WebSocket client. Messages to the client use
+// the encoding of the subprotocol negotiated during the upgrade.
 func (m *Manager) AddClient(conn *websocket.Conn) {
     m.clientsMux.Lock()
-    m.clients[conn] = true
+    m.clients[conn] = &client{
+        conn:  conn,
+        codec: CodecFor(conn.Subprotocol()),
+        subs:  make(map[string]*subscription),
+        feeds: make(map[string]struct{}),
+    }
     m.clientsMux.Unlock()
 }
 
 // RemoveClient removes a WebSocket client
 func (m *Manager) RemoveClient(conn *websocket.Conn) {
     m.clientsMux.Lock()
-    delete(m.clients, conn)
+    if c, ok := m.clients[conn]; ok {
+        c.stop()
+        delete(m.clients, conn)
+        m.releaseFeeds(c)
+    }
     m.clientsMux.Unlock()
 }
 
-// BroadcastOptionChain sends option chain data to all connected clients
+// SetDefaultRate sets the update rate in Hz used for clients that do not
+// request one in their subscribe message. A rate of 0 disables throttling.
+func (m *Manager) SetDefaultRate(rate float64) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+    m.defaultRate = rate
+}
+
+// HandleMessage processes a message received from a WebSocket client. Text
+// frames are always read as JSON; binary frames use the client's codec.
+func (m *Manager) HandleMessage(conn *websocket.Conn, messageType int, data []byte) {
+    var msg ClientMessage
+    if err := m.decode(conn, messageType, data, &msg); err != nil {
+        m.sendError(conn, fmt.Sprintf("invalid message: %v", err))
+        return
+    }
+    symbol := strings.ToUpper(strings.TrimSpace(msg.Symbol))
+    if symbol == "" {
+        m.sendError(conn, "symbol is required")
+        return
+    }
+    if msg.Channel != "" && msg.Channel != ChannelChain {
+        m.handleFeedMessage(conn, msg, symbol)
+        return
+    }
+
+    rate := -1.0
+    if msg.Rate != nil {
+        if *msg.Rate < 0 {
+            m.sendError(conn, "rate must not be negative")
+            return
+        }
+        rate = *msg.Rate
+    }
+
+    switch msg.Type {
+    case MessageSubscribe, MessageResync:
+        m.Subscribe(conn, symbol, rate)
+    case MessageUnsubscribe:
+        m.Unsubscribe(conn, symbol)
+    default:
+        m.sendError(conn, fmt.Sprintf("unknown message type: %q", msg.Type))
+    }
+}
+
+// decode parses a client request with the codec matching the frame type
+func (m *Manager) decode(conn *websocket.Conn, messageType int, data []byte, msg *ClientMessage) error {
+    codec := Codec(jsonCodec{})
+    if messageType == websocket.BinaryMessage {
+        m.clientsMux.Lock()
+        if c, ok := m.clients[conn]; ok {
+            codec = c.codec
+        }
+        m.clientsMux.Unlock()
+    }
+    return codec.Decode(data, msg)
+}
+
+// Subscribe sends the client a snapshot of the symbol's chain and streams
+// deltas from then on, at most rate times per second. A rate of 0 streams
+// every update and a negative rate selects the manager default, or keeps
+// the current rate of an existing subscription.
+// Subscribing again acts as a resync: a fresh snapshot is sent and the
+// sequence continues from its number.
+func (m *Manager) Subscribe(conn *websocket.Conn, symbol string, rate float64) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    c, ok := m.clients[conn]
+    if !ok {
+        return
+    }
+    sub, ok := c.subs[symbol]
+    var interval time.Duration
+    switch {
+    case rate < 0 && ok:
+        // A resync without a rate keeps the current one
+        interval = sub.interval
+    case rate < 0:
+        interval = rateInterval(m.defaultRate)
+    default:
+        interval = rateInterval(rate)
+    }
+    if ok && sub.interval != interval {
+        sub.stop()
+        ok = false
+    }
+    if !ok {
+        seq := uint64(0)
+        if sub != nil {
+            seq = sub.seq
+        }
+        sub = &subscription{
+            seq:      seq,
+            interval: interval,
+            pending:  newPendingDelta(),
+            done:     make(chan struct{}),
+        }
+        c.subs[symbol] = sub
+        if interval > 0 {
+            go m.runThrottle(c, symbol, sub)
+        }
+    }
+    // The snapshot supersedes anything still pending
+    sub.pending = newPendingDelta()
+
+    chain := models.OptionChain{Symbol: symbol}
+    if t, ok := m.topics[symbol]; ok {
+        chain = t.chain
+    }
+    snapshot := SnapshotMessage{
+        Type:   MessageSnapshot,
+        Symbol: symbol,
+        Seq:    sub.seq,
+        Chain:  chain,
+    }
+    if err := c.write(snapshot); err != nil {
+        log.Printf("WebSocket write error: %v", err)
+        m.dropClient(c)
+    }
+}
+
+// Unsubscribe stops streaming a symbol to the client
+func (m *Manager) Unsubscribe(conn *websocket.Conn, symbol string) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    if c, ok := m.clients[conn]; ok {
+        if sub, ok := c.subs[symbol]; ok {
+            sub.stop()
+            delete(c.subs, symbol)
+        }
+    }
+}
+
+// BroadcastOptionChain publishes the latest chain for a symbol and sends
+// the changes to every subscribed client
 func (m *Manager) BroadcastOptionChain(chain models.OptionChain) {
     m.clientsMux.Lock()
-    for client := range m.clients {
-        err := client.WriteJSON(chain)
-        if err != nil {
+    defer m.clientsMux.Unlock()
+
+    t, ok := m.topics[chain.Symbol]
+    if !ok {
+        t = &topic{}
+        m.topics[chain.Symbol] = t
+    }
+    index := IndexChain(chain)
+    delta := diffChains(t.chain, chain, t.index, index)
+    t.chain = chain
+    t.index = index
+    t.rememberContracts(chain)
+    if delta.empty() {
+        return
+    }
+
+    t.seq++
+    msg := delta.message(chain, t.seq)
+    t.record(msg)
+    t.forgetContracts(msg)
+    m.notifyListeners(t, msg)
+
+    for _, c := range m.clients {
+        sub, ok := c.subs[chain.Symbol]
+        if !ok {
+            continue
+        }
+        if sub.interval > 0 {
+            sub.pending.merge(delta)
+            continue
+        }
+        sub.seq++
+        if err := c.write(delta.message(chain, sub.seq)); err != nil {
             log.Printf("WebSocket write error: %v", err)
-            client.Close()
-            delete(m.clients, client)
+            m.dropClient(c)
         }
     }
-    m.clientsMux.Unlock()
+}
+
+// runThrottle flushes a throttled subscription on every tick until it is
+// stopped
+func (m *Manager) runThrottle(c *client, symbol string, sub *subscription) {
+    ticker := time.NewTicker(sub.interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-sub.done:
+            return
+        case <-ticker.C:
+            m.flush(c, symbol, sub)
+        }
+    }
+}
+
+// flush sends the changes a throttled subscription has accumulated since
+// its last update as a single delta
+func (m *Manager) flush(c *client, symbol string, sub *subscription) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    t, ok := m.topics[symbol]
+    if !ok || sub.pending.empty() || c.subs[symbol] != sub {
+        return
+    }
+    delta := sub.pending.build(t)
+    sub.seq++
+    if err := c.write(delta.message(t.chain, sub.seq)); err != nil {
+        log.Printf("WebSocket write error: %v", err)
+        m.dropClient(c)
+    }
+}
+
+// sendError reports a rejected request to the client
+func (m *Manager) sendError(conn *websocket.Conn, message string) {
+    m.clientsMux.Lock()
+    defer m.clientsMux.Unlock()
+
+    if c, ok := m.clients[conn]; ok {
+        if err := c.write(ErrorMessage{Type: MessageError, Message: message}); err != nil {
+            log.Printf("WebSocket write error: %v", err)
+            m.dropClient(c)
+        }
+    }
+}
+
+// dropClient closes and removes a client. Callers must hold clientsMux.
+func (m *Manager) dropClient(c *client) {
+    c.stop()
+    c.conn.Close()
+    delete(m.clients, c.conn)
+    m.releaseFeeds(c)
+}
+
+// releaseFeeds releases the feeds of a removed client. Callers must hold
+// clientsMux.
+func (m *Manager) releaseFeeds(c *client) {
+    for key := range c.feeds {
+        m.release(key)
+    }
+}
+
+// stop ends the throttling of every subscription of the client
+func (c *client) stop() {
+    for _, sub := range c.subs {
+        sub.stop()
+    }
+}
+
+// stop ends the throttling of the subscription
+func (s *subscription) stop() {
+    select {
+    case <-s.done:
+    default:
+        close(s.done)
+    }
+}
+
+// write encodes a message with the client's codec and sends it with mutex
+// protection
+func (c *client) write(v interface{}) error {
+    data, err := c.codec.Encode(v)
+    if err != nil {
+        return err
+    }
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.conn.WriteMessage(c.codec.MessageType(), data)
 }
 
 // StartSimulation begins simulating option chain updates
@@ -89,11 +408,13 @@ func GenerateSampleOptions(optionType string) []models.OptionData {
     options := make([]models.OptionData, 5)
     baseStrike := 150.0
 
+    expiration := time.Now().AddDate(0, 0, 30)
     for i := range options {
         strike := baseStrike + (float64(i-2) * 2.5)
         options[i] = models.OptionData{
+            Symbol:     sampleSymbol("AAPL", expiration, optionType, strike),
             Strike:     strike,
-            Expiration: time.Now().AddDate(0, 0, 30).Format("2006-01-02"),
+            Expiration: expiration.Format("2006-01-02"),
             Type:       optionType,
             Bid:        rand.Float64() * 5,
             Ask:        rand.Float64() * 5 + 0.15,
@@ -109,3 +430,20 @@ func GenerateSampleOptions(optionType string) []models.OptionData {
     }
     return options
 }
+
+// rateInterval converts an update rate in Hz to a flush interval
+func rateInterval(rate float64) time.Duration {
+    if rate <= 0 {
+        return 0
+    }
+    return time.Duration(float64(time.Second) / rate)
+}
+
+// sampleSymbol builds a DXLink-style option symbol for sample data
+func sampleSymbol(root string, expiration time.Time, optionType string, strike float64) string {
+    side := "P"
+    if optionType == "call" {
+        side = "C"
+    }
+    return fmt.Sprintf(".%s%s%s%g", root, expiration.Format("060102"), side, strike)
+}
@@ -0,0 +1,40 @@
+package surface
+
+import (
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Message carries the latest surface of a symbol to stream clients
+type Message struct {
+    Type    string  `json:"type"` // "surface"
+    Symbol  string  `json:"symbol"`
+    Surface Surface `json:"surface"`
+}
+
+// NewMessage wraps a surface for streaming
+func NewMessage(s Surface) Message {
+    return Message{Type: "surface", Symbol: s.Symbol, Surface: s}
+}
+
+// ServerMessage returns the message in its protobuf form
+func (m Message) ServerMessage() *optionsv1.ServerMessage {
+    return &optionsv1.ServerMessage{Message: &optionsv1.ServerMessage_Surface{Surface: m.Surface.Proto()}}
+}
+
+// Proto converts the surface to its protobuf form
+func (s Surface) Proto() *optionsv1.Surface {
+    rows := make([]*optionsv1.SurfaceRow, len(s.Vols))
+    for i, vols := range s.Vols {
+        rows[i] = &optionsv1.SurfaceRow{Vols: vols}
+    }
+    return &optionsv1.Surface{
+        Symbol:          s.Symbol,
+        UnderlyingPrice: s.Underlying,
+        LastUpdated:     timestamppb.New(s.Updated),
+        Axis:            string(s.Axis),
+        Tenors:          s.Tenors,
+        Nodes:           s.Nodes,
+        Rows:            rows,
+    }
+}
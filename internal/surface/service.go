@@ -0,0 +1,65 @@
+package surface
+
+import (
+    "context"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/store"
+)
+
+// Service builds surfaces from the aggregated chains and republishes them
+// at a fixed cadence
+type Service struct {
+    chains   *store.ChainStore
+    interval time.Duration
+    publish  func(Surface)
+    watched  func(symbol string) bool
+    now      func() time.Time
+}
+
+// NewService creates a surface service over the chain store. Every
+// interval, publish receives the moneyness surface of each stored
+// underlying for which watched reports true.
+func NewService(chains *store.ChainStore, interval time.Duration, publish func(Surface), watched func(symbol string) bool) *Service {
+    return &Service{
+        chains:   chains,
+        interval: interval,
+        publish:  publish,
+        watched:  watched,
+        now:      time.Now,
+    }
+}
+
+// Surface builds the current surface of an underlying. It reports false
+// if no chain is stored for the symbol.
+func (s *Service) Surface(symbol string, axis Axis) (Surface, bool, error) {
+    chain, ok := s.chains.Get(symbol)
+    if !ok {
+        return Surface{}, false, nil
+    }
+    surface, err := Build(chain, axis, s.now())
+    return surface, true, err
+}
+
+// Run publishes surfaces until the context is cancelled
+func (s *Service) Run(ctx context.Context) {
+    ticker := time.NewTicker(s.interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            for _, symbol := range s.chains.Symbols() {
+                if !s.watched(symbol) {
+                    continue
+                }
+                surface, ok, err := s.Surface(symbol, AxisMoneyness)
+                if ok && err == nil {
+                    s.publish(surface)
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,207 @@
+package surface
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "sort"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/analytics"
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+// Axis selects how strikes are measured across the surface
+type Axis string
+
+const (
+    AxisMoneyness Axis = "moneyness" // strike / underlying price
+    AxisDelta     Axis = "delta"     // call delta; puts are mapped to 1 + delta
+)
+
+// Default grid of the surface
+var (
+    DefaultTenors    = []float64{7, 14, 30, 60, 90, 180, 365}
+    DefaultMoneyness = []float64{0.8, 0.85, 0.9, 0.95, 1, 1.05, 1.1, 1.15, 1.2}
+    DefaultDeltas    = []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+)
+
+// ErrNoVols is returned for chains without any implied volatilities
+var ErrNoVols = errors.New("no implied volatilities in chain")
+
+// Surface is implied volatility on a regular grid of days to expiration
+// and moneyness or delta
+type Surface struct {
+    Symbol     string      `json:"symbol"`
+    Underlying float64     `json:"underlyingPrice"`
+    Updated    time.Time   `json:"lastUpdated"`
+    Axis       Axis        `json:"axis"`
+    Tenors     []float64   `json:"tenors"` // days to expiration
+    Nodes      []float64   `json:"nodes"`  // moneyness or delta
+    Vols       [][]float64 `json:"vols"`   // Vols[i][j] at Tenors[i] and Nodes[j]
+}
+
+// ParseAxis parses an axis name, defaulting to moneyness
+func ParseAxis(name string) (Axis, error) {
+    switch Axis(name) {
+    case "", AxisMoneyness:
+        return AxisMoneyness, nil
+    case AxisDelta:
+        return AxisDelta, nil
+    }
+    return "", fmt.Errorf("unknown surface axis: %q", name)
+}
+
+// slice is the smile of one expiration: implied volatility against the
+// axis, sorted by the axis
+type slice struct {
+    days float64
+    xs   []float64
+    vols []float64
+}
+
+// Build computes the surface of a chain on the default grid. Each
+// expiration contributes its out-of-the-money contracts; the smile is
+// interpolated linearly along the axis and flat beyond its ends, and
+// expirations are interpolated linearly in total variance. Tenors beyond
+// the last expiration are left out rather than extrapolated.
+func Build(chain models.OptionChain, axis Axis, now time.Time) (Surface, error) {
+    nodes := DefaultMoneyness
+    if axis == AxisDelta {
+        nodes = DefaultDeltas
+    }
+
+    slices := buildSlices(chain, axis, now)
+    if len(slices) == 0 {
+        return Surface{}, ErrNoVols
+    }
+
+    var tenors []float64
+    last := slices[len(slices)-1].days
+    for _, tenor := range DefaultTenors {
+        if tenor <= last {
+            tenors = append(tenors, tenor)
+        }
+    }
+    if len(tenors) == 0 {
+        tenors = []float64{math.Round(last*10) / 10}
+    }
+
+    vols := make([][]float64, len(tenors))
+    for i, tenor := range tenors {
+        vols[i] = make([]float64, len(nodes))
+        for j, x := range nodes {
+            vols[i][j] = volAt(slices, tenor, x)
+        }
+    }
+
+    return Surface{
+        Symbol:     chain.Symbol,
+        Underlying: chain.Underlying,
+        Updated:    chain.Updated,
+        Axis:       axis,
+        Tenors:     tenors,
+        Nodes:      nodes,
+        Vols:       vols,
+    }, nil
+}
+
+// buildSlices groups the chain's out-of-the-money implied volatilities by
+// expiration, ordered by days to expiration
+func buildSlices(chain models.OptionChain, axis Axis, now time.Time) []slice {
+    if chain.Underlying <= 0 {
+        return nil
+    }
+
+    type point struct{ x, vol float64 }
+    points := make(map[string][]point)
+    add := func(options []models.OptionData, call bool) {
+        for _, option := range options {
+            // Out-of-the-money contracts carry the most time value and
+            // the tightest vol quotes
+            if call != (option.Strike >= chain.Underlying) {
+                continue
+            }
+            vol := option.MidIV
+            if vol <= 0 {
+                vol = option.ImpliedVol
+            }
+            if vol <= 0 {
+                continue
+            }
+            x := option.Strike / chain.Underlying
+            if axis == AxisDelta {
+                if option.Delta == 0 {
+                    continue
+                }
+                x = option.Delta
+                if !call {
+                    x += 1
+                }
+            }
+            points[option.Expiration] = append(points[option.Expiration], point{x, vol})
+        }
+    }
+    add(chain.Calls, true)
+    add(chain.Puts, false)
+
+    var slices []slice
+    for expiration, pts := range points {
+        t, err := analytics.YearsToExpiration(expiration, now)
+        if err != nil || t <= 0 {
+            continue
+        }
+        sort.Slice(pts, func(i, j int) bool { return pts[i].x < pts[j].x })
+        s := slice{days: t * 365}
+        for _, p := range pts {
+            s.xs = append(s.xs, p.x)
+            s.vols = append(s.vols, p.vol)
+        }
+        slices = append(slices, s)
+    }
+    sort.Slice(slices, func(i, j int) bool { return slices[i].days < slices[j].days })
+    return slices
+}
+
+// volAt interpolates the surface at a tenor in days and an axis value
+func volAt(slices []slice, days, x float64) float64 {
+    if days <= slices[0].days {
+        return slices[0].vol(x)
+    }
+    for i := 1; i < len(slices); i++ {
+        hi := slices[i]
+        if days > hi.days {
+            continue
+        }
+        lo := slices[i-1]
+        loVol, hiVol := lo.vol(x), hi.vol(x)
+        weight := (days - lo.days) / (hi.days - lo.days)
+        loVar := loVol * loVol * lo.days
+        hiVar := hiVol * hiVol * hi.days
+        variance := loVar + (hiVar-loVar)*weight
+        if variance <= 0 {
+            // Total variance falling with time is a calendar arbitrage;
+            // fall back to interpolating the vols themselves
+            return loVol + (hiVol-loVol)*weight
+        }
+        return math.Sqrt(variance / days)
+    }
+    return slices[len(slices)-1].vol(x)
+}
+
+// vol interpolates the smile linearly, flat beyond its ends
+func (s slice) vol(x float64) float64 {
+    n := len(s.xs)
+    if x <= s.xs[0] {
+        return s.vols[0]
+    }
+    if x >= s.xs[n-1] {
+        return s.vols[n-1]
+    }
+    i := sort.SearchFloat64s(s.xs, x)
+    if s.xs[i] == s.xs[i-1] {
+        return s.vols[i]
+    }
+    weight := (x - s.xs[i-1]) / (s.xs[i] - s.xs[i-1])
+    return s.vols[i-1] + (s.vols[i]-s.vols[i-1])*weight
+}
@@ -35,12 +35,16 @@ type Client struct {
     // Connection management
     reconnectManager *reconnectManager
     subscriptions   []DXSubscription
+    subscribers     map[DXSubscription]int // requests holding each subscription
+    feedChannel     int
     transformer     *DataTransformer
     
     // Error handling
     errorHandler      func(error)
     disconnectHandler func()
     reconnectHandler  func()
+    candleHandler     func(symbol string, candle models.Candle)
+    saleHandler       func(trade models.Trade)
 }
 
 // NewClient creates a new Tastytrade API client
@@ -62,6 +66,8 @@ func NewClient(config Config) *Client {
         reconnectHandler: func() {
             log.Println("DXLink reconnected")
         },
+        candleHandler: func(string, models.Candle) {},
+        saleHandler:   func(models.Trade) {},
     }
 }
 
@@ -157,7 +163,14 @@ func (c *Client) ConnectDXLink(ctx context.Context) error {
 // Subscribe sets up a channel and subscribes to market data
 func (c *Client) Subscribe(ctx context.Context, channel int, subscriptions []DXSubscription) error {
     // Store subscriptions for reconnection
+    c.mu.Lock()
     c.subscriptions = subscriptions
+    c.subscribers = make(map[DXSubscription]int, len(subscriptions))
+    for _, sub := range subscriptions {
+        c.subscribers[sub] = 1
+    }
+    c.feedChannel = channel
+    c.mu.Unlock()
 
     // Open channel
     channelReq := DXChannelRequest{
@@ -183,9 +196,13 @@ func (c *Client) Subscribe(ctx context.Context, channel int, subscriptions []DXS
         AcceptAggregationPeriod: 0.1,
         AcceptDataFormat:        dataFormat,
         AcceptEventFields: map[string][]string{
-            "Quote":  {"eventType", "eventSymbol", "bidPrice", "askPrice", "bidSize", "askSize"},
-            "Greeks": {"eventType", "eventSymbol", "volatility", "delta", "gamma", "theta", "rho", "vega"},
-            "Trade":  {"eventType", "eventSymbol", "price", "dayVolume", "size"},
+            "Quote":       {"eventType", "eventSymbol", "bidPrice", "askPrice", "bidSize", "askSize"},
+            "Greeks":      {"eventType", "eventSymbol", "volatility", "delta", "gamma", "theta", "rho", "vega"},
+            "Trade":       {"eventType", "eventSymbol", "price", "dayVolume", "size"},
+            "Summary":     {"eventType", "eventSymbol", "openInterest"},
+            "Candle":      {"eventType", "eventSymbol", "time", "open", "high", "low", "close"},
+            "TimeAndSale": {"eventType", "eventSymbol", "time", "exchangeCode", "price", "size",
+                               "bidPrice", "askPrice", "aggressorSide", "spreadLeg"},
         },
     }
     if err := c.writeJSON(feedSetup); err != nil {
@@ -208,6 +225,96 @@ func (c *Client) Subscribe(ctx context.Context, channel int, subscriptions []DXS
     return nil
 }
 
+// AddSubscriptions subscribes to more market data on the channel opened by
+// Subscribe. Events already subscribed are not requested again, but each
+// request holds them until a matching RemoveSubscriptions.
+func (c *Client) AddSubscriptions(subscriptions []DXSubscription) error {
+    c.mu.Lock()
+    if c.subscribers == nil {
+        c.subscribers = make(map[DXSubscription]int)
+    }
+    var add []DXSubscription
+    for _, sub := range subscriptions {
+        c.subscribers[sub]++
+        if c.subscribers[sub] == 1 {
+            add = append(add, sub)
+        }
+    }
+    c.subscriptions = append(c.subscriptions, add...)
+    channel := c.feedChannel
+    c.mu.Unlock()
+
+    if len(add) == 0 {
+        return nil
+    }
+    sub := DXFeedSubscription{
+        DXMessage: DXMessage{
+            Type:    "FEED_SUBSCRIPTION",
+            Channel: channel,
+        },
+        Add: add,
+    }
+    if err := c.writeJSON(sub); err != nil {
+        return fmt.Errorf("adding feed subscriptions: %w", err)
+    }
+    return nil
+}
+
+// RemoveSubscriptions releases events requested with AddSubscriptions,
+// unsubscribing those no other request still holds
+func (c *Client) RemoveSubscriptions(subscriptions []DXSubscription) error {
+    c.mu.Lock()
+    var remove []DXSubscription
+    for _, sub := range subscriptions {
+        if c.subscribers[sub] == 0 {
+            continue
+        }
+        c.subscribers[sub]--
+        if c.subscribers[sub] == 0 {
+            delete(c.subscribers, sub)
+            remove = append(remove, sub)
+        }
+    }
+    if len(remove) > 0 {
+        kept := make([]DXSubscription, 0, len(c.subscriptions))
+        for _, sub := range c.subscriptions {
+            if _, ok := c.subscribers[sub]; ok {
+                kept = append(kept, sub)
+            }
+        }
+        c.subscriptions = kept
+    }
+    channel := c.feedChannel
+    c.mu.Unlock()
+
+    if len(remove) == 0 {
+        return nil
+    }
+    sub := DXFeedSubscription{
+        DXMessage: DXMessage{
+            Type:    "FEED_SUBSCRIPTION",
+            Channel: channel,
+        },
+        Remove: remove,
+    }
+    if err := c.writeJSON(sub); err != nil {
+        return fmt.Errorf("removing feed subscriptions: %w", err)
+    }
+    return nil
+}
+
+// OptionSubscriptions returns the events that make up a contract in the
+// aggregated chain
+func OptionSubscriptions(symbol string) []DXSubscription {
+    return []DXSubscription{
+        {Type: "Quote", Symbol: symbol},
+        {Type: "Greeks", Symbol: symbol},
+        {Type: "Trade", Symbol: symbol},
+        {Type: "Summary", Symbol: symbol},
+        {Type: "TimeAndSale", Symbol: symbol},
+    }
+}
+
 // StartReading starts reading market data events
 func (c *Client) StartReading(ctx context.Context, callback func(models.OptionChain)) {
     go func() {
@@ -222,6 +329,20 @@ func (c *Client) StartReading(ctx context.Context, callback func(models.OptionCh
                     c.errorHandler(fmt.Errorf("reading market data: %w", err))
                     return
                 }
+                if event.EventType == "Candle" {
+                    c.candleHandler(candleUnderlying(event.EventSymbol), models.Candle{
+                        Time:  time.UnixMilli(event.Time),
+                        Open:  event.Open,
+                        High:  event.High,
+                        Low:   event.Low,
+                        Close: event.Close,
+                    })
+                    continue
+                }
+                if event.EventType == "TimeAndSale" {
+                    c.saleHandler(timeAndSale(event))
+                    continue
+                }
                 c.transformer.HandleEvent(event)
                 chain := c.transformer.GetOptionChain(event.EventSymbol)
                 callback(chain)
@@ -282,6 +403,21 @@ func (c *Client) SetDisconnectHandler(handler func()) {
     c.disconnectHandler = handler
 }
 
+// SetCandleHandler sets the handler for candle events, which carry the
+// underlying symbol without its candle period
+func (c *Client) SetCandleHandler(handler func(symbol string, candle models.Candle)) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.candleHandler = handler
+}
+
+// SetTimeAndSaleHandler sets the handler for time and sale prints
+func (c *Client) SetTimeAndSaleHandler(handler func(trade models.Trade)) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.saleHandler = handler
+}
+
 // SetReconnectHandler sets the handler for successful reconnections
 func (c *Client) SetReconnectHandler(handler func()) {
     c.mu.Lock()
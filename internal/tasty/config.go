@@ -39,6 +39,7 @@ type Config struct {
     WSPingTimeout  time.Duration
     WSWriteTimeout time.Duration
     WSReadTimeout  time.Duration
+    WSDefaultRate  float64 // chain updates per second for clients that do not ask for a rate (0 = unthrottled)
 
     // Rate limiting
     RateLimitRequests int
@@ -88,6 +89,7 @@ func LoadConfig() (*Config, error) {
     config.WSPingTimeout = getDurationOrDefault("WS_PING_TIMEOUT", 10*time.Second)
     config.WSWriteTimeout = getDurationOrDefault("WS_WRITE_TIMEOUT", 15*time.Second)
     config.WSReadTimeout = getDurationOrDefault("WS_READ_TIMEOUT", 15*time.Second)
+    config.WSDefaultRate = getFloatOrDefault("WS_DEFAULT_RATE", 4)
 
     // Load rate limiting settings
     config.RateLimitRequests = getIntOrDefault("RATE_LIMIT_REQUESTS", 10)
@@ -122,6 +124,18 @@ func getIntOrDefault(key string, defaultValue int) int {
     return val
 }
 
+func getFloatOrDefault(key string, defaultValue float64) float64 {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    val, err := strconv.ParseFloat(str, 64)
+    if err != nil {
+        return defaultValue
+    }
+    return val
+}
+
 func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
     str := os.Getenv(key)
     if str == "" {
@@ -144,6 +158,9 @@ func validateConfig(config *Config) error {
     if config.WSPingInterval <= 0 {
         return fmt.Errorf("invalid websocket ping interval")
     }
+    if config.WSDefaultRate < 0 {
+        return fmt.Errorf("invalid websocket default rate")
+    }
     if config.RateLimitRequests <= 0 {
         return fmt.Errorf("invalid rate limit requests")
     }
@@ -1,12 +1,20 @@
 package tasty
 
 import (
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
     "sync"
     "time"
 
     "github.com/ryanhamamura/options-chain-go/internal/models"
 )
 
+// optionSymbolPattern matches DXLink option streamer symbols such as
+// ".SPY240119C450" or ".SPXW240119P4500.5"
+var optionSymbolPattern = regexp.MustCompile(`^\.([A-Z0-9/]+?)(\d{6})([CP])(\d+(?:\.\d+)?)$`)
+
 // DataTransformer aggregates and transforms DXLink market data
 type DataTransformer struct {
     mu sync.RWMutex
@@ -15,14 +23,17 @@ type DataTransformer struct {
     greeks  map[string]MarketDataEvent
     trades  map[string]MarketDataEvent
     summary map[string]MarketDataEvent
+    // Option symbols seen so far, grouped by underlying
+    contracts map[string]map[string]struct{}
 }
 
 func NewDataTransformer() *DataTransformer {
     return &DataTransformer{
-        quotes:  make(map[string]MarketDataEvent),
-        greeks:  make(map[string]MarketDataEvent),
-        trades:  make(map[string]MarketDataEvent),
-        summary: make(map[string]MarketDataEvent),
+        quotes:    make(map[string]MarketDataEvent),
+        greeks:    make(map[string]MarketDataEvent),
+        trades:    make(map[string]MarketDataEvent),
+        summary:   make(map[string]MarketDataEvent),
+        contracts: make(map[string]map[string]struct{}),
     }
 }
 
@@ -41,65 +52,168 @@ func (t *DataTransformer) HandleEvent(event MarketDataEvent) {
     case "Summary":
         t.summary[event.EventSymbol] = event
     }
+
+    // Index option contracts under their underlying
+    if underlying := parseUnderlyingFromSymbol(event.EventSymbol); underlying != "" {
+        if t.contracts[underlying] == nil {
+            t.contracts[underlying] = make(map[string]struct{})
+        }
+        t.contracts[underlying][event.EventSymbol] = struct{}{}
+    }
 }
 
-// GetOptionChain generates an option chain from the latest market data
+// GetOptionChain generates the full option chain for the underlying of the
+// given symbol from the latest market data. Both option and underlying
+// symbols are accepted.
 func (t *DataTransformer) GetOptionChain(symbol string) models.OptionChain {
     t.mu.RLock()
     defer t.mu.RUnlock()
 
+    underlying := symbol
+    if root := parseUnderlyingFromSymbol(symbol); root != "" {
+        underlying = root
+    }
+
+    // Create the chain
+    chain := models.OptionChain{
+        Symbol:     underlying,
+        Underlying: t.underlyingPrice(underlying),
+        Updated:    time.Now(),
+    }
+
+    for contract := range t.contracts[underlying] {
+        optionData := t.optionData(contract)
+
+        // Sort into calls and puts
+        if optionData.Type == "call" {
+            chain.Calls = append(chain.Calls, optionData)
+        } else {
+            chain.Puts = append(chain.Puts, optionData)
+        }
+    }
+
+    sortOptions(chain.Calls)
+    sortOptions(chain.Puts)
+
+    return chain
+}
+
+// optionData transforms the cached events of a single contract into
+// OptionData. Callers must hold the read lock.
+func (t *DataTransformer) optionData(symbol string) models.OptionData {
     // Get latest data for the symbol
     quote := t.quotes[symbol]
     greeks := t.greeks[symbol]
     trade := t.trades[symbol]
+    summary := t.summary[symbol]
 
-    // Transform into OptionData
-    optionData := models.OptionData{
-        Strike:     parseStrikeFromSymbol(symbol),  // You'll need to implement this
-        Expiration: parseExpirationFromSymbol(symbol), // And this
-        Type:       parseOptionType(symbol),        // And this
+    return models.OptionData{
+        Symbol:     symbol,
+        Strike:     parseStrikeFromSymbol(symbol),
+        Expiration: parseExpirationFromSymbol(symbol),
+        Type:       parseOptionType(symbol),
         Bid:        quote.BidPrice,
         Ask:        quote.AskPrice,
         LastPrice:  trade.Price,
         Volume:     int(trade.DayVolume),
-        OpenInt:    0, // Get from Summary event if available
+        OpenInt:    int(summary.OpenInterest),
         Delta:      greeks.Delta,
         Gamma:      greeks.Gamma,
         Theta:      greeks.Theta,
         Vega:       greeks.Vega,
+        Rho:        greeks.Rho,
         ImpliedVol: greeks.Volatility,
     }
+}
 
-    // Create the chain
-    chain := models.OptionChain{
-        Symbol:     symbol,
-        Underlying: trade.Price,
-        Updated:    time.Now(),
+// underlyingPrice returns the last trade price of the underlying, falling
+// back to the quote midpoint. Callers must hold the read lock.
+func (t *DataTransformer) underlyingPrice(symbol string) float64 {
+    if trade, ok := t.trades[symbol]; ok && trade.Price > 0 {
+        return trade.Price
     }
-
-    // Sort into calls and puts
-    if optionData.Type == "call" {
-        chain.Calls = append(chain.Calls, optionData)
-    } else {
-        chain.Puts = append(chain.Puts, optionData)
+    if quote, ok := t.quotes[symbol]; ok && quote.BidPrice > 0 && quote.AskPrice > 0 {
+        return (quote.BidPrice + quote.AskPrice) / 2
     }
+    return 0
+}
 
-    return chain
+// sortOptions orders contracts by expiration and then strike
+func sortOptions(options []models.OptionData) {
+    sort.Slice(options, func(i, j int) bool {
+        if options[i].Expiration != options[j].Expiration {
+            return options[i].Expiration < options[j].Expiration
+        }
+        return options[i].Strike < options[j].Strike
+    })
+}
+
+// Helper functions for parsing DXLink option symbols
+// (.<root><yymmdd><C|P><strike>)
+func parseUnderlyingFromSymbol(symbol string) string {
+    m := optionSymbolPattern.FindStringSubmatch(symbol)
+    if m == nil {
+        return ""
+    }
+    return m[1]
 }
 
-// Helper functions for parsing option symbols
-// You'll need to implement these based on Tastytrade's symbol format
 func parseStrikeFromSymbol(symbol string) float64 {
-    // Implementation depends on symbol format
-    return 0
+    m := optionSymbolPattern.FindStringSubmatch(symbol)
+    if m == nil {
+        return 0
+    }
+    strike, err := strconv.ParseFloat(m[4], 64)
+    if err != nil {
+        return 0
+    }
+    return strike
 }
 
 func parseExpirationFromSymbol(symbol string) string {
-    // Implementation depends on symbol format
-    return ""
+    m := optionSymbolPattern.FindStringSubmatch(symbol)
+    if m == nil {
+        return ""
+    }
+    expiration, err := time.Parse("060102", m[2])
+    if err != nil {
+        return ""
+    }
+    return expiration.Format("2006-01-02")
+}
+
+// candleUnderlying strips the period from a candle symbol such as
+// "SPY{=1d}"
+func candleUnderlying(symbol string) string {
+    if i := strings.Index(symbol, "{"); i >= 0 {
+        return symbol[:i]
+    }
+    return symbol
+}
+
+// timeAndSale converts a TimeAndSale event to a print
+func timeAndSale(event MarketDataEvent) models.Trade {
+    return models.Trade{
+        Symbol:     event.EventSymbol,
+        Underlying: parseUnderlyingFromSymbol(event.EventSymbol),
+        Time:       time.UnixMilli(event.Time),
+        Price:      event.Price,
+        Size:       event.Size,
+        Exchange:   event.ExchangeCode,
+        Side:       strings.ToLower(strings.TrimPrefix(event.AggressorSide, "UNDEFINED")),
+        Bid:        event.BidPrice,
+        Ask:        event.AskPrice,
+        SpreadLeg:  event.SpreadLeg,
+    }
 }
 
 func parseOptionType(symbol string) string {
-    // Implementation depends on symbol format
-    return ""
+    m := optionSymbolPattern.FindStringSubmatch(symbol)
+    if m == nil {
+        return ""
+    }
+    if m[3] == "C" {
+        return "call"
+    }
+    return "put"
 }
@@ -49,8 +49,9 @@ type DXFeedSetup struct {
 
 // DXSubscription represents a single market data subscription
 type DXSubscription struct {
-    Type   string `json:"type"`
-    Symbol string `json:"symbol"`
+    Type     string `json:"type"`
+    Symbol   string `json:"symbol"`
+    FromTime int64  `json:"fromTime,omitempty"` // candle history start, Unix milliseconds
 }
 
 // DXFeedSubscription for subscribing to market data
@@ -85,4 +86,19 @@ type MarketDataEvent struct {
     Price      float64 `json:"price,omitempty"`
     DayVolume  float64 `json:"dayVolume,omitempty"`
     Size       float64 `json:"size,omitempty"`
+
+    // Summary fields
+    OpenInterest float64 `json:"openInterest,omitempty"`
+
+    // Time and sale fields, with the time and the quote fields
+    ExchangeCode  string `json:"exchangeCode,omitempty"`
+    AggressorSide string `json:"aggressorSide,omitempty"` // BUY, SELL or UNDEFINED
+    SpreadLeg     bool   `json:"spreadLeg,omitempty"`
+
+    // Candle fields
+    Time  int64   `json:"time,omitempty"` // Unix milliseconds
+    Open  float64 `json:"open,omitempty"`
+    High  float64 `json:"high,omitempty"`
+    Low   float64 `json:"low,omitempty"`
+    Close float64 `json:"close,omitempty"`
 }
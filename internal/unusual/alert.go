@@ -0,0 +1,55 @@
+package unusual
+
+import (
+    "fmt"
+    "time"
+)
+
+// Kind is the kind of activity an alert flags
+type Kind string
+
+const (
+    KindVolume   Kind = "volume"    // day volume far above its daily average
+    KindVolumeOI Kind = "volume_oi" // day volume above open interest, likely new positions
+    KindBlock    Kind = "block"     // a single large print
+    KindSweep    Kind = "sweep"     // one side taking liquidity across several exchanges at once
+)
+
+// Kinds lists every alert kind
+var Kinds = []Kind{KindVolume, KindVolumeOI, KindBlock, KindSweep}
+
+// ParseKind parses an alert kind name
+func ParseKind(name string) (Kind, error) {
+    for _, k := range Kinds {
+        if Kind(name) == k {
+            return k, nil
+        }
+    }
+    return "", fmt.Errorf("unknown alert kind: %q", name)
+}
+
+// Alert is a contract flagged for unusual activity. Print alerts carry the
+// side, size, average price and premium of the print or sweep; volume
+// alerts carry the day volume against its reference in Ratio.
+type Alert struct {
+    ID              uint64    `json:"id"`
+    Time            time.Time `json:"time"`
+    Kind            Kind      `json:"kind"`
+    Underlying      string    `json:"underlying"`
+    UnderlyingPrice float64   `json:"underlyingPrice"`
+    Contract        string    `json:"contract"`
+    Expiration      string    `json:"expiration"`
+    Strike          float64   `json:"strike"`
+    Type            string    `json:"type"`
+
+    Side      string   `json:"side,omitempty"`
+    Size      float64  `json:"size,omitempty"`
+    Price     float64  `json:"price,omitempty"`
+    Premium   float64  `json:"premium,omitempty"` // dollars
+    Exchanges []string `json:"exchanges,omitempty"`
+
+    Volume        int     `json:"volume"`
+    OpenInterest  int     `json:"openInterest"`
+    AverageVolume float64 `json:"averageVolume,omitempty"` // daily, over the lookback
+    Ratio         float64 `json:"ratio,omitempty"`         // volume over average volume or open interest
+}
@@ -0,0 +1,93 @@
+package unusual
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "time"
+)
+
+// Config holds the unusual activity thresholds
+type Config struct {
+    MinVolume      int           // day volume below which volume alerts are not raised
+    VolumeMultiple float64       // day volume over its daily average that is unusual
+    VolumeOIRatio  float64       // day volume over open interest that is unusual
+    BlockSize      float64       // contracts in a single print that make it a block
+    BlockPremium   float64       // dollars in a single print that make it a block
+    SweepExchanges int           // exchanges one side must hit within the window to sweep
+    SweepWindow    time.Duration // time from the first print of a sweep to its last
+    SweepMinSize   float64       // contracts a sweep must total
+    Lookback       int           // calendar days of daily volume averaged
+    LogWindow      time.Duration // how long alerts stay in the log
+    HistoryFile    string        // file the daily volume history persists to, if set
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+    config := &Config{
+        MinVolume:      getIntOrDefault("UNUSUAL_MIN_VOLUME", 100),
+        VolumeMultiple: getFloatOrDefault("UNUSUAL_VOLUME_MULTIPLE", 5),
+        VolumeOIRatio:  getFloatOrDefault("UNUSUAL_VOLUME_OI_RATIO", 1),
+        BlockSize:      getFloatOrDefault("UNUSUAL_BLOCK_SIZE", 250),
+        BlockPremium:   getFloatOrDefault("UNUSUAL_BLOCK_PREMIUM", 100000),
+        SweepExchanges: getIntOrDefault("UNUSUAL_SWEEP_EXCHANGES", 3),
+        SweepWindow:    getDurationOrDefault("UNUSUAL_SWEEP_WINDOW", time.Second),
+        SweepMinSize:   getFloatOrDefault("UNUSUAL_SWEEP_MIN_SIZE", 50),
+        Lookback:       getIntOrDefault("UNUSUAL_LOOKBACK", 30),
+        LogWindow:      getDurationOrDefault("UNUSUAL_LOG_WINDOW", 24*time.Hour),
+        HistoryFile:    os.Getenv("UNUSUAL_HISTORY_FILE"),
+    }
+
+    switch {
+    case config.VolumeMultiple <= 1:
+        return nil, fmt.Errorf("invalid unusual volume multiple: %v", config.VolumeMultiple)
+    case config.VolumeOIRatio <= 0:
+        return nil, fmt.Errorf("invalid unusual volume/OI ratio: %v", config.VolumeOIRatio)
+    case config.SweepExchanges < 2:
+        return nil, fmt.Errorf("invalid sweep exchange count: %d", config.SweepExchanges)
+    case config.SweepWindow <= 0:
+        return nil, fmt.Errorf("invalid sweep window: %v", config.SweepWindow)
+    case config.Lookback < minHistoryDays:
+        return nil, fmt.Errorf("invalid unusual volume lookback: %d", config.Lookback)
+    case config.LogWindow <= 0:
+        return nil, fmt.Errorf("invalid unusual alert log window: %v", config.LogWindow)
+    }
+
+    return config, nil
+}
+
+func getIntOrDefault(key string, defaultValue int) int {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := strconv.Atoi(str)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
+
+func getFloatOrDefault(key string, defaultValue float64) float64 {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    value, err := strconv.ParseFloat(str, 64)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
+
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+    str := os.Getenv(key)
+    if str == "" {
+        return defaultValue
+    }
+    duration, err := time.ParseDuration(str)
+    if err != nil {
+        return defaultValue
+    }
+    return duration
+}
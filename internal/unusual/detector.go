@@ -0,0 +1,360 @@
+package unusual
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log"
+    "os"
+    "sort"
+    "sync"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+const (
+    // multiplier is the number of shares per contract
+    multiplier = 100
+
+    // minHistoryDays is the daily volume history needed for volume alerts
+    minHistoryDays = 5
+)
+
+// volumeSample is the volume of a contract on one day
+type volumeSample struct {
+    Date   string `json:"date"` // 2006-01-02
+    Volume int    `json:"volume"`
+}
+
+// contractState is the latest chain data of a contract
+type contractState struct {
+    underlying string
+    price      float64 // underlying price
+    option     models.OptionData
+}
+
+// sweep is a run of prints on one side of a contract
+type sweep struct {
+    side      string
+    start     time.Time
+    exchanges map[string]bool
+    size      float64
+    notional  float64
+    alerted   bool
+}
+
+// Detector flags unusual option activity from time and sale prints and
+// the aggregated chains, keeping the alerts of a rolling window
+type Detector struct {
+    config  Config
+    publish func(Alert)
+    now     func() time.Time
+
+    mu        sync.RWMutex
+    day       string
+    history   map[string][]volumeSample // by contract, by date
+    contracts map[string]contractState
+    flagged   map[string]bool // contract and kind volume alerts raised today
+    sweeps    map[string]*sweep
+    alerts    []Alert // by time, oldest first
+    nextID    uint64
+}
+
+// NewDetector creates a detector that sends each alert to publish, loading
+// the volume history file if one is configured
+func NewDetector(config Config, publish func(Alert)) (*Detector, error) {
+    d := &Detector{
+        config:    config,
+        publish:   publish,
+        now:       time.Now,
+        history:   make(map[string][]volumeSample),
+        contracts: make(map[string]contractState),
+        flagged:   make(map[string]bool),
+        sweeps:    make(map[string]*sweep),
+    }
+    if config.HistoryFile == "" {
+        return d, nil
+    }
+
+    data, err := os.ReadFile(config.HistoryFile)
+    if errors.Is(err, os.ErrNotExist) {
+        return d, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("reading volume history: %w", err)
+    }
+    if err := json.Unmarshal(data, &d.history); err != nil {
+        return nil, fmt.Errorf("parsing volume history: %w", err)
+    }
+    return d, nil
+}
+
+// Observe records the day volume of each contract of a chain and flags
+// volume far above its daily average or above open interest. Each is
+// flagged once a day per contract.
+func (d *Detector) Observe(chain models.OptionChain) {
+    now := d.now()
+    var alerts []Alert
+
+    d.mu.Lock()
+    d.rollover(now)
+    for _, options := range [][]models.OptionData{chain.Calls, chain.Puts} {
+        for _, option := range options {
+            state := contractState{underlying: chain.Symbol, price: chain.Underlying, option: option}
+            d.contracts[option.Symbol] = state
+            d.record(option.Symbol, option.Volume)
+
+            if option.Volume < d.config.MinVolume {
+                continue
+            }
+            volume := float64(option.Volume)
+            if avg := d.average(option.Symbol); avg > 0 && volume >= d.config.VolumeMultiple*avg {
+                alert := d.alert(KindVolume, state, now)
+                alert.AverageVolume = avg
+                alert.Ratio = volume / avg
+                alerts = d.flagOnce(alerts, alert)
+            }
+            if option.OpenInt > 0 && volume >= d.config.VolumeOIRatio*float64(option.OpenInt) {
+                alert := d.alert(KindVolumeOI, state, now)
+                alert.Ratio = volume / float64(option.OpenInt)
+                alerts = d.flagOnce(alerts, alert)
+            }
+        }
+    }
+    d.mu.Unlock()
+
+    for _, alert := range alerts {
+        d.publish(alert)
+    }
+}
+
+// AddTrade flags an option print that is a block by size or premium, or
+// that completes a sweep: prints on one side across enough exchanges
+// within the sweep window. Spread legs and prints of unknown side do not
+// count towards sweeps.
+func (d *Detector) AddTrade(trade models.Trade) {
+    if trade.Underlying == "" || trade.Size <= 0 || trade.Price <= 0 {
+        return
+    }
+    if trade.Time.IsZero() {
+        trade.Time = d.now()
+    }
+    var alerts []Alert
+
+    d.mu.Lock()
+    d.rollover(d.now())
+    state, ok := d.contracts[trade.Symbol]
+    if !ok {
+        state = contractState{underlying: trade.Underlying, option: models.OptionData{Symbol: trade.Symbol}}
+    }
+    side := aggressor(trade)
+    premium := trade.Price * trade.Size * multiplier
+
+    if trade.Size >= d.config.BlockSize || premium >= d.config.BlockPremium {
+        alert := d.alert(KindBlock, state, trade.Time)
+        alert.Side = side
+        alert.Size = trade.Size
+        alert.Price = trade.Price
+        alert.Premium = premium
+        if trade.Exchange != "" {
+            alert.Exchanges = []string{trade.Exchange}
+        }
+        alerts = append(alerts, d.logAlert(alert))
+    }
+
+    if !trade.SpreadLeg && side != "" {
+        s := d.sweeps[trade.Symbol]
+        if s == nil || s.side != side || trade.Time.Sub(s.start) > d.config.SweepWindow {
+            s = &sweep{side: side, start: trade.Time, exchanges: make(map[string]bool)}
+            d.sweeps[trade.Symbol] = s
+        }
+        s.exchanges[trade.Exchange] = true
+        s.size += trade.Size
+        s.notional += premium
+        if !s.alerted && len(s.exchanges) >= d.config.SweepExchanges && s.size >= d.config.SweepMinSize {
+            s.alerted = true
+            alert := d.alert(KindSweep, state, trade.Time)
+            alert.Side = side
+            alert.Size = s.size
+            alert.Price = s.notional / s.size / multiplier
+            alert.Premium = s.notional
+            alert.Exchanges = sortedKeys(s.exchanges)
+            alerts = append(alerts, d.logAlert(alert))
+        }
+    }
+    d.mu.Unlock()
+
+    for _, alert := range alerts {
+        d.publish(alert)
+    }
+}
+
+// Alerts returns the logged alerts, newest first, optionally only those of
+// an underlying or of a kind
+func (d *Detector) Alerts(underlying string, kind Kind) []Alert {
+    d.mu.RLock()
+    defer d.mu.RUnlock()
+
+    cutoff := d.now().Add(-d.config.LogWindow)
+    alerts := []Alert{}
+    for i := len(d.alerts) - 1; i >= 0; i-- {
+        a := d.alerts[i]
+        if a.Time.Before(cutoff) {
+            break
+        }
+        if (underlying == "" || a.Underlying == underlying) && (kind == "" || a.Kind == kind) {
+            alerts = append(alerts, a)
+        }
+    }
+    return alerts
+}
+
+// Save writes the volume history file, if one is configured
+func (d *Detector) Save() error {
+    d.mu.RLock()
+    defer d.mu.RUnlock()
+    return d.save()
+}
+
+// save writes the volume history file. Callers must hold the lock.
+func (d *Detector) save() error {
+    if d.config.HistoryFile == "" {
+        return nil
+    }
+    data, err := json.Marshal(d.history)
+    if err != nil {
+        return fmt.Errorf("encoding volume history: %w", err)
+    }
+    if err := os.WriteFile(d.config.HistoryFile, data, 0o644); err != nil {
+        return fmt.Errorf("writing volume history: %w", err)
+    }
+    return nil
+}
+
+// rollover starts a new day: it trims the volume history to the lookback,
+// writes it and resets the day's flags and sweeps. Callers must hold the
+// lock.
+func (d *Detector) rollover(now time.Time) {
+    today := now.Format("2006-01-02")
+    if today == d.day {
+        return
+    }
+    first := d.day == ""
+    d.day = today
+    d.flagged = make(map[string]bool)
+    d.sweeps = make(map[string]*sweep)
+
+    cutoff := now.AddDate(0, 0, -d.config.Lookback).Format("2006-01-02")
+    for contract, samples := range d.history {
+        for len(samples) > 0 && samples[0].Date < cutoff {
+            samples = samples[1:]
+        }
+        if len(samples) == 0 {
+            delete(d.history, contract)
+            continue
+        }
+        d.history[contract] = samples
+    }
+    if first {
+        return
+    }
+    if err := d.save(); err != nil {
+        log.Printf("Error saving volume history: %v", err)
+    }
+}
+
+// record sets the volume of a contract for the day. Callers must hold the
+// lock.
+func (d *Detector) record(contract string, volume int) {
+    samples := d.history[contract]
+    if n := len(samples); n > 0 && samples[n-1].Date == d.day {
+        samples[n-1].Volume = volume
+        return
+    }
+    d.history[contract] = append(samples, volumeSample{Date: d.day, Volume: volume})
+}
+
+// average returns the mean daily volume of a contract before today, or 0
+// with too little history. Callers must hold the lock.
+func (d *Detector) average(contract string) float64 {
+    var total, days int
+    for _, sample := range d.history[contract] {
+        if sample.Date != d.day {
+            total += sample.Volume
+            days++
+        }
+    }
+    if days < minHistoryDays {
+        return 0
+    }
+    return float64(total) / float64(days)
+}
+
+// alert starts an alert on a contract
+func (d *Detector) alert(kind Kind, state contractState, at time.Time) Alert {
+    return Alert{
+        Time:            at,
+        Kind:            kind,
+        Underlying:      state.underlying,
+        UnderlyingPrice: state.price,
+        Contract:        state.option.Symbol,
+        Expiration:      state.option.Expiration,
+        Strike:          state.option.Strike,
+        Type:            state.option.Type,
+        Volume:          state.option.Volume,
+        OpenInterest:    state.option.OpenInt,
+    }
+}
+
+// flagOnce logs a volume alert unless its contract was already flagged
+// for the kind today. Callers must hold the lock.
+func (d *Detector) flagOnce(alerts []Alert, alert Alert) []Alert {
+    key := alert.Contract + ":" + string(alert.Kind)
+    if d.flagged[key] {
+        return alerts
+    }
+    d.flagged[key] = true
+    return append(alerts, d.logAlert(alert))
+}
+
+// logAlert numbers an alert and adds it to the log, dropping alerts older than
+// the log window. Prints can arrive late, so the alert goes in at its time
+// to keep the log sorted. Callers must hold the lock.
+func (d *Detector) logAlert(alert Alert) Alert {
+    d.nextID++
+    alert.ID = d.nextID
+    at := sort.Search(len(d.alerts), func(i int) bool { return d.alerts[i].Time.After(alert.Time) })
+    d.alerts = append(d.alerts, Alert{})
+    copy(d.alerts[at+1:], d.alerts[at:])
+    d.alerts[at] = alert
+
+    cutoff := d.now().Add(-d.config.LogWindow)
+    i := sort.Search(len(d.alerts), func(i int) bool { return !d.alerts[i].Time.Before(cutoff) })
+    d.alerts = d.alerts[i:]
+    return alert
+}
+
+// aggressor returns the side that took liquidity in a print, from the
+// feed or else from the print's price against the quote
+func aggressor(trade models.Trade) string {
+    switch {
+    case trade.Side != "":
+        return trade.Side
+    case trade.Ask > 0 && trade.Price >= trade.Ask:
+        return "buy"
+    case trade.Bid > 0 && trade.Price <= trade.Bid:
+        return "sell"
+    }
+    return ""
+}
+
+// sortedKeys returns the members of a set in order
+func sortedKeys(set map[string]bool) []string {
+    keys := make([]string, 0, len(set))
+    for key := range set {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys
+}
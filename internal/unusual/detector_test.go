@@ -0,0 +1,40 @@
+package unusual
+
+import (
+    "testing"
+    "time"
+
+    "github.com/ryanhamamura/options-chain-go/internal/models"
+)
+
+func TestAlertsWithLatePrints(t *testing.T) {
+    now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+    d, err := NewDetector(Config{BlockSize: 100, BlockPremium: 1e9, SweepExchanges: 3, LogWindow: time.Hour}, func(Alert) {})
+    if err != nil {
+        t.Fatal(err)
+    }
+    d.now = func() time.Time { return now }
+
+    // Blocks printed 10, 50 and 30 minutes ago, then one replayed from
+    // before the window
+    for _, minutes := range []int{10, 50, 30, 90} {
+        d.AddTrade(models.Trade{
+            Symbol:     ".SPY261218C600",
+            Underlying: "SPY",
+            Time:       now.Add(-time.Duration(minutes) * time.Minute),
+            Price:      1,
+            Size:       100,
+        })
+    }
+
+    alerts := d.Alerts("", "")
+    want := []int{10, 30, 50}
+    if len(alerts) != len(want) {
+        t.Fatalf("got %d alerts, want the %d inside the window", len(alerts), len(want))
+    }
+    for i, minutes := range want {
+        if at := now.Add(-time.Duration(minutes) * time.Minute); !alerts[i].Time.Equal(at) {
+            t.Errorf("alert %d at %v, want %v", i, alerts[i].Time, at)
+        }
+    }
+}
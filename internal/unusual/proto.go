@@ -0,0 +1,48 @@
+package unusual
+
+import (
+    optionsv1 "github.com/ryanhamamura/options-chain-go/internal/pb/options/v1"
+    "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Message carries an unusual activity alert on an underlying to stream
+// clients
+type Message struct {
+    Type   string `json:"type"` // "unusual"
+    Symbol string `json:"symbol"`
+    Alert  Alert  `json:"alert"`
+}
+
+// NewMessage wraps an alert for streaming
+func NewMessage(a Alert) Message {
+    return Message{Type: "unusual", Symbol: a.Underlying, Alert: a}
+}
+
+// ServerMessage returns the message in its protobuf form
+func (m Message) ServerMessage() *optionsv1.ServerMessage {
+    return &optionsv1.ServerMessage{Message: &optionsv1.ServerMessage_Unusual{Unusual: m.Alert.Proto()}}
+}
+
+// Proto converts the alert to its protobuf form
+func (a Alert) Proto() *optionsv1.UnusualAlert {
+    return &optionsv1.UnusualAlert{
+        Id:              a.ID,
+        Time:            timestamppb.New(a.Time),
+        Kind:            string(a.Kind),
+        Underlying:      a.Underlying,
+        UnderlyingPrice: a.UnderlyingPrice,
+        Contract:        a.Contract,
+        Expiration:      a.Expiration,
+        Strike:          a.Strike,
+        Type:            a.Type,
+        Side:            a.Side,
+        Size:            a.Size,
+        Price:           a.Price,
+        Premium:         a.Premium,
+        Exchanges:       a.Exchanges,
+        Volume:          int64(a.Volume),
+        OpenInterest:    int64(a.OpenInterest),
+        AverageVolume:   a.AverageVolume,
+        Ratio:           a.Ratio,
+    }
+}